@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// announceFunc returns the embeds of a guild announcement, rendered with the given Display
+type announceFunc func(p *Display) []*discordgo.MessageEmbed
+
+// announceToGuilds posts the embeds returned by the given announceFunc to the announce channel of each
+// guild that enabled the announcement preference k. If the guild configured a role in the preference
+// rk, the role is mentioned
+func (b *Bot) announceToGuilds(k, rk model.GuildPrefKey, f announceFunc) {
+	ll := b.Log.With().Str("context", "bot.announceToGuilds").Str("pref", string(k)).Logger()
+	gl, err := b.Model.Guild.GetGuilds()
+	if err != nil {
+		ll.Error().Msgf("failed to read guilds from DB: %s", err)
+		return
+	}
+	for _, g := range gl {
+		en, err := b.Model.Guild.GetPrefBool(g, k)
+		if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+			ll.Warn().Msgf("failed to read announcement preference from DB: %s", err)
+			continue
+		}
+		if !en {
+			continue
+		}
+		rid, err := b.Model.Guild.GetPrefString(g, rk)
+		if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+			ll.Warn().Msgf("failed to read announcement role preference from DB: %s", err)
+		}
+
+		el := f(NewDisplay(b.guildPrinter(g), DisplayPrefs{}))
+		if len(el) <= 0 {
+			continue
+		}
+		for _, e := range EmbedPages(el, MaxEmbedsPerMessage) {
+			ms := &discordgo.MessageSend{Embeds: e, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+			if rid != "" {
+				ms.Content = fmt.Sprintf("<@&%s>", rid)
+				ms.AllowedMentions.Roles = []string{rid}
+			}
+			if _, err := b.Session.ChannelMessageSendComplex(b.Model.Guild.AnnouceChannel(g), ms); err != nil {
+				ll.Error().Msgf("failed to send announcement to guild %q: %s", g.GuildID, err)
+				break
+			}
+		}
+	}
+}
+
+// announceDeeds announces the given newly published deeds in the guilds that enabled deed announcements
+func (b *Bot) announceDeeds(dl []*model.Deed) {
+	b.announceToGuilds(model.GuildPrefAnnounceDeeds, model.GuildPrefAnnounceDeedsRole,
+		func(p *Display) []*discordgo.MessageEmbed {
+			var el []*discordgo.MessageEmbed
+			for _, d := range dl {
+				e := deedEmbed(p, d)
+				if e == nil {
+					continue
+				}
+				e.Title = p.Sprintf("New deed: %s", e.Title)
+				el = append(el, e)
+			}
+			return el
+		})
+}
+
+// announceTradeRoutes announces the given trade routes in the guilds that enabled trade route
+// announcements. If nr is set, the routes belong to a newly published rotation, otherwise they are
+// changes to an already announced rotation
+func (b *Bot) announceTradeRoutes(tl []*model.TradeRoute, nr bool) {
+	b.announceToGuilds(model.GuildPrefAnnounceTradeRoutes, model.GuildPrefAnnounceTradeRoutesRole,
+		func(p *Display) []*discordgo.MessageEmbed {
+			t := p.Sprintf("Updated trade routes")
+			if nr {
+				t = p.Sprintf("New trade routes")
+			}
+			return []*discordgo.MessageEmbed{tradeRoutesEmbed(p, t, tl)}
+		})
+}
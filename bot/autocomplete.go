@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// MaxAutocompleteChoices is the maximum number of choices Discord accepts in an autocomplete response
+const MaxAutocompleteChoices = 25
+
+// autocompleteMemberSearch is the number of guild members that are searched for registered users
+const autocompleteMemberSearch = 100
+
+// AutocompleteProvider returns the choices for the focused option of an autocomplete interaction. The
+// given value is what the user has typed so far
+type AutocompleteProvider func(s DiscordAPI, i *discordgo.InteractionCreate,
+	v string) ([]*discordgo.ApplicationCommandOptionChoice, error)
+
+// autocompleteProviders returns the map of option paths and the corresponding autocomplete providers.
+// The option path consists of the command name, the names of the sub-commands and the option name,
+// separated by spaces
+func (b *Bot) autocompleteProviders() map[string]AutocompleteProvider {
+	pm := map[string]AutocompleteProvider{
+		"goal remove id":      b.AutocompleteGoals,
+		"subscribe remove id": b.AutocompleteSubscriptions,
+		"traderoutes outpost": b.AutocompleteOutposts,
+		"sell commodity":      b.AutocompleteCommodities,
+	}
+	for n := 1; n <= GraphMaxMembers; n++ {
+		pm[fmt.Sprintf("graph member-%d", n)] = b.AutocompleteMembers
+	}
+	return pm
+}
+
+// AutocompleteHandler is the central handler method for the autocomplete interactions of slash command
+// options. It will look up the provider of the focused option and respond with its choices
+func (b *Bot) AutocompleteHandler(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	s := b.Session
+
+	// We only process autocomplete interactions
+	if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+	cd := i.ApplicationCommandData()
+	op, fo := focusedOption(cd.Name, cd.Options)
+	ll := b.Log.With().Str("context", "bot.AutocompleteHandler").
+		Str("option", op).Logger()
+
+	cl := []*discordgo.ApplicationCommandOptionChoice{}
+	if ap, ok := b.autocompleteProviders()[op]; ok && fo != nil {
+		al, err := ap(s, i, fmt.Sprint(fo.Value))
+		if err != nil {
+			ll.Error().Msgf("failed to provide autocomplete choices: %s", err)
+		}
+		if err == nil && al != nil {
+			cl = al
+		}
+	}
+	if len(cl) > MaxAutocompleteChoices {
+		cl = cl[:MaxAutocompleteChoices]
+	}
+
+	r := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: cl},
+	}
+	if err := s.InteractionRespond(i.Interaction, r); err != nil {
+		ll.Error().Msgf("failed to respond to autocomplete interaction: %s", err)
+	}
+}
+
+// focusedOption returns the option path (see autocompleteProviders) and the option that is currently
+// focused by the user
+func focusedOption(p string, ol []*discordgo.ApplicationCommandInteractionDataOption,
+) (string, *discordgo.ApplicationCommandInteractionDataOption) {
+	for _, o := range ol {
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionSubCommand, discordgo.ApplicationCommandOptionSubCommandGroup:
+			if sp, fo := focusedOption(p+" "+o.Name, o.Options); fo != nil {
+				return sp, fo
+			}
+		default:
+			if o.Focused {
+				return p + " " + o.Name, o
+			}
+		}
+	}
+	return p, nil
+}
+
+// matchChoices returns the choices whose name fuzzy matches the given value, best matches first
+func matchChoices(v string, cl []*discordgo.ApplicationCommandOptionChoice,
+) []*discordgo.ApplicationCommandOptionChoice {
+	nl := make([]string, len(cl))
+	for n, c := range cl {
+		nl[n] = c.Name
+	}
+	var ml []*discordgo.ApplicationCommandOptionChoice
+	for _, n := range fuzzyFind(v, nl) {
+		ml = append(ml, cl[n])
+	}
+	return ml
+}
+
+// AutocompleteOutposts provides the outpost names of the current trade routes
+func (b *Bot) AutocompleteOutposts(_ DiscordAPI, _ *discordgo.InteractionCreate,
+	v string,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	tl, err := b.Model.TradeRoute.GetTradeRoutes(b.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trade routes from DB: %w", err)
+	}
+	var cl []*discordgo.ApplicationCommandOptionChoice
+	for _, tr := range tl {
+		cl = append(cl, &discordgo.ApplicationCommandOptionChoice{Name: tr.Outpost, Value: tr.Outpost})
+	}
+	return matchChoices(v, cl), nil
+}
+
+// AutocompleteCommodities provides the names of the commodities that are sought after or in surplus on
+// the current trade routes
+func (b *Bot) AutocompleteCommodities(_ DiscordAPI, _ *discordgo.InteractionCreate,
+	v string,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	tl, err := b.Model.TradeRoute.GetTradeRoutes(b.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trade routes from DB: %w", err)
+	}
+	c := cases.Title(language.English)
+	cm := make(map[string]bool)
+	var cl []*discordgo.ApplicationCommandOptionChoice
+	for _, tr := range tl {
+		for _, co := range []string{tr.SoughtAfter, tr.Surplus} {
+			if co == "" || cm[co] {
+				continue
+			}
+			cm[co] = true
+			cl = append(cl, &discordgo.ApplicationCommandOptionChoice{Name: c.String(co), Value: co})
+		}
+	}
+	return matchChoices(v, cl), nil
+}
+
+// AutocompleteMembers provides the registered members of the guild of the interaction whose name starts
+// with the given value. Members that keep their stats private and the requesting user are left out
+func (b *Bot) AutocompleteMembers(s DiscordAPI, i *discordgo.InteractionCreate,
+	v string,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	if i.GuildID == "" || strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+	ml, err := s.GuildMembersSearch(i.GuildID, strings.TrimSpace(v), autocompleteMemberSearch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guild members: %w", err)
+	}
+	ru := interactionUserID(i.Interaction)
+	var cl []*discordgo.ApplicationCommandOptionChoice
+	for _, m := range ml {
+		if m.User == nil || m.User.Bot || m.User.ID == ru {
+			continue
+		}
+		u, err := b.Model.User.GetByUserID(m.User.ID)
+		if err != nil {
+			continue
+		}
+		if err := b.checkStatsPrivacy(u); err != nil {
+			continue
+		}
+		cl = append(cl, &discordgo.ApplicationCommandOptionChoice{Name: memberName(m, m.User), Value: m.User.ID})
+		if len(cl) >= MaxAutocompleteChoices {
+			break
+		}
+	}
+	return cl, nil
+}
+
+// AutocompleteGoals provides the goals of the requesting user
+func (b *Bot) AutocompleteGoals(_ DiscordAPI, i *discordgo.InteractionCreate,
+	v string,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return nil, nil
+	}
+	gl, err := b.Model.UserGoal.GetByUserID(r.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user goals from DB: %w", err)
+	}
+	p := b.display(i.Interaction)
+	v = strings.ToLower(strings.TrimSpace(v))
+	var cl []*discordgo.ApplicationCommandOptionChoice
+	for _, g := range gl {
+		n := p.Sprintf("#%d %s", g.ID, goalName(p, g))
+		if !strings.Contains(strings.ToLower(n), v) {
+			continue
+		}
+		cl = append(cl, &discordgo.ApplicationCommandOptionChoice{Name: n, Value: g.ID})
+	}
+	return cl, nil
+}
+
+// AutocompleteSubscriptions provides the subscriptions of the requesting user
+func (b *Bot) AutocompleteSubscriptions(_ DiscordAPI, i *discordgo.InteractionCreate,
+	v string,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return nil, nil
+	}
+	sl, err := b.Model.UserSubscription.GetByUserID(r.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user subscriptions from DB: %w", err)
+	}
+	p := b.display(i.Interaction)
+	v = strings.ToLower(strings.TrimSpace(v))
+	var cl []*discordgo.ApplicationCommandOptionChoice
+	for _, us := range sl {
+		n := subscriptionName(p, us)
+		if !strings.Contains(strings.ToLower(n), v) {
+			continue
+		}
+		cl = append(cl, &discordgo.ApplicationCommandOptionChoice{Name: n, Value: us.ID})
+	}
+	return cl, nil
+}
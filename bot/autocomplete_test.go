@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+func TestBot_AutocompleteMembers(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		name    string
+		v       string
+		private bool
+		want    []string
+	}{
+		{"registered members", "b", false, []string{"201"}},
+		{"nickname", "capt", false, []string{"201"}},
+		{"no match", "x", false, nil},
+		{"empty value", " ", false, nil},
+		{"private stats", "b", true, nil},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			s.AddMember(&discordgo.Member{GuildID: "300", User: &discordgo.User{ID: "200", Username: "bert"}})
+			s.AddMember(&discordgo.Member{GuildID: "300", Nick: "Captain",
+				User: &discordgo.User{ID: "201", Username: "bob"}})
+			s.AddMember(&discordgo.Member{GuildID: "300", User: &discordgo.User{ID: "202", Username: "bill"}})
+			s.AddMember(&discordgo.Member{GuildID: "300",
+				User: &discordgo.User{ID: "203", Username: "botty", Bot: true}})
+			s.AddMember(&discordgo.Member{GuildID: "301", User: &discordgo.User{ID: "204", Username: "ben"}})
+			for _, uid := range []string{"200", "201", "203", "204"} {
+				db.onArg("FROM users", uid, userRow(7, uid, nil))
+			}
+			db.pref(t, "user_prefs", model.UserPrefStatsPrivate, tc.private)
+			i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+				GuildID: "300",
+				Member:  &discordgo.Member{User: &discordgo.User{ID: "200"}},
+			}}
+
+			cl, err := b.AutocompleteMembers(s, i, tc.v)
+			if err != nil {
+				t.Fatalf("AutocompleteMembers failed: %s", err)
+			}
+			if len(cl) != len(tc.want) {
+				t.Fatalf("AutocompleteMembers failed, expected %d choices, got: %d", len(tc.want), len(cl))
+			}
+			for n, c := range cl {
+				if c.Value != tc.want[n] {
+					t.Errorf("AutocompleteMembers failed, expected choice: %s, got: %v", tc.want[n], c.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestBot_graphMember(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		name string
+		v    string
+		want string
+		err  error
+	}{
+		{"registered member", "201", "Captain", nil},
+		{"typed name", "bob", "", ErrGraphMember},
+		{"member of another guild", "204", "", ErrGraphMember},
+		{"unknown user", "299", "", ErrGraphMember},
+		{"unregistered member", "202", "", nil},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			s.AddMember(&discordgo.Member{GuildID: "300", Nick: "Captain",
+				User: &discordgo.User{ID: "201", Username: "bob"}})
+			s.AddMember(&discordgo.Member{GuildID: "300", User: &discordgo.User{ID: "202", Username: "bill"}})
+			s.AddMember(&discordgo.Member{GuildID: "301", User: &discordgo.User{ID: "204", Username: "ben"}})
+			db.onArg("FROM users", "201", userRow(7, "201", nil))
+			db.onArg("FROM users", "204", userRow(8, "204", nil))
+			i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "300"}}
+
+			gm, err := b.graphMember(i, tc.v)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("graphMember failed, expected error: %s, got: %v", tc.err, err)
+				}
+				return
+			}
+			if tc.want == "" {
+				if err == nil {
+					t.Errorf("graphMember failed, expected error for unregistered member")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("graphMember failed: %s", err)
+			}
+			if gm.name != tc.want || gm.user.UserID != tc.v {
+				t.Errorf("graphMember failed, expected: %s/%s, got: %s/%s", tc.want, tc.v, gm.name, gm.user.UserID)
+			}
+		})
+	}
+}
@@ -1,11 +1,12 @@
 package bot
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -37,24 +38,106 @@ const (
 	ErrFailedStringConvert       = "failed to convert string to int: %s"
 )
 
+// List of Bot specific errors
+var (
+	// ErrConfigNil is returned if the Bot is initialized without a configuration
+	ErrConfigNil = errors.New("provided Config pointer must not be nil")
+
+	// ErrInvalidEncryptionKey is returned if the configuration holds no or an invalid global
+	// encryption key
+	ErrInvalidEncryptionKey = errors.New("no/invalid encryption key in configuration")
+)
+
 // Bot represents the bot instance
 type Bot struct {
 	Log     zerolog.Logger
 	Config  *config.Config
-	Session *discordgo.Session
+	Session DiscordAPI
 	Model   model.Model
 
-	st time.Time
+	clock Clock
+	db    *sql.DB
+	langs map[string]*interactionLang
+	lgmu  sync.Mutex
+	pages map[string]*pageState
+	pgmu  sync.Mutex
+	rec   *EventRecorder
+	sot   SoTClient
+	st    time.Time
+}
+
+// Option is a function to override the defaults of the Bot in the New() method
+type Option func(b *Bot)
+
+// WithSession injects the DiscordAPI the bot is supposed to use instead of creating a new
+// discordgo.Session in Run()
+func WithSession(s DiscordAPI) Option {
+	return func(b *Bot) {
+		b.Session = s
+	}
+}
+
+// WithDB injects an already opened database connection into the Bot
+func WithDB(db *sql.DB) Option {
+	return func(b *Bot) {
+		b.db = db
+	}
+}
+
+// WithModel injects the model stores the Bot is supposed to use. If set, the Bot will not open
+// a database connection on its own
+func WithModel(m model.Model) Option {
+	return func(b *Bot) {
+		b.Model = m
+	}
+}
+
+// WithSoTClient injects the HTTP client that is used to query the Sea of Thieves APIs
+func WithSoTClient(c SoTClient) Option {
+	return func(b *Bot) {
+		b.sot = c
+	}
+}
+
+// WithClock overrides the wall clock of the Bot
+func WithClock(c Clock) Option {
+	return func(b *Bot) {
+		b.clock = c
+	}
+}
+
+// WithLogger sets the logger for the Bot. If not set, the Bot will not log anything
+func WithLogger(l zerolog.Logger) Option {
+	return func(b *Bot) {
+		b.Log = l
+	}
 }
 
 // New initializes a new Bot instance
-func New(l zerolog.Logger, c *config.Config) (*Bot, error) {
+func New(c *config.Config, ol ...Option) (*Bot, error) {
+	if c == nil {
+		return nil, ErrConfigNil
+	}
 	b := &Bot{
 		Config: c,
-		Log:    l,
-		st:     time.Now(),
+		Log:    zerolog.Nop(),
+		clock:  wallClock{},
+	}
+	for _, o := range ol {
+		if o == nil {
+			continue
+		}
+		o(b)
+	}
+	b.st = b.clock.Now()
+
+	// We require a global encryption key
+	if c.Data.EncryptionKey == "" || len(c.Data.EncryptionKey) != config.CryptoKeyLen {
+		return nil, ErrInvalidEncryptionKey
 	}
-	if c.Discord.Token == "" {
+
+	// The discord token is only required if we have to create the session ourselves
+	if b.Session == nil && c.Discord.Token == "" {
 		if t := os.Getenv("ARRGO_TOKEN"); t == "" {
 			return nil, fmt.Errorf("no discord token found in config file %q or environment", c.ConfFilePath())
 		} else {
@@ -63,67 +146,67 @@ func New(l zerolog.Logger, c *config.Config) (*Bot, error) {
 	}
 
 	// Connect to DB model
-	db, err := b.OpenDB(c)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-	b.Model = model.New(db, c)
-
-	// We require a global encryption key
-	if c.Data.EncryptionKey == "" || len(c.Data.EncryptionKey) != config.CryptoKeyLen {
-		b.Log.Warn().Msgf("no/invalid encryption key in configuration file... generating key...")
-		cs, err := crypto.RandomStringSecure(config.CryptoKeyLen, true, false)
-		if err != nil {
-			b.Log.Error().Msgf("failed to generate encryption key: %s", err)
-			os.Exit(1)
+	if b.Model.User == nil {
+		if b.db == nil {
+			db, err := b.OpenDB(c)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open database: %w", err)
+			}
+			b.db = db
 		}
-		b.Log.Info().Msg("encryption key generated... please add the following key to your config...")
-		b.Log.Info().Msgf(`enc_key = "%s"`, cs)
-		os.Exit(0)
+		b.Model = model.New(b.db, c)
 	}
 
 	return b, nil
 }
 
-// Run executes the Bot's main loop
-func (b *Bot) Run() error {
+// Run executes the Bot's main loop until the given context is cancelled
+func (b *Bot) Run(ctx context.Context) error {
 	ll := b.Log.With().Str("context", "bot.Run").Logger()
 	ll.Debug().Msg("initializing bot...")
 
-	dg, err := discordgo.New("Bot " + b.Config.Discord.Token)
-	if err != nil {
-		return fmt.Errorf("failed to create discord session: %w", err)
+	if b.Session == nil {
+		dg, err := b.newDiscordSession()
+		if err != nil {
+			return err
+		}
+		b.Session = dg
 	}
-	b.Session = dg
-
-	// Define list of events we want to see
-	dg.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages |
-		discordgo.IntentsGuildVoiceStates | discordgo.IntentsDirectMessages |
-		discordgo.IntentsGuildPresences | discordgo.IntentsMessageContent |
-		discordgo.IntentsGuildIntegrations
 
 	// Add handlers
 	b.Session.AddHandlerOnce(b.ReadyHandler)
 	b.Session.AddHandler(b.GuildCreate)
 	b.Session.AddHandler(b.GuildDelete)
 	b.Session.AddHandler(b.SlashCommandHandler)
+	b.Session.AddHandler(b.ComponentHandler)
+	b.Session.AddHandler(b.AutocompleteHandler)
 	b.Session.AddHandler(b.UserPlaySoT)
+	if b.rec != nil {
+		b.Session.AddHandler(b.recordEvent)
+	}
 
 	// Open the websocket and begin listening.
-	err = b.Session.Open()
-	if err != nil {
+	if err := b.Session.Open(); err != nil {
 		return fmt.Errorf("failed to open websocket to listen: %w", err)
 	}
 
+	// Migrate the RAT cookie expiration of users that stored their cookie before it became queryable
+	go func() {
+		n, err := b.Model.User.BackfillRATExpire()
+		if err != nil {
+			ll.Error().Msgf("failed to backfill RAT cookie expiration: %s", err)
+			return
+		}
+		if n > 0 {
+			ll.Info().Msgf("backfilled RAT cookie expiration for %d users", n)
+		}
+	}()
+
 	// Register/Update slash commands
 	if err := b.RegisterSlashCommands(); err != nil {
 		ll.Error().Msgf("slash command registration failed: %s", err)
 	}
 
-	// We need a signal channel
-	sc := make(chan os.Signal, 1)
-	signal.Notify(sc)
-
 	// Timer events
 	rd, err := crypto.RandDuration(b.Config.Timer.FHSpam, "m")
 	if err != nil {
@@ -145,6 +228,18 @@ func (b *Bot) Run() error {
 	defer ddt.Stop()
 	urt := time.NewTicker(b.Config.Timer.URUpdate)
 	defer urt.Stop()
+	ult := time.NewTicker(b.Config.Timer.ULUpdate)
+	defer ult.Stop()
+	uat := time.NewTicker(b.Config.Timer.UAUpdate)
+	defer uat.Stop()
+	spt := time.NewTicker(b.Config.Timer.SPUpdate)
+	defer spt.Stop()
+	drt := time.NewTicker(b.Config.Timer.DRCheck)
+	defer drt.Stop()
+	ret := time.NewTicker(b.Config.Timer.RTRun)
+	defer ret.Stop()
+	nft := time.NewTicker(b.Config.Timer.NFFlush)
+	defer nft.Stop()
 
 	// Perform an update for all scheduled update tasks once if first-run flag is set
 	if b.Config.GetFirstRun() {
@@ -158,29 +253,33 @@ func (b *Bot) Run() error {
 			if err := b.ScheduledEventUpdateUserReputation(); err != nil {
 				b.Log.Error().Msgf("failed to update user reputation: %s", err)
 			}
+			if err := b.ScheduledEventUpdateUserLedger(); err != nil {
+				b.Log.Error().Msgf("failed to update user ledger: %s", err)
+			}
+			if err := b.ScheduledEventUpdateUserAchievements(); err != nil {
+				b.Log.Error().Msgf("failed to update user achievements: %s", err)
+			}
+			if err := b.ScheduledEventUpdateUserSeason(); err != nil {
+				b.Log.Error().Msgf("failed to update user season progress: %s", err)
+			}
 			if err := b.ScheduledEventUpdateDailyDeeds(); err != nil {
 				b.Log.Error().Msgf("failed to update daily deeds: %s", err)
 			}
 		}()
 	}
 
-	// Wait here until CTRL-C or other term signal is received.
+	// Wait here until the context is cancelled
 	ll.Info().Msg("bot successfully initialized and connected. Press CTRL-C to exit.")
 	for {
 		select {
-		case rs := <-sc:
-			if rs == syscall.SIGKILL ||
-				rs == syscall.SIGABRT ||
-				rs == syscall.SIGINT ||
-				rs == syscall.SIGTERM {
-				ll.Warn().Msgf("received %s signal. Exiting.", rs)
-
-				// Cleanly close down the Discord session.
-				if err := b.Session.Close(); err != nil {
-					ll.Error().Msgf("failed to gracefully close discord session: %s", err)
-				}
-				return nil
+		case <-ctx.Done():
+			ll.Warn().Msgf("bot context is done: %s. Exiting.", ctx.Err())
+
+			// Cleanly close down the Discord session.
+			if err := b.Session.Close(); err != nil {
+				ll.Error().Msgf("failed to gracefully close discord session: %s", err)
 			}
+			return nil
 		case <-fht.C:
 			go func() {
 				if err := b.ScheduledEventSoTFlameheart(); err != nil {
@@ -216,6 +315,24 @@ func (b *Bot) Run() error {
 					ll.Error().Msgf("failed to process scheuled user reputation update event: %s", err)
 				}
 			}()
+		case <-ult.C:
+			go func() {
+				if err := b.ScheduledEventUpdateUserLedger(); err != nil {
+					ll.Error().Msgf("failed to process scheuled user ledger update event: %s", err)
+				}
+			}()
+		case <-uat.C:
+			go func() {
+				if err := b.ScheduledEventUpdateUserAchievements(); err != nil {
+					ll.Error().Msgf("failed to process scheuled user achievements update event: %s", err)
+				}
+			}()
+		case <-spt.C:
+			go func() {
+				if err := b.ScheduledEventUpdateUserSeason(); err != nil {
+					ll.Error().Msgf("failed to process scheuled user season progress update event: %s", err)
+				}
+			}()
 		case <-rct.C:
 			go func() {
 				if err := b.ScheduledEventCheckRATCookies(); err != nil {
@@ -228,6 +345,24 @@ func (b *Bot) Run() error {
 					ll.Error().Msgf("failed to process scheuled daily deeds update event: %s", err)
 				}
 			}()
+		case <-drt.C:
+			go func() {
+				if err := b.ScheduledEventRemindSwiftDeeds(); err != nil {
+					ll.Error().Msgf("failed to process scheuled swift deed reminder event: %s", err)
+				}
+			}()
+		case <-ret.C:
+			go func() {
+				if err := b.ScheduledEventRetention(); err != nil {
+					ll.Error().Msgf("failed to process scheuled retention event: %s", err)
+				}
+			}()
+		case <-nft.C:
+			go func() {
+				if err := b.ScheduledEventFlushNotifications(); err != nil {
+					ll.Error().Msgf("failed to process scheuled notification flush event: %s", err)
+				}
+			}()
 		}
 	}
 }
@@ -0,0 +1,23 @@
+package bot
+
+import "time"
+
+// Clock is the time source of the Bot. It allows to replace the wall clock, i. e. for replaying
+// recorded events
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// wallClock is the default Clock and uses the system time
+type wallClock struct{}
+
+// Now returns the current system time
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses the current goroutine for the given duration
+func (wallClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/locale"
+)
+
+// ComponentIDSep separates the handler name and the arguments in the custom ID of a component
+const ComponentIDSep = ":"
+
+// ComponentIDMaxLen is the maximum length of a custom ID allowed by Discord
+const ComponentIDMaxLen = 100
+
+// List of component specific errors
+var (
+	ErrComponentIDSep     = errors.New("component ID argument must not contain the separator")
+	ErrComponentIDTooLong = errors.New("component ID exceeds the maximum length")
+)
+
+// ComponentHandlerFunc is a handler method for message component and modal submit interactions
+type ComponentHandlerFunc func(s DiscordAPI, i *discordgo.InteractionCreate) error
+
+// NewComponentID returns the custom ID for a component that is routed to the handler registered for the
+// given name. The arguments are handed to the handler as part of the custom ID. An error is returned if the
+// name or one of the arguments contains ComponentIDSep or if the custom ID exceeds ComponentIDMaxLen
+func NewComponentID(n string, al ...string) (string, error) {
+	fl := append([]string{n}, al...)
+	for _, f := range fl {
+		if strings.Contains(f, ComponentIDSep) {
+			return "", fmt.Errorf("%w: %q", ErrComponentIDSep, f)
+		}
+	}
+	ci := strings.Join(fl, ComponentIDSep)
+	if len(ci) > ComponentIDMaxLen {
+		return "", fmt.Errorf("%w: %d > %d", ErrComponentIDTooLong, len(ci), ComponentIDMaxLen)
+	}
+	return ci, nil
+}
+
+// ParseComponentID splits the given custom ID into the handler name and its arguments
+func ParseComponentID(ci string) (string, []string) {
+	f := strings.Split(ci, ComponentIDSep)
+	return f[0], f[1:]
+}
+
+// componentHandlers returns the map of handler names and the corresponding handler methods for message
+// components and modals
+func (b *Bot) componentHandlers() map[string]ComponentHandlerFunc {
+	return map[string]ComponentHandlerFunc{
+		ComponentUnregisterConfirm: b.ComponentUnregister,
+		ComponentUnregisterCancel:  b.ComponentUnregister,
+		ComponentPage:              b.ComponentPageTurn,
+	}
+}
+
+// ComponentHandler is the central handler method for all message components (e.g. buttons) and modal
+// submits. It will look up the handler name of the custom ID (see NewComponentID) of the received
+// interaction and when found execute the corresponding method. Other than slash commands, component
+// interactions are not deferred, so the handler methods have to respond to the interaction themselves
+func (b *Bot) ComponentHandler(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	s := b.Session
+
+	var ci string
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		ci = i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		ci = i.ModalSubmitData().CustomID
+	default:
+		return
+	}
+	ll := b.Log.With().Str("context", "bot.ComponentHandler").
+		Str("custom_id", ci).Logger()
+
+	n, _ := ParseComponentID(ci)
+	h, ok := b.componentHandlers()[n]
+	if !ok {
+		ll.Warn().Msg("no handler found for component interaction")
+		return
+	}
+	if err := h(s, i); err != nil {
+		ll.Error().Msgf("failed to process component interaction: %s", err)
+		l := b.interactionLanguage(i.Interaction)
+		p := locale.NewPrinter(l)
+		r := &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{
+					{
+						Type: discordgo.EmbedTypeArticle,
+						Description: p.Sprintf("I am sorry, but I was not able to process your request: %s",
+							localizeError(l, err)),
+						Title: p.Sprintf("Oh no! Something went wrong!"),
+					},
+				},
+			},
+		}
+		_ = s.InteractionRespond(i.Interaction, r)
+	}
+}
+
+// interactionUserID returns the Discord user ID of the user that triggered the given interaction
+func interactionUserID(i *discordgo.Interaction) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
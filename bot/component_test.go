@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewComponentID(t *testing.T) {
+	tt := []struct {
+		name string
+		n    string
+		al   []string
+		want string
+		err  error
+	}{
+		{"name only", "unregister_confirm", nil, "unregister_confirm", nil},
+		{"with arguments", ComponentPage, []string{"abc", "3"}, "page:abc:3", nil},
+		{"empty argument", ComponentPage, []string{"", "3"}, "page::3", nil},
+		{"separator in name", "page:x", nil, "", ErrComponentIDSep},
+		{"separator in argument", ComponentPage, []string{"a:b", "3"}, "", ErrComponentIDSep},
+		{"maximum length", "x", []string{strings.Repeat("y", ComponentIDMaxLen-2)},
+			"x:" + strings.Repeat("y", ComponentIDMaxLen-2), nil},
+		{"too long", "x", []string{strings.Repeat("y", ComponentIDMaxLen-1)}, "", ErrComponentIDTooLong},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ci, err := NewComponentID(tc.n, tc.al...)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("NewComponentID failed, expected error: %s, got: %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewComponentID failed: %s", err)
+			}
+			if ci != tc.want {
+				t.Errorf("NewComponentID failed, expected: %q, got: %q", tc.want, ci)
+			}
+			n, al := ParseComponentID(ci)
+			if n != tc.n {
+				t.Errorf("ParseComponentID failed, expected name: %q, got: %q", tc.n, n)
+			}
+			if len(al) != len(tc.al) || (len(al) > 0 && !reflect.DeepEqual(al, tc.al)) {
+				t.Errorf("ParseComponentID failed, expected arguments: %q, got: %q", tc.al, al)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordAPI is the narrow subset of the discordgo.Session that ArrGo makes use of. A *discordgo.Session
+// satisfies this interface, but it allows to inject alternative implementations like the recording
+// fake session of the discordfake package into the Bot
+type DiscordAPI interface {
+	AddHandler(h interface{}) func()
+	AddHandlerOnce(h interface{}) func()
+	Open() error
+	Close() error
+	User(uid string, ol ...discordgo.RequestOption) (*discordgo.User, error)
+	UserChannelCreate(rid string, ol ...discordgo.RequestOption) (*discordgo.Channel, error)
+	GuildMember(gid, uid string, ol ...discordgo.RequestOption) (*discordgo.Member, error)
+	GuildMembersSearch(gid, q string, l int, ol ...discordgo.RequestOption) ([]*discordgo.Member, error)
+	UserChannelPermissions(uid, cid string, ol ...discordgo.RequestOption) (int64, error)
+	ChannelMessageSend(cid string, c string, ol ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendEmbed(cid string, e *discordgo.MessageEmbed,
+		ol ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(cid string, d *discordgo.MessageSend,
+		ol ...discordgo.RequestOption) (*discordgo.Message, error)
+	ApplicationCommands(aid, gid string, ol ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandCreate(aid string, gid string, c *discordgo.ApplicationCommand,
+		ol ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+	ApplicationCommandEdit(aid, gid, cid string, c *discordgo.ApplicationCommand,
+		ol ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+	ApplicationCommandDelete(aid, gid, cid string, ol ...discordgo.RequestOption) error
+	InteractionRespond(i *discordgo.Interaction, r *discordgo.InteractionResponse,
+		ol ...discordgo.RequestOption) error
+	InteractionResponseEdit(i *discordgo.Interaction, e *discordgo.WebhookEdit,
+		ol ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+// Make sure that the discordgo.Session satisfies the DiscordAPI
+var _ DiscordAPI = (*discordgo.Session)(nil)
+
+// guildMember returns the member of the given guild. The member is taken from the state cache of the
+// Discord session if possible and only requested from the API otherwise
+func (b *Bot) guildMember(gid, uid string) (*discordgo.Member, error) {
+	if ds, ok := b.Session.(*discordgo.Session); ok && ds.State != nil {
+		if m, err := ds.State.Member(gid, uid); err == nil && m.User != nil {
+			return m, nil
+		}
+	}
+	return b.Session.GuildMember(gid, uid)
+}
+
+// newDiscordSession returns a new *discordgo.Session with the intents required by the bot
+func (b *Bot) newDiscordSession() (*discordgo.Session, error) {
+	dg, err := discordgo.New("Bot " + b.Config.Discord.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discord session: %w", err)
+	}
+
+	// Define list of events we want to see
+	dg.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages |
+		discordgo.IntentsGuildVoiceStates | discordgo.IntentsDirectMessages |
+		discordgo.IntentsGuildPresences | discordgo.IntentsMessageContent |
+		discordgo.IntentsGuildIntegrations
+
+	return dg, nil
+}
+
+// applicationID returns the application ID of the bot user connected to the Discord session
+func (b *Bot) applicationID() (string, error) {
+	u, err := b.Session.User("@me")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up bot user: %w", err)
+	}
+	if u == nil || u.ID == "" {
+		return "", fmt.Errorf("no valid bot user. Required itents might be missing from discord token")
+	}
+	return u.ID, nil
+}
@@ -0,0 +1,8 @@
+package bot
+
+import (
+	"github.com/wneessen/arrgo/discordfake"
+)
+
+// Make sure that the recording fake satisfies the DiscordAPI
+var _ DiscordAPI = (*discordfake.Session)(nil)
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// List of distance units
+const (
+	DistanceNauticalMiles = "nmi"
+	DistanceKilometres    = "km"
+	DistanceMiles         = "mi"
+)
+
+// List of number grouping styles. Numbers are grouped according to the language of the user by default
+const (
+	NumberGroupingComma  = "comma"
+	NumberGroupingPeriod = "period"
+	NumberGroupingSpace  = "space"
+	NumberGroupingNone   = "none"
+)
+
+// List of clock formats. Times are rendered in the local time of the Discord client by default
+const (
+	Clock24h = "24h"
+	Clock12h = "12h"
+)
+
+// metresPerUnit holds the conversion factors of the supported distance units
+var metresPerUnit = map[string]float64{
+	DistanceNauticalMiles: 1852,
+	DistanceKilometres:    1000,
+	DistanceMiles:         1609.344,
+}
+
+// DisplayPrefs holds the display preferences of a user
+type DisplayPrefs struct {
+	DistanceUnit   string
+	NumberGrouping string
+	Timezone       string
+	Clock          string
+}
+
+// Display is a message.Printer that renders numbers, distances and times according to the display
+// preferences of a user
+type Display struct {
+	*message.Printer
+	DisplayPrefs
+	tz *time.Location
+}
+
+// NewDisplay returns a new Display for the given message.Printer and display preferences
+func NewDisplay(p *message.Printer, dp DisplayPrefs) *Display {
+	d := &Display{Printer: p, DisplayPrefs: dp, tz: time.UTC}
+	if _, ok := metresPerUnit[d.DistanceUnit]; !ok {
+		d.DistanceUnit = DistanceNauticalMiles
+	}
+	if d.Timezone != "" {
+		if tz, err := time.LoadLocation(d.Timezone); err == nil {
+			d.tz = tz
+		}
+	}
+	return d
+}
+
+// display returns a Display in the language and with the display preferences of the requesting user
+// of the given interaction
+func (b *Bot) display(i *discordgo.Interaction) *Display {
+	p := b.printer(i)
+	uid := interactionUserID(i)
+	if uid == "" {
+		return NewDisplay(p, DisplayPrefs{})
+	}
+	u, err := b.Model.User.GetByUserID(uid)
+	if err != nil {
+		return NewDisplay(p, DisplayPrefs{})
+	}
+	return NewDisplay(p, b.displayPrefs(u))
+}
+
+// userDisplay returns a Display in the language and with the display preferences of the given user
+// for messages that are not a response to an interaction
+func (b *Bot) userDisplay(u *model.User) *Display {
+	if u == nil {
+		return NewDisplay(b.userPrinter(u), DisplayPrefs{})
+	}
+	return NewDisplay(b.userPrinter(u), b.displayPrefs(u))
+}
+
+// displayPrefs reads the display preferences of the given user from the database
+func (b *Bot) displayPrefs(u *model.User) DisplayPrefs {
+	var dp DisplayPrefs
+	for k, v := range map[model.UserPrefKey]*string{
+		model.UserPrefDistanceUnit:   &dp.DistanceUnit,
+		model.UserPrefNumberGrouping: &dp.NumberGrouping,
+		model.UserPrefTimezone:       &dp.Timezone,
+		model.UserPrefClock:          &dp.Clock,
+	} {
+		pv, err := b.Model.User.GetPrefString(u, k)
+		if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+			b.Log.Warn().Msgf("failed to read display preference %q of user %d: %s", k, u.ID, err)
+		}
+		*v = pv
+	}
+	return dp
+}
+
+// Int returns the given integer with the digit grouping of the user
+func (d *Display) Int(v int64) string {
+	gs, _ := d.separators()
+	if d.NumberGrouping == "" {
+		return d.Sprint(number.Decimal(v))
+	}
+	s := strconv.FormatInt(v, 10)
+	if v < 0 {
+		return "-" + groupDigits(s[1:], gs)
+	}
+	return groupDigits(s, gs)
+}
+
+// Float returns the given float with the given precision and the digit grouping of the user
+func (d *Display) Float(v float64, pr int) string {
+	if d.NumberGrouping == "" {
+		return d.Sprint(number.Decimal(v, number.MinFractionDigits(pr), number.MaxFractionDigits(pr)))
+	}
+	gs, ds := d.separators()
+	s := strconv.FormatFloat(v, 'f', pr, 64)
+	ip, fp, _ := strings.Cut(s, ".")
+	sg := ""
+	if strings.HasPrefix(ip, "-") {
+		sg, ip = "-", ip[1:]
+	}
+	if fp == "" {
+		return sg + groupDigits(ip, gs)
+	}
+	return sg + groupDigits(ip, gs) + ds + fp
+}
+
+// Distance returns the given distance in metres converted to the distance unit of the user. Short
+// distances (e.g. of a single voyage) are given with one decimal
+func (d *Display) Distance(m int64) string {
+	dv := float64(m) / metresPerUnit[d.DistanceUnit]
+	v := d.Int(int64(dv))
+	if dv < 100 && dv > -100 {
+		v = d.Float(dv, 1)
+	}
+	switch d.DistanceUnit {
+	case DistanceKilometres:
+		return d.Sprintf("%s km", v)
+	case DistanceMiles:
+		return d.Sprintf("%s mi", v)
+	default:
+		return d.Sprintf("%s nmi", v)
+	}
+}
+
+// Time returns the given time in the timezone and clock format of the user. Without a timezone or
+// clock preference the time is rendered as Discord timestamp in the local time of the Discord client
+func (d *Display) Time(t time.Time) string {
+	if d.Timezone == "" && d.Clock == "" {
+		return discordTimestamp(t.Unix(), "f")
+	}
+	f := "2006-01-02 15:04 MST"
+	if d.Clock == Clock12h {
+		f = "2006-01-02 3:04 PM MST"
+	}
+	return t.In(d.tz).Format(f)
+}
+
+// separators returns the digit grouping and decimal separators of the number grouping of the user
+func (d *Display) separators() (string, string) {
+	switch d.NumberGrouping {
+	case NumberGroupingPeriod:
+		return ".", ","
+	case NumberGroupingSpace:
+		return "\u00a0", ","
+	case NumberGroupingNone:
+		return "", "."
+	default:
+		return ",", "."
+	}
+}
+
+// groupDigits inserts the separator between each group of three digits of the given digit string
+func groupDigits(s, sep string) string {
+	if sep == "" || len(s) <= 3 {
+		return s
+	}
+	var sb strings.Builder
+	for n, c := range s {
+		if n > 0 && (len(s)-n)%3 == 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
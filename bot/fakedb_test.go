@@ -0,0 +1,248 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wneessen/arrgo/config"
+	"github.com/wneessen/arrgo/discordfake"
+)
+
+// testEncryptionKey is the global encryption key of the test configuration
+const testEncryptionKey = "0123456789abcdef0123456789abcdef"
+
+// fakeResult is a scripted answer of the fakeDB to all queries that contain q and, if set, the argument a.
+// If err is set, the query fails with it
+type fakeResult struct {
+	q    string
+	a    driver.Value
+	rows [][]driver.Value
+	err  error
+}
+
+// fakeDB is a scripted database/sql driver for the tests of the bot. Queries are answered by the
+// latest registered fakeResult that matches. Queries without a matching result return no rows and
+// all other statements are reported to have affected a single row
+type fakeDB struct {
+	mu    sync.Mutex
+	res   []fakeResult
+	execs []string
+}
+
+// on registers the rows that are returned for queries that contain q
+func (f *fakeDB) on(q string, rows ...[]driver.Value) {
+	f.onArg(q, nil, rows...)
+}
+
+// onArg registers the rows that are returned for queries that contain q and have a as argument
+func (f *fakeDB) onArg(q string, a driver.Value, rows ...[]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.res = append(f.res, fakeResult{q: q, a: a, rows: rows})
+}
+
+// onArgErr registers the error that is returned for queries that contain q and have a as argument
+func (f *fakeDB) onArgErr(q string, a driver.Value, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.res = append(f.res, fakeResult{q: q, a: a, err: err})
+}
+
+// pref registers the gob-encoded value v for the preference k of the given prefs table
+func (f *fakeDB) pref(t *testing.T, tbl string, k interface{}, v interface{}) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		t.Fatalf("failed to encode preference %v: %s", k, err)
+	}
+	f.onArg("FROM "+tbl, fmt.Sprint(k), []driver.Value{buf.Bytes()})
+}
+
+// executed returns true if a statement that contains s has been executed
+func (f *fakeDB) executed(s string) bool {
+	return f.execCount(s) > 0
+}
+
+// execCount returns the number of executed statements that contain s
+func (f *fakeDB) execCount(s string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, e := range f.execs {
+		if strings.Contains(e, s) {
+			n++
+		}
+	}
+	return n
+}
+
+// query returns the rows or error of the latest fakeResult that matches the given query and arguments
+func (f *fakeDB) query(q string, al []driver.NamedValue) ([][]driver.Value, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for n := len(f.res) - 1; n >= 0; n-- {
+		r := f.res[n]
+		if !strings.Contains(q, r.q) {
+			continue
+		}
+		if r.a == nil {
+			return r.rows, r.err
+		}
+		for _, a := range al {
+			if fmt.Sprint(a.Value) == fmt.Sprint(r.a) {
+				return r.rows, r.err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Connect implements the driver.Connector interface
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: f}, nil
+}
+
+// Driver implements the driver.Connector interface
+func (f *fakeDB) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+// fakeDriver is the driver.Driver of the fakeDB. It can only be used via the fakeDB connector
+type fakeDriver struct{}
+
+// Open implements the driver.Driver interface
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("the fake driver can only be used via its connector")
+}
+
+// fakeConn is a connection to the fakeDB
+type fakeConn struct {
+	db *fakeDB
+}
+
+// Prepare implements the driver.Conn interface. Prepared statements are not supported
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported by the fake driver")
+}
+
+// Close implements the driver.Conn interface
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+// Begin implements the driver.Conn interface
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+// QueryContext implements the driver.QueryerContext interface
+func (c *fakeConn) QueryContext(_ context.Context, q string, al []driver.NamedValue) (driver.Rows, error) {
+	rl, err := c.db.query(q, al)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{rows: rl}, nil
+}
+
+// ExecContext implements the driver.ExecerContext interface
+func (c *fakeConn) ExecContext(_ context.Context, q string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.execs = append(c.db.execs, q)
+	return driver.RowsAffected(1), nil
+}
+
+// fakeTx is a transaction of the fakeDB. It does nothing
+type fakeTx struct{}
+
+// Commit implements the driver.Tx interface
+func (fakeTx) Commit() error { return nil }
+
+// Rollback implements the driver.Tx interface
+func (fakeTx) Rollback() error { return nil }
+
+// fakeRows are the rows of a fakeResult
+type fakeRows struct {
+	rows [][]driver.Value
+	n    int
+}
+
+// Columns implements the driver.Rows interface
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	cl := make([]string, len(r.rows[0]))
+	for n := range cl {
+		cl[n] = fmt.Sprintf("c%d", n)
+	}
+	return cl
+}
+
+// Close implements the driver.Rows interface
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+// Next implements the driver.Rows interface
+func (r *fakeRows) Next(dl []driver.Value) error {
+	if r.n >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dl, r.rows[r.n])
+	r.n++
+	return nil
+}
+
+// testClock is a Clock that stands still at a fixed time. Sleep returns right away
+type testClock struct {
+	t time.Time
+}
+
+// Now returns the time of the testClock
+func (c testClock) Now() time.Time {
+	return c.t
+}
+
+// Sleep returns right away
+func (c testClock) Sleep(time.Duration) {}
+
+// newTestBot returns a Bot that runs against the recording Discord fake and the scripted fakeDB at the
+// given time
+func newTestBot(t *testing.T, now time.Time) (*Bot, *discordfake.Session, *fakeDB) {
+	t.Helper()
+	c := &config.Config{}
+	c.Data.EncryptionKey = testEncryptionKey
+	c.Reminder.Stages = "24h,6h,1h"
+	fdb := &fakeDB{}
+	db := sql.OpenDB(fdb)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	s := discordfake.New()
+	b, err := New(c, WithSession(s), WithClock(testClock{t: now}), WithDB(db))
+	if err != nil {
+		t.Fatalf("failed to create test bot: %s", err)
+	}
+	return b, s, fdb
+}
+
+// userRow returns a row of the users table for the given user
+func userRow(id int64, uid string, re *time.Time) []driver.Value {
+	var rv driver.Value
+	if re != nil {
+		rv = *re
+	}
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []driver.Value{id, uid, []byte("0123456789abcdef0123456789abcdef"), rv, int64(1), ts, ts}
+}
@@ -1,6 +1,9 @@
 package bot
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // List of icons/emojis
 const (
@@ -58,3 +61,13 @@ func dbEmissaryToName(e string) string {
 		return ""
 	}
 }
+
+// discordTimestamp returns the Discord timestamp markdown for the given Unix time. The style (e.g. "R" for
+// relative or "f" for short date/time) is optional. Timestamps must not be passed as number to a
+// message.Printer, as it would apply the digit grouping of the language
+func discordTimestamp(t int64, st string) string {
+	if st == "" {
+		return fmt.Sprintf("<t:%d>", t)
+	}
+	return fmt.Sprintf("<t:%d:%s>", t, st)
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyNoMatch is the score of fuzzyScore if a name does not match the query at all
+const fuzzyNoMatch = -1
+
+// fuzzyNormalize returns the given string in lower case, without anything but letters and digits
+func fuzzyNormalize(s string) []rune {
+	var rl []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			rl = append(rl, r)
+		}
+	}
+	return rl
+}
+
+// fuzzyScore returns how well the given name matches the given query, ignoring case, spaces and
+// punctuation. An exact match scores 0, a prefix 1 and a substring 2. Names that only match with
+// a few typos score 3 and more, depending on the number of typos. If the name does not match at all,
+// fuzzyNoMatch is returned
+func fuzzyScore(q, n string) int {
+	qr, nr := fuzzyNormalize(q), fuzzyNormalize(n)
+	qs, ns := string(qr), string(nr)
+	switch {
+	case qs == ns:
+		return 0
+	case strings.HasPrefix(ns, qs):
+		return 1
+	case strings.Contains(ns, qs):
+		return 2
+	}
+
+	// A typo is allowed for every 4 characters of the query. The query is compared to the full name
+	// and the beginnings of the name of about the query's length, so that incomplete names with typos
+	// match as well
+	d := levenshtein(qr, nr)
+	for l := len(qr) - 1; l <= len(qr)+1; l++ {
+		if l <= 0 || l >= len(nr) {
+			continue
+		}
+		if pd := levenshtein(qr, nr[:l]); pd < d {
+			d = pd
+		}
+	}
+	if d > len(qr)/4 {
+		return fuzzyNoMatch
+	}
+	return 3 + d
+}
+
+// fuzzyFind returns the indices of the names of the given list that match the given query, best
+// matches first
+func fuzzyFind(q string, nl []string) []int {
+	var il, sl []int
+	for n, na := range nl {
+		if s := fuzzyScore(q, na); s != fuzzyNoMatch {
+			il = append(il, n)
+			sl = append(sl, s)
+		}
+	}
+	sort.Stable(fuzzyResult{il: il, sl: sl})
+	return il
+}
+
+// fuzzyResult sorts the matching indices of fuzzyFind by their score
+type fuzzyResult struct {
+	il []int
+	sl []int
+}
+
+func (r fuzzyResult) Len() int           { return len(r.il) }
+func (r fuzzyResult) Less(i, j int) bool { return r.sl[i] < r.sl[j] }
+func (r fuzzyResult) Swap(i, j int) {
+	r.il[i], r.il[j] = r.il[j], r.il[i]
+	r.sl[i], r.sl[j] = r.sl[j], r.sl[i]
+}
+
+// levenshtein returns the edit distance between the two given rune slices
+func levenshtein(a, b []rune) int {
+	pr := make([]int, len(b)+1)
+	cr := make([]int, len(b)+1)
+	for j := range pr {
+		pr[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cr[0] = i
+		for j := 1; j <= len(b); j++ {
+			c := pr[j-1]
+			if a[i-1] != b[j-1] {
+				c++
+			}
+			if pr[j]+1 < c {
+				c = pr[j] + 1
+			}
+			if cr[j-1]+1 < c {
+				c = cr[j-1] + 1
+			}
+			cr[j] = c
+		}
+		pr, cr = cr, pr
+	}
+	return pr[len(b)]
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tt := []struct {
+		name  string
+		query string
+		match string
+		score int
+	}{
+		{"exact match", "Sanctuary Outpost", "Sanctuary Outpost", 0},
+		{"exact match ignoring case and punctuation", "sanctuary-outpost", "Sanctuary Outpost", 0},
+		{"prefix", "sanc", "Sanctuary Outpost", 1},
+		{"substring", "outpost", "Sanctuary Outpost", 2},
+		{"one typo", "sanctuery", "Sanctuary Outpost", 4},
+		{"swapped letters", "sanctaury", "Sanctuary Outpost", 5},
+		{"incomplete with typo", "sanctuery out", "Sanctuary Outpost", 4},
+		{"too many typos", "sxnctxxry", "Sanctuary Outpost", fuzzyNoMatch},
+		{"short query with typo", "snc", "Sanctuary Outpost", fuzzyNoMatch},
+		{"no match", "plunder", "Sanctuary Outpost", fuzzyNoMatch},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := fuzzyScore(tc.query, tc.match); s != tc.score {
+				t.Errorf("fuzzyScore(%q, %q) failed, expected: %d, got: %d", tc.query, tc.match, tc.score, s)
+			}
+		})
+	}
+}
+
+func TestFuzzyFind(t *testing.T) {
+	nl := []string{"Dagger Tooth Outpost", "Sanctuary Outpost", "Ancient Spire Outpost", "Sanctuary"}
+	tt := []struct {
+		name  string
+		query string
+		il    []int
+	}{
+		{"best match first", "sanctuary", []int{3, 1}},
+		{"substring of all", "outpost", []int{0, 1, 2}},
+		{"typo", "dager", []int{0}},
+		{"no match", "golden sands", nil},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if il := fuzzyFind(tc.query, nl); !reflect.DeepEqual(il, tc.il) {
+				t.Errorf("fuzzyFind(%q) failed, expected: %v, got: %v", tc.query, tc.il, il)
+			}
+		})
+	}
+}
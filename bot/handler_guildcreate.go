@@ -8,17 +8,18 @@ import (
 
 	"github.com/wneessen/arrgo/config"
 	"github.com/wneessen/arrgo/crypto"
+	"github.com/wneessen/arrgo/locale"
 	"github.com/wneessen/arrgo/model"
 )
 
 // GuildCreate receives GUILD_CREATE updates from each server the bot is connected to
-func (b *Bot) GuildCreate(s *discordgo.Session, ev *discordgo.GuildCreate) {
+func (b *Bot) GuildCreate(_ *discordgo.Session, ev *discordgo.GuildCreate) {
 	ll := b.Log.With().Str("context", "bot.GuildCreate").Str("guild_id", ev.Guild.ID).Logger()
 
 	// Check if guild is already present in database
 	var g *model.Guild
 	var err error
-	_, err = b.Model.Guild.GetByGuildID(ev.Guild.ID)
+	g, err = b.Model.Guild.GetByGuildID(ev.Guild.ID)
 	if err != nil {
 		if !errors.Is(err, model.ErrGuildNotExistent) {
 			ll.Error().Msgf("failed to fetch guild from DB: %s", err)
@@ -46,6 +47,7 @@ func (b *Bot) GuildCreate(s *discordgo.Session, ev *discordgo.GuildCreate) {
 		}
 		if err := b.Model.Guild.Insert(g); err != nil {
 			ll.Error().Msgf("failed to insert guild into database: %s", err)
+			return
 		}
 
 		// By default we don't want FH spam
@@ -54,11 +56,12 @@ func (b *Bot) GuildCreate(s *discordgo.Session, ev *discordgo.GuildCreate) {
 		}
 
 		// Send introduction to system channel
+		p := locale.NewPrinter(locale.Match(ev.Guild.PreferredLocale))
 		ef := []*discordgo.MessageEmbedField{
 			{
-				Name: "Ahoy, Mateys!",
-				Value: "I am ArrGo the Discord Pirate Lord! I just joined this nice vessel to have " +
-					"an an eye on you scallywags!",
+				Name: p.Sprintf("Ahoy, Mateys!"),
+				Value: p.Sprintf("I am ArrGo the Discord Pirate Lord! I just joined this nice vessel to have " +
+					"an an eye on you scallywags!"),
 				Inline: true,
 			},
 		}
@@ -67,11 +70,16 @@ func (b *Bot) GuildCreate(s *discordgo.Session, ev *discordgo.GuildCreate) {
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf(`%s/piratelord_small.png`, AssetsBaseURL),
 			},
-			Title:  "Avast ye!",
+			Title:  p.Sprintf("Avast ye!"),
 			Fields: ef,
 		}
-		if _, err := s.ChannelMessageSendEmbed(ev.Guild.SystemChannelID, e); err != nil {
+		if _, err := b.Session.ChannelMessageSendEmbed(ev.Guild.SystemChannelID, e); err != nil {
 			ll.Error().Msgf("failed to send introcution message: %s", err)
 		}
 	}
+
+	// Remember the guild's locale for messages that are not a response to an interaction
+	if err := b.Model.Guild.SetPref(g, model.GuildPrefLocale, ev.Guild.PreferredLocale); err != nil {
+		ll.Error().Msgf("failed to set guild preference locale in database: %s", err)
+	}
 }
@@ -6,10 +6,9 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 
 	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
 )
 
 // UserPlaySoT receives PRESENCE_UPDATE from each server and handles if the user starts playing SoT
@@ -56,7 +55,7 @@ func (b *Bot) UserPlaySoT(_ *discordgo.Session, ev *discordgo.PresenceUpdate) {
 			ll.Warn().Msgf("failed to set user's status in database: %s", err)
 			return
 		}
-		if err := b.Model.User.SetPref(u, model.UserPrefPlaysSoTStartTime, time.Now().Unix()); err != nil {
+		if err := b.Model.User.SetPref(u, model.UserPrefPlaysSoTStartTime, b.clock.Now().Unix()); err != nil {
 			ll.Warn().Msgf("failed to set user's start time in database: %s", err)
 			return
 		}
@@ -94,10 +93,13 @@ func (b *Bot) UserPlaySoT(_ *discordgo.Session, ev *discordgo.PresenceUpdate) {
 			ll.Warn().Msgf("failed to retrieve start time from DB: %s", err)
 			return
 		}
-		et := time.Now().Unix()
+		et := b.clock.Now().Unix()
+		if err := b.Model.User.SetPref(u, model.UserPrefPlaysSoTEndTime, et); err != nil {
+			ll.Warn().Msgf("failed to set user's end time in database: %s", err)
+		}
 
 		go func(s, e int64, rq *Requester, pu *discordgo.PresenceUpdate) {
-			time.Sleep(time.Minute * 1)
+			b.clock.Sleep(time.Minute * 1)
 			wp, err := b.Model.User.GetPrefBool(rq.User, model.UserPrefPlaysSoT)
 			if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
 				ll.Warn().Msgf(ErrFailedRetrieveUserStatsDB, err)
@@ -144,123 +146,129 @@ func (b *Bot) UserPlaySoT(_ *discordgo.Session, ev *discordgo.PresenceUpdate) {
 				return
 			}
 
-			p := message.NewPrinter(language.German)
-			var ef []*discordgo.MessageEmbedField
-			if uss.Gold != use.Gold {
-				v := use.Gold - uss.Gold
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Gold", IconGold),
-					Value:  fmt.Sprintf("%s **%s** Gold", changeIcon(v), p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.Doubloons != use.Doubloons {
-				v := use.Doubloons - uss.Doubloons
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Doubloons", IconDoubloon),
-					Value:  fmt.Sprintf("%s **%s** Doubloons", changeIcon(v), p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.AncientCoins != use.AncientCoins {
-				v := use.AncientCoins - uss.AncientCoins
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Ancient Coins", IconAncientCoin),
-					Value:  fmt.Sprintf("%s **%s** Ancient Coints", changeIcon(v), p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.KrakenDefeated != use.KrakenDefeated {
-				v := use.KrakenDefeated - uss.KrakenDefeated
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Kraken", IconKraken),
-					Value:  fmt.Sprintf("**%s** defeated", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.MegalodonEnounter != use.MegalodonEnounter {
-				v := use.MegalodonEnounter - uss.MegalodonEnounter
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Megalodon", IconMegalodon),
-					Value:  fmt.Sprintf("**%s** encounter(s)", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.ChestsHandedIn != use.ChestsHandedIn {
-				v := use.ChestsHandedIn - uss.ChestsHandedIn
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Chests", IconChest),
-					Value:  fmt.Sprintf("**%s** handed in", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.ShipsSunk != use.ShipsSunk {
-				v := use.ShipsSunk - uss.ShipsSunk
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Other Ships", IconShip),
-					Value:  fmt.Sprintf("**%s** sunk", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.VomittedTimes != use.VomittedTimes {
-				v := use.VomittedTimes - uss.VomittedTimes
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Vomitted", IconVomit),
-					Value:  fmt.Sprintf("**%s** times", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			if uss.DistanceSailed != use.DistanceSailed {
-				v := use.DistanceSailed - uss.DistanceSailed
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Name:   fmt.Sprintf("%s Distance", IconDistance),
-					Value:  fmt.Sprintf("**%s** nmi sailed", p.Sprintf("%d", v)),
-					Inline: true,
-				})
-			}
-			ef = append(ef, &discordgo.MessageEmbedField{
-				Name:   fmt.Sprintf("%s Duration", IconDuration),
-				Value:  fmt.Sprintf("**%s** played", pd.String()),
-				Inline: true,
-			})
-			for len(ef)%3 != 0 {
-				ef = append(ef, &discordgo.MessageEmbedField{
-					Value:  "\U0000FEFF",
-					Name:   "\U0000FEFF",
-					Inline: true,
-				})
+			if err := b.sendVoyageSummary(u, pu.GuildID, s, pd, uss, use); err != nil {
+				ll.Error().Msgf("failed to send voyage summary message: %s", err)
 			}
+		}(st, et, &r, ev)
+	}
+}
 
-			if len(ef) > 1 {
-				du, err := b.Session.User(u.UserID)
-				if err != nil {
-					ll.Warn().Msgf("failed to retrieve user information from Discord: %s", err)
-					return
-				}
-				eb := []*discordgo.MessageEmbed{
-					{
-						Title:  fmt.Sprintf("Sea of Thieves voyage summary for @%s", du.Username),
-						Type:   discordgo.EmbedTypeRich,
-						Fields: ef,
-					},
-				}
+// sendVoyageSummary sends the summary of the stats changes between the given start and end stats of a
+// Sea of Thieves play session of the given user
+func (b *Bot) sendVoyageSummary(u *model.User, gid string, s int64, pd time.Duration, uss, use *model.UserStat,
+) error {
+	p := b.userDisplay(u)
+	var ef []*discordgo.MessageEmbedField
+	if uss.Gold != use.Gold {
+		v := use.Gold - uss.Gold
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Gold", IconGold),
+			Value:  p.Sprintf("%s **%s** Gold", changeIcon(v), p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.Doubloons != use.Doubloons {
+		v := use.Doubloons - uss.Doubloons
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Doubloons", IconDoubloon),
+			Value:  p.Sprintf("%s **%s** Doubloons", changeIcon(v), p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.AncientCoins != use.AncientCoins {
+		v := use.AncientCoins - uss.AncientCoins
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Ancient Coins", IconAncientCoin),
+			Value:  p.Sprintf("%s **%s** Ancient Coins", changeIcon(v), p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.KrakenDefeated != use.KrakenDefeated {
+		v := use.KrakenDefeated - uss.KrakenDefeated
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Kraken", IconKraken),
+			Value:  p.Sprintf("**%s** defeated", p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.MegalodonEnounter != use.MegalodonEnounter {
+		v := use.MegalodonEnounter - uss.MegalodonEnounter
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Megalodon", IconMegalodon),
+			Value:  p.Sprintf("**%s** encounter(s)", p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.ChestsHandedIn != use.ChestsHandedIn {
+		v := use.ChestsHandedIn - uss.ChestsHandedIn
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Chests", IconChest),
+			Value:  p.Sprintf("**%s** handed in", p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.ShipsSunk != use.ShipsSunk {
+		v := use.ShipsSunk - uss.ShipsSunk
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Other Ships", IconShip),
+			Value:  p.Sprintf("**%s** sunk", p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.VomittedTimes != use.VomittedTimes {
+		v := use.VomittedTimes - uss.VomittedTimes
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Vomitted", IconVomit),
+			Value:  p.Sprintf("**%s** times", p.Int(v)),
+			Inline: true,
+		})
+	}
+	if uss.DistanceSailed != use.DistanceSailed {
+		v := use.DistanceSailed - uss.DistanceSailed
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("%s Distance", IconDistance),
+			Value:  p.Sprintf("**%s** sailed", p.Distance(v)),
+			Inline: true,
+		})
+	}
+	ef = append(ef, &discordgo.MessageEmbedField{
+		Name:   p.Sprintf("%s Duration", IconDuration),
+		Value:  p.Sprintf("**%s** played", pd.String()),
+		Inline: true,
+	})
+	for len(ef)%3 != 0 {
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Value:  "\U0000FEFF",
+			Name:   "\U0000FEFF",
+			Inline: true,
+		})
+	}
 
-				g, err := b.Model.Guild.GetByGuildID(pu.GuildID)
-				if err != nil {
-					ll.Error().Msgf("failed to retrieve guild information from DB: %s", err)
-					return
-				}
-				ag, err := b.Model.Guild.GetPrefBool(g, model.GuildPrefAnnounceSoTSummary)
-				if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
-					ll.Error().Msgf("failed to fetch guild preference from DB: %s", err)
-					return
-				}
-				if ag {
-					if _, err := b.Session.ChannelMessageSendEmbed(b.Model.Guild.AnnouceChannel(g), eb[0]); err != nil {
-						ll.Error().Msgf("failed to send voyage summary message: %s", err)
-					}
-				}
-			}
-		}(st, et, &r, ev)
+	if len(ef) <= 1 {
+		return nil
+	}
+	du, err := b.Session.User(u.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user information from Discord: %w", err)
+	}
+	eb := []*discordgo.MessageEmbed{
+		{
+			Title:  p.Sprintf("Sea of Thieves voyage summary for @%s", du.Username),
+			Type:   discordgo.EmbedTypeRich,
+			Fields: ef,
+		},
+	}
+
+	no := &notify.Notification{
+		Type:     notify.TypeSessionSummary,
+		User:     u,
+		Embed:    eb[0],
+		GuildID:  gid,
+		DedupKey: fmt.Sprintf("summary-%d", s),
+		NoPing:   true,
+	}
+	if _, err := b.notifier().Notify(no); err != nil {
+		return err
 	}
+	return nil
 }
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+func TestBot_sendVoyageSummary(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	st := now.Add(-time.Minute * 30)
+	tt := []struct {
+		name     string
+		announce bool
+	}{
+		{"summaries enabled", true},
+		{"summaries disabled", false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			s.AddUser(&discordgo.User{ID: "200", Username: "bob"})
+			db.onArg("FROM guilds", "300", []driver.Value{
+				int64(5), "300", "Crew", "201", st, "401", []byte(testEncryptionKey), int64(1), st, st,
+			})
+			db.pref(t, "guild_prefs", model.GuildPrefAnnounceSoTSummary, tc.announce)
+			db.pref(t, "guild_prefs", model.GuildPrefAnnounceChannel, "400")
+			u := &model.User{ID: 7, UserID: "200"}
+			uss := &model.UserStat{Gold: 1000, ChestsHandedIn: 10, CreateTime: st}
+			use := &model.UserStat{Gold: 4000, ChestsHandedIn: 13, CreateTime: now}
+
+			if err := b.sendVoyageSummary(u, "300", st.Unix(), time.Minute*30, uss, use); err != nil {
+				t.Fatalf("sendVoyageSummary failed: %s", err)
+			}
+			ml := s.ChannelMessages("400")
+			if !tc.announce {
+				if len(s.Messages()) != 0 {
+					t.Errorf("sendVoyageSummary failed, expected no message, got: %d", len(s.Messages()))
+				}
+				return
+			}
+			if len(ml) != 1 {
+				t.Fatalf("sendVoyageSummary failed, expected 1 message in announce channel, got: %d", len(ml))
+			}
+			if len(ml[0].Embeds) != 1 {
+				t.Fatalf("sendVoyageSummary failed, expected 1 embed, got: %d", len(ml[0].Embeds))
+			}
+			e := ml[0].Embeds[0]
+			if e.Title != "Sea of Thieves voyage summary for @bob" {
+				t.Errorf("sendVoyageSummary failed, unexpected title: %q", e.Title)
+			}
+			var vl []string
+			for _, f := range e.Fields {
+				vl = append(vl, f.Value)
+			}
+			for _, f := range []string{"**3,000** Gold", "**3** handed in", "**30m0s** played"} {
+				if !strings.Contains(strings.Join(vl, "\n"), f) {
+					t.Errorf("sendVoyageSummary failed, expected a field with: %q, got: %q", f, vl)
+				}
+			}
+		})
+	}
+}
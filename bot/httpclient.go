@@ -21,6 +21,13 @@ type HTTPClient struct {
 	*http.Client
 }
 
+// SoTClient is the interface for the HTTP client that is used to query the Sea of Thieves
+// and rarethief.com APIs
+type SoTClient interface {
+	HTTPReq(p string, m HTTPReqMethod, q map[string]string) (*HTTPRequest, error)
+	Fetch(r *HTTPRequest) ([]byte, *http.Response, error)
+}
+
 // HTTPRequest is an object wrapper for the Go http.Request
 type HTTPRequest struct {
 	*http.Request
@@ -75,6 +82,15 @@ func NewHTTPClient() (*HTTPClient, error) {
 	return &HTTPClient{hc}, nil
 }
 
+// sotClient returns the SoTClient injected into the Bot or a new HTTPClient if none was provided.
+// A fresh HTTPClient is used per request, so that the cookie jars of different users don't mix
+func (b *Bot) sotClient() (SoTClient, error) {
+	if b.sot != nil {
+		return b.sot, nil
+	}
+	return NewHTTPClient()
+}
+
 // HTTPReq generates a HTTPRequest based on the Request method and request URI
 func (h *HTTPClient) HTTPReq(p string, m HTTPReqMethod, q map[string]string) (*HTTPRequest, error) {
 	u, err := url.Parse(p)
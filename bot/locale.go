@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/wneessen/arrgo/locale"
+	"github.com/wneessen/arrgo/model"
+)
+
+// discordLocales maps the supported languages to the Discord locales of the slash command localizations
+var discordLocales = map[language.Tag]discordgo.Locale{
+	language.German: discordgo.German,
+}
+
+// InteractionLanguageTTL is the duration for which the resolved language of an interaction is kept. It
+// matches the lifetime of the interaction token
+const InteractionLanguageTTL = time.Minute * 15
+
+// interactionLang is the resolved language of an interaction
+type interactionLang struct {
+	lang    language.Tag
+	expires time.Time
+}
+
+// interactionLanguage returns the language for the response to an interaction. The language override of
+// the user takes precedence over the locale of the user's Discord client and the locale of the guild. The
+// client locale of registered users is remembered for notifications outside of interactions. The language
+// is only resolved once per interaction
+func (b *Bot) interactionLanguage(i *discordgo.Interaction) language.Tag {
+	if l, ok := b.loadInteractionLanguage(i.ID); ok {
+		return l
+	}
+	var ol string
+	if uid := interactionUserID(i); uid != "" {
+		if u, err := b.Model.User.GetByUserID(uid); err == nil {
+			ol = b.userLanguageOverride(u)
+			if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+				b.rememberUserLocale(u, string(i.Locale))
+			}
+		}
+	}
+	gl := ""
+	if i.GuildLocale != nil {
+		gl = string(*i.GuildLocale)
+	}
+	l := locale.Match(ol, string(i.Locale), gl)
+	b.storeInteractionLanguage(i.ID, l)
+	return l
+}
+
+// storeInteractionLanguage stores the resolved language of the interaction with the given ID and removes
+// expired languages
+func (b *Bot) storeInteractionLanguage(id string, l language.Tag) {
+	if id == "" {
+		return
+	}
+	b.lgmu.Lock()
+	defer b.lgmu.Unlock()
+	if b.langs == nil {
+		b.langs = make(map[string]*interactionLang)
+	}
+	n := b.clock.Now()
+	for ek, el := range b.langs {
+		if n.After(el.expires) {
+			delete(b.langs, ek)
+		}
+	}
+	b.langs[id] = &interactionLang{lang: l, expires: n.Add(InteractionLanguageTTL)}
+}
+
+// loadInteractionLanguage returns the resolved language of the interaction with the given ID, unless it
+// has expired
+func (b *Bot) loadInteractionLanguage(id string) (language.Tag, bool) {
+	b.lgmu.Lock()
+	defer b.lgmu.Unlock()
+	il, ok := b.langs[id]
+	if !ok || b.clock.Now().After(il.expires) {
+		return language.Tag{}, false
+	}
+	return il.lang, true
+}
+
+// forgetInteractionLanguage removes the resolved language of the interaction with the given ID, so that
+// it is resolved again, e.g. after the user changed their language
+func (b *Bot) forgetInteractionLanguage(id string) {
+	b.lgmu.Lock()
+	defer b.lgmu.Unlock()
+	delete(b.langs, id)
+}
+
+// printer returns a message.Printer in the language of the given interaction
+func (b *Bot) printer(i *discordgo.Interaction) *message.Printer {
+	return locale.NewPrinter(b.interactionLanguage(i))
+}
+
+// userPrinter returns a message.Printer in the language of the given user for messages that are not
+// a response to an interaction
+func (b *Bot) userPrinter(u *model.User) *message.Printer {
+	if u == nil {
+		return locale.NewPrinter(locale.Default)
+	}
+	l, err := b.Model.User.GetPrefString(u, model.UserPrefLocale)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		b.Log.Warn().Msgf("failed to read locale of user %d: %s", u.ID, err)
+	}
+	return locale.NewPrinter(locale.Match(b.userLanguageOverride(u), l))
+}
+
+// guildPrinter returns a message.Printer in the language of the given guild for messages that are not
+// a response to an interaction
+func (b *Bot) guildPrinter(g *model.Guild) *message.Printer {
+	l, err := b.Model.Guild.GetPrefString(g, model.GuildPrefLocale)
+	if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+		b.Log.Warn().Msgf("failed to read locale of guild %d: %s", g.ID, err)
+	}
+	return locale.NewPrinter(locale.Match(l))
+}
+
+// userLanguageOverride returns the language the user has chosen with the /language command
+func (b *Bot) userLanguageOverride(u *model.User) string {
+	l, err := b.Model.User.GetPrefString(u, model.UserPrefLanguage)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		b.Log.Warn().Msgf("failed to read language override of user %d: %s", u.ID, err)
+	}
+	return l
+}
+
+// rememberUserLocale stores the Discord client locale of the user if it changed
+func (b *Bot) rememberUserLocale(u *model.User, l string) {
+	if l == "" {
+		return
+	}
+	ol, err := b.Model.User.GetPrefString(u, model.UserPrefLocale)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		b.Log.Warn().Msgf("failed to read locale of user %d: %s", u.ID, err)
+		return
+	}
+	if ol == l {
+		return
+	}
+	if err := b.Model.User.SetPref(u, model.UserPrefLocale, l); err != nil {
+		b.Log.Warn().Msgf("failed to store locale of user %d: %s", u.ID, err)
+	}
+}
+
+// localizeError returns the message of the given error in the given language. If the message itself has
+// no translation, the first translatable error in the chain of wrapped errors is translated
+func localizeError(t language.Tag, err error) string {
+	em := err.Error()
+	if v, ok := locale.Lookup(t, em); ok {
+		return v
+	}
+	for we := errors.Unwrap(err); we != nil; we = errors.Unwrap(we) {
+		if v, ok := locale.Lookup(t, we.Error()); ok {
+			return strings.Replace(em, we.Error(), v, 1)
+		}
+	}
+	return em
+}
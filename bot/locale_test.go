@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/language"
+)
+
+// localeInteraction returns an interaction of the given type by the given user with a German client locale
+func localeInteraction(id, uid string, t discordgo.InteractionType) *discordgo.Interaction {
+	return &discordgo.Interaction{
+		ID:     id,
+		Type:   t,
+		Locale: discordgo.German,
+		Member: &discordgo.Member{User: &discordgo.User{ID: uid}},
+	}
+}
+
+func TestBot_interactionLanguage(t *testing.T) {
+	b, _, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+	db.onArg("FROM users", "200", userRow(7, "200", nil))
+
+	i := localeInteraction("500", "200", discordgo.InteractionApplicationCommand)
+	for n := 0; n < 3; n++ {
+		if l := b.interactionLanguage(i); l != language.German {
+			t.Errorf("interactionLanguage failed, expected: %s, got: %s", language.German, l)
+		}
+	}
+	if n := db.execCount("INSERT INTO user_prefs"); n != 1 {
+		t.Errorf("interactionLanguage failed, expected the client locale to be stored once, got: %d", n)
+	}
+
+	b.forgetInteractionLanguage(i.ID)
+	_ = b.interactionLanguage(i)
+	if n := db.execCount("INSERT INTO user_prefs"); n != 2 {
+		t.Errorf("interactionLanguage failed, expected the language to be resolved again, got: %d", n)
+	}
+}
+
+func TestBot_interactionLanguage_autocomplete(t *testing.T) {
+	b, _, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+	db.onArg("FROM users", "200", userRow(7, "200", nil))
+
+	i := localeInteraction("500", "200", discordgo.InteractionApplicationCommandAutocomplete)
+	if l := b.interactionLanguage(i); l != language.German {
+		t.Errorf("interactionLanguage failed, expected: %s, got: %s", language.German, l)
+	}
+	if db.executed("user_prefs") {
+		t.Error("interactionLanguage failed, expected autocomplete interactions not to store the locale")
+	}
+}
+
+func TestBot_interactionLanguage_noUser(t *testing.T) {
+	b, _, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+	i := &discordgo.Interaction{ID: "500", Locale: discordgo.German}
+	if l := b.interactionLanguage(i); l != language.German {
+		t.Errorf("interactionLanguage failed, expected: %s, got: %s", language.German, l)
+	}
+	if db.executed("user_prefs") {
+		t.Error("interactionLanguage failed, expected no preferences to be stored without a user")
+	}
+}
@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/message"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// NotificationLogRetention is the duration after which the dedup records of sent notifications are purged
+const NotificationLogRetention = time.Hour * 24 * 30
+
+// notifier returns a notify.Notifier that delivers notifications via the Bot's Discord session
+func (b *Bot) notifier() *notify.Notifier {
+	return notify.New(b.Model, b.Session, notify.WithLogger(b.Log), notify.WithClock(b.clock.Now))
+}
+
+// ScheduledEventFlushNotifications delivers the queued notifications of users whose quiet hours ended
+func (b *Bot) ScheduledEventFlushNotifications() error {
+	ll := b.Log.With().Str("context", "bot.ScheduledEventFlushNotifications").Logger()
+	n, err := b.notifier().Flush()
+	if n > 0 {
+		ll.Debug().Msgf("delivered %d queued notifications", n)
+	}
+	return err
+}
+
+// routeString returns the human-readable description of the given notify.Route in the language of the
+// given message.Printer
+func routeString(p *message.Printer, rt notify.Route) string {
+	switch rt.Delivery {
+	case notify.DeliveryChannel:
+		return p.Sprintf("ping in <#%s>", rt.ChannelID)
+	case notify.DeliveryRole:
+		return p.Sprintf("<@&%s> mention in <#%s>", rt.RoleID, rt.ChannelID)
+	case notify.DeliveryMuted:
+		return p.Sprintf("muted")
+	default:
+		return p.Sprintf("direct message")
+	}
+}
+
+// setNotifyRoute stores the delivery preferences of the user for the given notification type. Empty
+// channel and role IDs remove the corresponding preference
+func (b *Bot) setNotifyRoute(u *model.User, t notify.Type, d notify.Delivery, cid, rid string) error {
+	if d == notify.DeliveryDefault {
+		if err := b.Model.User.DeletePref(u, model.UserPrefNotifyDelivery(string(t))); err != nil {
+			return fmt.Errorf("failed to remove notification delivery from DB: %w", err)
+		}
+	}
+	if d != notify.DeliveryDefault {
+		if err := b.Model.User.SetPref(u, model.UserPrefNotifyDelivery(string(t)), string(d)); err != nil {
+			return fmt.Errorf("failed to store notification delivery in DB: %w", err)
+		}
+	}
+	pl := map[model.UserPrefKey]string{
+		model.UserPrefNotifyChannel(string(t)): cid,
+		model.UserPrefNotifyRole(string(t)):    rid,
+	}
+	for k, v := range pl {
+		if v == "" {
+			if err := b.Model.User.DeletePref(u, k); err != nil {
+				return fmt.Errorf("failed to remove notification preference from DB: %w", err)
+			}
+			continue
+		}
+		if err := b.Model.User.SetPref(u, k, v); err != nil {
+			return fmt.Errorf("failed to store notification preference in DB: %w", err)
+		}
+	}
+	return nil
+}
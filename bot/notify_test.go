@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/wneessen/arrgo/notify"
+)
+
+func TestBot_ScheduledEventFlushNotifications_drop(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		name     string
+		attempts int64
+		unlog    bool
+	}{
+		{"retried", int64(notify.FlushMaxAttempts - 2), false},
+		{"dropped", int64(notify.FlushMaxAttempts - 1), true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _, db := newTestBot(t, now)
+			pl := []byte(`{"type":"milestone","embed":{"title":"Goal reached!"},"dedupKey":"goal-1",` +
+				`"dedupRecord":"dm:goal-1"}`)
+			db.on("FROM notification_queue", []driver.Value{
+				int64(3), int64(7), "milestone", pl, now.Add(-time.Minute), tc.attempts, now,
+			})
+			db.on("UPDATE notification_queue", []driver.Value{tc.attempts + 1})
+
+			// The user can not be looked up, so the delivery fails
+			if err := b.ScheduledEventFlushNotifications(); err != nil {
+				t.Fatalf("ScheduledEventFlushNotifications failed: %s", err)
+			}
+			if db.executed("DELETE FROM notification_queue") != tc.unlog {
+				t.Errorf("ScheduledEventFlushNotifications failed, expected dequeue: %t", tc.unlog)
+			}
+			if db.executed("DELETE FROM notification_log") != tc.unlog {
+				t.Errorf("ScheduledEventFlushNotifications failed, expected removal of the dedup record: %t",
+					tc.unlog)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/crypto"
+)
+
+// ComponentPage is the component handler name of the paging buttons of paginated results
+const ComponentPage = "page"
+
+// PageTTL is the duration for which the pages of a paginated result can be browsed
+const PageTTL = time.Minute * 30
+
+// MaxEmbedsPerMessage is the maximum number of embeds Discord allows in a single message
+const MaxEmbedsPerMessage = 10
+
+// pageKeyLen is the length of the random key of a paginated result
+const pageKeyLen = 12
+
+// List of paginator specific errors
+var (
+	ErrPageExpired = errors.New("this list has expired. Please run the command again")
+	ErrPageOwner   = errors.New("only the pirate who requested this list can browse it")
+)
+
+// pageState holds the pages of a paginated result
+type pageState struct {
+	owner   string
+	pages   [][]*discordgo.MessageEmbed
+	expires time.Time
+}
+
+// EmbedPages splits the given list of embeds into pages of at most n embeds
+func EmbedPages(el []*discordgo.MessageEmbed, n int) [][]*discordgo.MessageEmbed {
+	if n <= 0 || n > MaxEmbedsPerMessage {
+		n = MaxEmbedsPerMessage
+	}
+	var pl [][]*discordgo.MessageEmbed
+	for len(el) > n {
+		pl = append(pl, el[:n])
+		el = el[n:]
+	}
+	return append(pl, el)
+}
+
+// paginate edits the deferred response of the given interaction with the first of the given pages. If
+// there is more than one page, the pages are kept for PageTTL and paging buttons are added that only
+// the requesting user can use
+func (b *Bot) paginate(s DiscordAPI, i *discordgo.InteractionCreate, pl [][]*discordgo.MessageEmbed) error {
+	return b.paginateFrom(s, i, pl, 0)
+}
+
+// paginateFrom works like paginate, but shows the given page (starting at 0) first
+func (b *Bot) paginateFrom(s DiscordAPI, i *discordgo.InteractionCreate, pl [][]*discordgo.MessageEmbed,
+	pn int,
+) error {
+	if len(pl) <= 0 {
+		pl = [][]*discordgo.MessageEmbed{{}}
+	}
+	if pn < 0 || pn >= len(pl) {
+		pn = 0
+	}
+	e := pl[pn]
+	c := []discordgo.MessageComponent{}
+	if len(pl) > 1 {
+		k, err := crypto.RandomStringSecure(pageKeyLen, false, false)
+		if err != nil {
+			return fmt.Errorf("failed to generate page key: %w", err)
+		}
+		b.storePages(k, &pageState{
+			owner:   interactionUserID(i.Interaction),
+			pages:   pl,
+			expires: b.clock.Now().Add(PageTTL),
+		})
+		c, err = pageButtons(b.display(i.Interaction), k, pn, len(pl))
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e,
+		Components: &c}); err != nil {
+		return fmt.Errorf("failed to edit paginated response: %w", err)
+	}
+	return nil
+}
+
+// ComponentPageTurn handles the paging buttons of paginated results
+func (b *Bot) ComponentPageTurn(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	_, al := ParseComponentID(i.MessageComponentData().CustomID)
+	if len(al) != 2 {
+		return fmt.Errorf("invalid page custom ID: %s", i.MessageComponentData().CustomID)
+	}
+	pn, err := strconv.Atoi(al[1])
+	if err != nil {
+		return fmt.Errorf("invalid page number: %w", err)
+	}
+	ps, ok := b.loadPages(al[0])
+	if !ok {
+		return ErrPageExpired
+	}
+	if ps.owner != interactionUserID(i.Interaction) {
+		return ErrPageOwner
+	}
+	if pn < 0 || pn >= len(ps.pages) {
+		return fmt.Errorf("page %d out of range", pn)
+	}
+
+	c, err := pageButtons(b.display(i.Interaction), al[0], pn, len(ps.pages))
+	if err != nil {
+		return err
+	}
+	r := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     ps.pages[pn],
+			Components: c,
+		},
+	}
+	if err := s.InteractionRespond(i.Interaction, r); err != nil {
+		return fmt.Errorf("failed to respond to page turn: %w", err)
+	}
+	return nil
+}
+
+// storePages stores the given pages under the given key and removes expired pages
+func (b *Bot) storePages(k string, ps *pageState) {
+	b.pgmu.Lock()
+	defer b.pgmu.Unlock()
+	if b.pages == nil {
+		b.pages = make(map[string]*pageState)
+	}
+	n := b.clock.Now()
+	for ek, es := range b.pages {
+		if n.After(es.expires) {
+			delete(b.pages, ek)
+		}
+	}
+	b.pages[k] = ps
+}
+
+// loadPages returns the pages stored under the given key, unless they have expired
+func (b *Bot) loadPages(k string) (*pageState, bool) {
+	b.pgmu.Lock()
+	defer b.pgmu.Unlock()
+	ps, ok := b.pages[k]
+	if !ok || b.clock.Now().After(ps.expires) {
+		return nil, false
+	}
+	return ps, true
+}
+
+// pageButtons returns the paging buttons for the given page of the paginated result with the given key
+func pageButtons(p *Display, k string, pn, pc int) ([]discordgo.MessageComponent, error) {
+	var il []string
+	for _, a := range []string{strconv.Itoa(pn - 1), "current", strconv.Itoa(pn + 1)} {
+		ci, err := NewComponentID(ComponentPage, k, a)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create page button ID: %w", err)
+		}
+		il = append(il, ci)
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Previous"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: il[0],
+					Disabled: pn <= 0,
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Page %d of %d", pn+1, pc),
+					Style:    discordgo.SecondaryButton,
+					CustomID: il[1],
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Next"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: il[2],
+					Disabled: pn >= pc-1,
+				},
+			},
+		},
+	}, nil
+}
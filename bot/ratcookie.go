@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// RATCookieHelpURL points to the documentation on how to obtain a RAT cookie for the /setrat command
+const RATCookieHelpURL = "https://github.com/wneessen/arrgo#getting-access-to-the-api"
+
+// MaxRATReminderStages is the maximum number of RAT cookie reminder stages a user can configure
+const MaxRATReminderStages = 5
+
+// ErrRATReminderStages is returned if the provided RAT cookie reminder stages could not be parsed
+var ErrRATReminderStages = fmt.Errorf("reminder stages need to be a comma-separated list of up to %d "+
+	"durations between 1m and 336h, e.g. \"24h,6h,1h\"", MaxRATReminderStages)
+
+// ParseRATReminderStages parses a comma-separated list of durations before the RAT cookie expiry at which
+// a reminder should be sent. The stages are returned in descending order
+func ParseRATReminderStages(v string) ([]time.Duration, error) {
+	var sl []time.Duration
+	sm := make(map[time.Duration]bool)
+	for _, sv := range strings.Split(v, ",") {
+		sv = strings.TrimSpace(sv)
+		if sv == "" {
+			continue
+		}
+		d, err := time.ParseDuration(sv)
+		if err != nil || d < time.Minute || d > DefaultRATCookieLifetime {
+			return nil, ErrRATReminderStages
+		}
+		if sm[d] {
+			continue
+		}
+		sm[d] = true
+		sl = append(sl, d)
+	}
+	if len(sl) == 0 || len(sl) > MaxRATReminderStages {
+		return nil, ErrRATReminderStages
+	}
+	sort.Slice(sl, func(x, y int) bool { return sl[x] > sl[y] })
+	return sl, nil
+}
+
+// ratReminderStages returns the RAT cookie reminder stages of the given user. If the user has not
+// configured any stages, the stages of the bot config are used
+func (b *Bot) ratReminderStages(u *model.User) []time.Duration {
+	sv, err := b.Model.User.GetPrefString(u, model.UserPrefRATReminderStages)
+	if err == nil {
+		if sl, err := ParseRATReminderStages(sv); err == nil {
+			return sl
+		}
+	}
+	sl, err := ParseRATReminderStages(b.Config.Reminder.Stages)
+	if err != nil {
+		return []time.Duration{time.Hour * 6}
+	}
+	return sl
+}
+
+// quarantineRATCookie marks the RAT cookie of the given user as invalid after the SoT API rejected it.
+// Quarantined users are excluded from all scheduled jobs until they store a new cookie via /setrat. The
+// user is notified right away
+func (b *Bot) quarantineRATCookie(u *model.User) error {
+	if err := b.Model.User.SetPref(u, model.UserPrefSoTAuthTokenInvalid, true); err != nil {
+		return fmt.Errorf("failed to mark RAT cookie as invalid in DB: %w", err)
+	}
+	b.Log.Info().Str("context", "bot.quarantineRATCookie").Msgf("RAT cookie of user %d has been "+
+		"rejected by the SoT API and is quarantined", u.ID)
+
+	na, err := b.Model.User.GetPrefBool(u, model.UserPrefSoTAuthTokenNotified)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return fmt.Errorf("failed to retrieve RAT cookie already notified status from DB: %w", err)
+	}
+	if na {
+		return nil
+	}
+	dk := "invalid"
+	if u.RATExpire != nil {
+		dk = fmt.Sprintf("invalid-%d", u.RATExpire.Unix())
+	}
+	p := b.userPrinter(u)
+	if err := b.sendRATReminder(p, u, p.Sprintf("Your SoT RAT cookie has been rejected by the Sea of "+
+		"Thieves API. I won't use it anymore. Please use the `/setrat` command to set a new one."), dk); err != nil {
+		return err
+	}
+	if err := b.Model.User.SetPref(u, model.UserPrefSoTAuthTokenNotified, true); err != nil {
+		return fmt.Errorf("failed to set 'user notified' user pref in DB: %w", err)
+	}
+	return nil
+}
+
+// sendRATReminder sends the given RAT cookie reminder to the user via the notification subsystem. The
+// reminder is only sent once per dedup key
+func (b *Bot) sendRATReminder(p *message.Printer, u *model.User, m, dk string) error {
+	no := &notify.Notification{
+		Type: notify.TypeCookieExpiry,
+		User: u,
+		Embed: &discordgo.MessageEmbed{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf("Sea of Thieves authentication cookie"),
+			Description: m,
+		},
+		Links:    []notify.Link{{Label: p.Sprintf("How to get a new cookie"), URL: RATCookieHelpURL}},
+		DedupKey: dk,
+	}
+	if _, err := b.notifier().Notify(no); err != nil {
+		return fmt.Errorf("failed to send RAT cookie reminder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// List of gateway event types that are recorded by the EventRecorder
+const (
+	EventTypeGuildCreate       = "GUILD_CREATE"
+	EventTypeGuildDelete       = "GUILD_DELETE"
+	EventTypeInteractionCreate = "INTERACTION_CREATE"
+	EventTypePresenceUpdate    = "PRESENCE_UPDATE"
+)
+
+// recordRedactedCommands are the slash commands whose option values contain credentials. The values
+// are redacted before an interaction with these commands is recorded
+var recordRedactedCommands = map[string]bool{
+	"setrat": true,
+}
+
+// RecordRedacted is the value that replaces the option values of redacted interactions in a recording
+const RecordRedacted = "[redacted]"
+
+// RecordedEvent represents a single gateway event in a recording. Recordings are stored as
+// JSONL, with one RecordedEvent per line
+type RecordedEvent struct {
+	Time time.Time       `json:"time"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// EventRecorder writes the relevant gateway events the bot receives to an io.Writer
+type EventRecorder struct {
+	mu sync.Mutex
+	c  Clock
+	e  *json.Encoder
+}
+
+// NewEventRecorder returns a new EventRecorder that writes to the given io.Writer
+func NewEventRecorder(w io.Writer) *EventRecorder {
+	return &EventRecorder{c: wallClock{}, e: json.NewEncoder(w)}
+}
+
+// WithEventRecorder enables the recording of incoming gateway events
+func WithEventRecorder(r *EventRecorder) Option {
+	return func(b *Bot) {
+		b.rec = r
+	}
+}
+
+// Record writes the given event to the recording, if it is of a relevant event type
+func (r *EventRecorder) Record(t string, d json.RawMessage) error {
+	switch t {
+	case EventTypeGuildCreate, EventTypeGuildDelete, EventTypeInteractionCreate, EventTypePresenceUpdate:
+	default:
+		return nil
+	}
+	if t == EventTypeInteractionCreate {
+		rd, err := redactInteraction(d)
+		if err != nil {
+			return err
+		}
+		d = rd
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.e.Encode(RecordedEvent{Time: r.c.Now(), Type: t, Data: d})
+}
+
+// redactInteraction replaces the option values of interactions with the recordRedactedCommands in the
+// given raw INTERACTION_CREATE payload, so that no credentials end up in a recording
+func redactInteraction(d json.RawMessage) (json.RawMessage, error) {
+	var ev map[string]interface{}
+	if err := json.Unmarshal(d, &ev); err != nil {
+		return nil, fmt.Errorf("failed to decode interaction: %w", err)
+	}
+	dm, ok := ev["data"].(map[string]interface{})
+	if !ok {
+		return d, nil
+	}
+	if n, _ := dm["name"].(string); !recordRedactedCommands[n] {
+		return d, nil
+	}
+	redactOptions(dm["options"])
+	rd, err := json.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode redacted interaction: %w", err)
+	}
+	return rd, nil
+}
+
+// redactOptions replaces the values of the given interaction options and their sub-options
+func redactOptions(ol interface{}) {
+	l, ok := ol.([]interface{})
+	if !ok {
+		return
+	}
+	for _, o := range l {
+		om, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := om["value"]; ok {
+			om["value"] = RecordRedacted
+		}
+		redactOptions(om["options"])
+	}
+}
+
+// recordEvent is the gateway handler that receives all raw events from the discordgo.Session
+// and passes them to the EventRecorder
+func (b *Bot) recordEvent(_ *discordgo.Session, ev *discordgo.Event) {
+	if err := b.rec.Record(ev.Type, ev.RawData); err != nil {
+		b.Log.Warn().Str("context", "bot.recordEvent").Msgf("failed to record %s event: %s", ev.Type, err)
+	}
+}
+
+// DispatchEvent decodes a RecordedEvent and passes it to the corresponding handler of the Bot
+func (b *Bot) DispatchEvent(ev RecordedEvent) error {
+	switch ev.Type {
+	case EventTypeGuildCreate:
+		e := &discordgo.GuildCreate{}
+		if err := json.Unmarshal(ev.Data, e); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", ev.Type, err)
+		}
+		b.GuildCreate(nil, e)
+	case EventTypeGuildDelete:
+		e := &discordgo.GuildDelete{}
+		if err := json.Unmarshal(ev.Data, e); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", ev.Type, err)
+		}
+		b.GuildDelete(nil, e)
+	case EventTypeInteractionCreate:
+		e := &discordgo.InteractionCreate{}
+		if err := json.Unmarshal(ev.Data, e); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", ev.Type, err)
+		}
+		b.SlashCommandHandler(nil, e)
+		b.ComponentHandler(nil, e)
+		b.AutocompleteHandler(nil, e)
+	case EventTypePresenceUpdate:
+		e := &discordgo.PresenceUpdate{}
+		if err := json.Unmarshal(ev.Data, e); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", ev.Type, err)
+		}
+		b.UserPlaySoT(nil, e)
+	default:
+		return fmt.Errorf("unsupported event type: %s", ev.Type)
+	}
+	return nil
+}
@@ -24,6 +24,8 @@ var (
 		"Please store your cookie with the **/setrat** command first")
 	ErrRATCookieExpired = errors.New("your Sea of Thieves authentication token is expired. " +
 		"Please use the **/setrat** command to update your token")
+	ErrRATCookieInvalid = errors.New("your Sea of Thieves authentication token has been rejected by the " +
+		"Sea of Thieves API. Please use the **/setrat** command to update your token")
 	ErrMemberNil = errors.New("provided Member pointer must not be nil")
 	ErrUserNil   = errors.New("provided User pointer must not be nil")
 )
@@ -66,6 +68,13 @@ func (r *Requester) GetSoTRATCookie() (string, error) {
 	if r.User == nil {
 		return "", ErrUserNil
 	}
+	iv, err := r.UserModel.GetPrefBool(r.User, model.UserPrefSoTAuthTokenInvalid)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return "", err
+	}
+	if iv {
+		return "", ErrRATCookieInvalid
+	}
 	c, err := r.UserModel.GetPrefStringEnc(r.User, model.UserPrefSoTAuthToken)
 	if err != nil {
 		return "", ErrUserHasNoRATCookie
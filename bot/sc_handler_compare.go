@@ -1,117 +1,167 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// SessionSnapshotDelay is the time after the end of a play session within which the stats snapshot of
+// the voyage summary is stored
+const SessionSnapshotDelay = time.Minute * 5
+
+// List of /compare periods that depend on the stored data of the user
+const (
+	// PeriodLastSession is the /compare period of the last Sea of Thieves play session of the user
+	PeriodLastSession = "last-session"
+
+	// PeriodThisSeason is the /compare period of the current Sea of Thieves season. It starts with the
+	// first season progress of the user that was stored in the current season
+	PeriodThisSeason = "this-season"
+)
+
+// List of /compare specific errors
+var (
+	ErrCompareOptions = errors.New("please provide only one of the options hours, days, period or window")
+	ErrCompareNoData  = errors.New("there are not enough stored stats snapshots within the chosen time " +
+		"window to compare")
+	ErrNoLastSession = errors.New("there is no recorded Sea of Thieves play session yet. Sessions are " +
+		"recorded if you share your game activity with Discord")
+	ErrNoSeasonStart = errors.New("there is no stored Sea of Thieves season progress yet. The progress is " +
+		"stored if you have set a valid RAT cookie")
 )
 
+// ComparePeriods is the list of named periods of the /compare period option
+var ComparePeriods = []struct {
+	Name  string
+	Value string
+}{
+	{Name: "Last 24 hours", Value: "24h"},
+	{Name: "Last 7 days", Value: "7d"},
+	{Name: "Last 30 days", Value: "30d"},
+	{Name: "Today", Value: PeriodToday},
+	{Name: "Yesterday", Value: PeriodYesterday},
+	{Name: "This week", Value: PeriodThisWeek},
+	{Name: "Last week", Value: PeriodLastWeek},
+	{Name: "This month", Value: PeriodThisMonth},
+	{Name: "Last month", Value: PeriodLastMonth},
+	{Name: "Last session", Value: PeriodLastSession},
+	{Name: "This season", Value: PeriodThisSeason},
+}
+
 // SlashCmdSoTCompare handles the /compare slash command
-func (b *Bot) SlashCmdSoTCompare(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	ol := i.ApplicationCommandData().Options
-	if len(ol) <= 0 {
-		return fmt.Errorf("no duration given")
-	}
-	ds, ok := ol[0].Value.(string)
-	if !ok {
-		return fmt.Errorf("failed to cast provided hour value as string")
-	}
-	d, err := time.ParseDuration(fmt.Sprintf("-%sh", ds))
+func (b *Bot) SlashCmdSoTCompare(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
 	if err != nil {
 		return err
 	}
-	ots := time.Now().Add(d)
-
-	r, err := b.NewRequester(i.Interaction)
+	p := b.display(i.Interaction)
+	w, wl, err := b.compareWindow(r.User, i.ApplicationCommandData().Options, p)
 	if err != nil {
 		return err
 	}
-	if err := b.StoreSoTUserStats(r); err != nil {
-		return fmt.Errorf("failed to update user stats in DB: %w", err)
+
+	var cus *model.UserStat
+	if b.clock.Now().Sub(w.To) < time.Minute {
+		if err := b.StoreSoTUserStats(r); err != nil {
+			return fmt.Errorf("failed to update user stats in DB: %w", err)
+		}
+		cus, err = b.Model.UserStats.GetByUserID(r.User.ID)
+	} else {
+		cus, err = b.Model.UserStats.GetByUserIDBeforeTime(r.User.ID, w.To)
 	}
-	cus, err := b.Model.UserStats.GetByUserID(r.User.ID)
 	if err != nil {
+		if errors.Is(err, model.ErrUserStatNotExistent) {
+			return ErrCompareNoData
+		}
 		return err
 	}
-	ous, err := b.Model.UserStats.GetByUserIDAtTime(r.User.ID, ots)
+	ous, err := b.Model.UserStats.GetByUserIDAtTime(r.User.ID, w.From)
 	if err != nil {
+		if errors.Is(err, model.ErrUserStatNotExistent) {
+			return ErrCompareNoData
+		}
 		return err
 	}
+	if !ous.CreateTime.Before(cus.CreateTime) {
+		return ErrCompareNoData
+	}
 
-	p := message.NewPrinter(language.German)
 	var ef []*discordgo.MessageEmbedField
 	if cus.Gold != ous.Gold {
 		v := cus.Gold - ous.Gold
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Gold", IconGold),
-			Value:  fmt.Sprintf("%s **%s** Gold", changeIcon(v), p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Gold", IconGold),
+			Value:  p.Sprintf("%s **%s** Gold", changeIcon(v), p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.Doubloons != ous.Doubloons {
 		v := cus.Doubloons - ous.Doubloons
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Doubloons", IconDoubloon),
-			Value:  fmt.Sprintf("%s **%s** Doubloons", changeIcon(v), p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Doubloons", IconDoubloon),
+			Value:  p.Sprintf("%s **%s** Doubloons", changeIcon(v), p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.AncientCoins != ous.AncientCoins {
 		v := cus.AncientCoins - ous.AncientCoins
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Ancient Coins", IconAncientCoin),
-			Value:  fmt.Sprintf("%s **%s** Ancient Coins", changeIcon(v), p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Ancient Coins", IconAncientCoin),
+			Value:  p.Sprintf("%s **%s** Ancient Coins", changeIcon(v), p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.KrakenDefeated != ous.KrakenDefeated {
 		v := cus.KrakenDefeated - ous.KrakenDefeated
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Kraken", IconKraken),
-			Value:  fmt.Sprintf("**%s** defeated", p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Kraken", IconKraken),
+			Value:  p.Sprintf("**%s** defeated", p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.MegalodonEnounter != ous.MegalodonEnounter {
 		v := cus.MegalodonEnounter - ous.MegalodonEnounter
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Megalodon", IconMegalodon),
-			Value:  fmt.Sprintf("**%s** encounter(s)", p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Megalodon", IconMegalodon),
+			Value:  p.Sprintf("**%s** encounter(s)", p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.ChestsHandedIn != ous.ChestsHandedIn {
 		v := cus.ChestsHandedIn - ous.ChestsHandedIn
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Chests", IconChest),
-			Value:  fmt.Sprintf("**%s** handed in", p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Chests", IconChest),
+			Value:  p.Sprintf("**%s** handed in", p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.ShipsSunk != ous.ShipsSunk {
 		v := cus.ShipsSunk - ous.ShipsSunk
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Other Ships", IconShip),
-			Value:  fmt.Sprintf("**%s** sunk", p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Other Ships", IconShip),
+			Value:  p.Sprintf("**%s** sunk", p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.VomittedTimes != ous.VomittedTimes {
 		v := cus.VomittedTimes - ous.VomittedTimes
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Vomitted", IconVomit),
-			Value:  fmt.Sprintf("**%s** times", p.Sprintf("%d", v)),
+			Name:   p.Sprintf("%s Vomitted", IconVomit),
+			Value:  p.Sprintf("**%s** times", p.Int(v)),
 			Inline: true,
 		})
 	}
 	if cus.DistanceSailed != ous.DistanceSailed {
 		v := cus.DistanceSailed - ous.DistanceSailed
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Distance", IconDistance),
-			Value:  fmt.Sprintf("**%s** nmi sailed", p.Sprintf("%d", v/1852)),
+			Name:   p.Sprintf("%s Distance", IconDistance),
+			Value:  p.Sprintf("**%s** sailed", p.Distance(v)),
 			Inline: true,
 		})
 	}
@@ -123,25 +173,17 @@ func (b *Bot) SlashCmdSoTCompare(s *discordgo.Session, i *discordgo.InteractionC
 		})
 	}
 
-	var e []*discordgo.MessageEmbed
-	if len(ef) > 0 {
-		e = []*discordgo.MessageEmbed{
-			{
-				Title: fmt.Sprintf("Your user statistics overview compared to %s hours ago in Sea of Thieves",
-					ds),
-				Type:   discordgo.EmbedTypeRich,
-				Fields: ef,
-			},
-		}
-	} else {
-		e = []*discordgo.MessageEmbed{
-			{
-				Title: fmt.Sprintf("None of your users stats in Sea of Thieves changed within the last "+
-					"%s hours", ds),
-				Type:   discordgo.EmbedTypeRich,
-				Fields: ef,
-			},
-		}
+	e := []*discordgo.MessageEmbed{
+		{
+			Title:  p.Sprintf("Your Sea of Thieves stats changes: %s", wl),
+			Type:   discordgo.EmbedTypeRich,
+			Fields: ef,
+			Description: p.Sprintf("Compared the snapshots of %s and %s", p.Time(ous.CreateTime),
+				p.Time(cus.CreateTime)),
+		},
+	}
+	if len(ef) <= 0 {
+		e[0].Title = p.Sprintf("None of your Sea of Thieves stats changed: %s", wl)
 	}
 
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -149,3 +191,144 @@ func (b *Bot) SlashCmdSoTCompare(s *discordgo.Session, i *discordgo.InteractionC
 	}
 	return nil
 }
+
+// compareWindow returns the time window and its label for the given /compare options. Without options
+// the last 24 hours are compared
+func (b *Bot) compareWindow(u *model.User, ol []*discordgo.ApplicationCommandInteractionDataOption,
+	p *Display,
+) (Window, string, error) {
+	if len(ol) > 1 {
+		return Window{}, "", ErrCompareOptions
+	}
+	n := b.clock.Now()
+	if len(ol) <= 0 {
+		return Window{From: n.Add(time.Hour * -24), To: n}, p.Sprintf("Last 24 hours"), nil
+	}
+
+	o := ol[0]
+	switch o.Name {
+	case "hours":
+		h := o.IntValue()
+		return Window{From: n.Add(time.Duration(-h) * time.Hour), To: n}, p.Sprintf("Last %d hours", h), nil
+	case "days":
+		d := o.IntValue()
+		return Window{From: n.AddDate(0, 0, int(-d)), To: n}, p.Sprintf("Last %d days", d), nil
+	case "period":
+		v := o.StringValue()
+		wl := v
+		for _, cp := range ComparePeriods {
+			if cp.Value == v {
+				wl = p.Sprintf(cp.Name)
+			}
+		}
+		w, err := b.userWindow(u, v, n, p)
+		return w, wl, err
+	default:
+		w, err := b.userWindow(u, o.StringValue(), n, p)
+		return w, o.StringValue(), err
+	}
+}
+
+// userWindow parses the given time window like ParseWindow, but also resolves the periods that depend on
+// the stored data of the given user
+func (b *Bot) userWindow(u *model.User, v string, n time.Time, p *Display) (Window, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case PeriodLastSession:
+		return b.lastSessionWindow(u)
+	case PeriodThisSeason:
+		return b.seasonWindow(u)
+	default:
+		return ParseWindow(v, n, p.tz)
+	}
+}
+
+// seasonWindow returns the time window of the current Sea of Thieves season of the given user. It starts
+// with the first stored season progress of the latest season of the user
+func (b *Bot) seasonWindow(u *model.User) (Window, error) {
+	ls, err := b.Model.UserSeason.GetLatestByUserID(u.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserSeasonNotExistent) {
+			return Window{}, ErrNoSeasonStart
+		}
+		return Window{}, fmt.Errorf("failed to read season progress from DB: %w", err)
+	}
+	fs, err := b.Model.UserSeason.GetByUserIDAtTime(u.ID, ls.Season, time.Time{})
+	if err != nil {
+		if errors.Is(err, model.ErrUserSeasonNotExistent) {
+			return Window{}, ErrNoSeasonStart
+		}
+		return Window{}, fmt.Errorf("failed to read season progress from DB: %w", err)
+	}
+	return Window{From: fs.CreateTime, To: b.clock.Now()}, nil
+}
+
+// lastSessionWindow returns the time window of the last (or current) Sea of Thieves play session of the
+// given user
+func (b *Bot) lastSessionWindow(u *model.User) (Window, error) {
+	st, err := b.Model.User.GetPrefInt64(u, model.UserPrefPlaysSoTStartTime)
+	if err != nil {
+		if errors.Is(err, model.ErrUserPrefNotExistent) {
+			return Window{}, ErrNoLastSession
+		}
+		return Window{}, fmt.Errorf("failed to read session start time from DB: %w", err)
+	}
+	w := Window{From: time.Unix(st, 0), To: b.clock.Now()}
+	pl, err := b.Model.User.GetPrefBool(u, model.UserPrefPlaysSoT)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return Window{}, fmt.Errorf("failed to read session state from DB: %w", err)
+	}
+	if pl {
+		return w, nil
+	}
+	et, err := b.Model.User.GetPrefInt64(u, model.UserPrefPlaysSoTEndTime)
+	if err != nil {
+		if errors.Is(err, model.ErrUserPrefNotExistent) {
+			return Window{}, ErrNoLastSession
+		}
+		return Window{}, fmt.Errorf("failed to read session end time from DB: %w", err)
+	}
+	if te := time.Unix(et, 0).Add(SessionSnapshotDelay); te.Before(w.To) {
+		w.To = te
+	}
+	return w, nil
+}
+
+// compareCommandOptions returns the options of the /compare slash command
+func compareCommandOptions() []*discordgo.ApplicationCommandOption {
+	var pc []*discordgo.ApplicationCommandOptionChoice
+	for _, cp := range ComparePeriods {
+		pc = append(pc, &discordgo.ApplicationCommandOptionChoice{Name: cp.Name, Value: cp.Value})
+	}
+	hmin, dmin := 1.0, 1.0
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "hours",
+			Description: "Compare with the stats of the given number of hours ago",
+			Required:    false,
+			MinValue:    &hmin,
+			MaxValue:    8760,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "days",
+			Description: "Compare with the stats of the given number of days ago",
+			Required:    false,
+			MinValue:    &dmin,
+			MaxValue:    365,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "period",
+			Description: "Compare the stats of a named period",
+			Required:    false,
+			Choices:     pc,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "window",
+			Description: "Compare a custom time window like 36h, 2w3d, 2024-05-01 or 2024-05-01..2024-05-07",
+			Required:    false,
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// statsRow returns a row of the user_stats table with the given gold, chests and creation time
+func statsRow(uid int64, gold, chests int64, ct time.Time) []driver.Value {
+	return []driver.Value{
+		int64(1), uid, "Pirate", gold, int64(100), int64(10), int64(1), int64(2), chests, int64(3),
+		int64(4), int64(5000), "raw", ct,
+	}
+}
+
+// compareInteraction returns an interaction of the /compare command with the given period
+func compareInteraction(uid, period string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:      "500",
+		Type:    discordgo.InteractionApplicationCommand,
+		GuildID: "300",
+		Locale:  discordgo.EnglishUS,
+		Member:  &discordgo.Member{User: &discordgo.User{ID: uid, Username: "bob"}},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "compare",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "period", Type: discordgo.ApplicationCommandOptionString, Value: period},
+			},
+		},
+	}}
+}
+
+func TestBot_SlashCmdSoTCompare(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2023, 5, 9, 0, 5, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 9, 23, 55, 0, 0, time.UTC)
+	tt := []struct {
+		name   string
+		gold   int64
+		chests int64
+		title  string
+		fields []string
+	}{
+		{
+			"gold and chests changed", 2500, 12, "Your Sea of Thieves stats changes: Yesterday",
+			[]string{"**1,500** Gold", "**2** handed in"},
+		},
+		{"nothing changed", 1000, 10, "None of your Sea of Thieves stats changed: Yesterday", nil},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			db.onArg("FROM users", "200", userRow(7, "200", nil))
+			db.on("s.ctime >= $2", statsRow(7, 1000, 10, from))
+			db.on("s.ctime <= $2", statsRow(7, tc.gold, tc.chests, to))
+
+			if err := b.SlashCmdSoTCompare(s, compareInteraction("200", PeriodYesterday)); err != nil {
+				t.Fatalf("SlashCmdSoTCompare failed: %s", err)
+			}
+			e, ok := s.LastInteractionEdit()
+			if !ok {
+				t.Fatal("SlashCmdSoTCompare did not edit the interaction response")
+			}
+			if len(e.Embeds) != 1 {
+				t.Fatalf("SlashCmdSoTCompare failed, expected 1 embed, got: %d", len(e.Embeds))
+			}
+			if e.Embeds[0].Title != tc.title {
+				t.Errorf("SlashCmdSoTCompare failed, expected title: %q, got: %q", tc.title, e.Embeds[0].Title)
+			}
+			var vl []string
+			for _, f := range e.Embeds[0].Fields {
+				if strings.TrimSpace(f.Value) != "\U0000FEFF" {
+					vl = append(vl, f.Value)
+				}
+			}
+			if len(vl) != len(tc.fields) {
+				t.Fatalf("SlashCmdSoTCompare failed, expected %d fields, got: %q", len(tc.fields), vl)
+			}
+			for n, f := range tc.fields {
+				if !strings.Contains(vl[n], f) {
+					t.Errorf("SlashCmdSoTCompare failed, expected field %q to contain: %q", vl[n], f)
+				}
+			}
+		})
+	}
+}
+
+func TestBot_SlashCmdSoTCompare_noData(t *testing.T) {
+	b, s, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+	db.onArg("FROM users", "200", userRow(7, "200", nil))
+
+	err := b.SlashCmdSoTCompare(s, compareInteraction("200", PeriodYesterday))
+	if !errors.Is(err, ErrCompareNoData) {
+		t.Errorf("SlashCmdSoTCompare failed, expected error: %s, got: %v", ErrCompareNoData, err)
+	}
+	if _, ok := s.LastInteractionEdit(); ok {
+		t.Error("SlashCmdSoTCompare failed, expected no interaction response edit")
+	}
+}
+
+func TestBot_seasonWindow(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	st := time.Date(2023, 4, 20, 18, 0, 0, 0, time.UTC)
+	b, _, db := newTestBot(t, now)
+	db.on("FROM user_season_progress", []driver.Value{
+		int64(3), int64(7), "Season Nine", int64(2), 23.5, int64(12), int64(80), st,
+	})
+
+	u := &model.User{ID: 7, UserID: "200"}
+	w, err := b.userWindow(u, PeriodThisSeason, now, b.display(compareInteraction("200", "").Interaction))
+	if err != nil {
+		t.Fatalf("userWindow failed: %s", err)
+	}
+	if !w.From.Equal(st) || !w.To.Equal(now) {
+		t.Errorf("userWindow failed, expected: %s - %s, got: %s - %s", st, now, w.From, w.To)
+	}
+}
+
+func TestBot_SlashCmdSoTCompare_noSeason(t *testing.T) {
+	b, s, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+	db.onArg("FROM users", "200", userRow(7, "200", nil))
+
+	for _, i := range []*discordgo.InteractionCreate{
+		compareInteraction("200", PeriodThisSeason),
+		compareWindowInteraction("200", "This-Season"),
+	} {
+		err := b.SlashCmdSoTCompare(s, i)
+		if !errors.Is(err, ErrNoSeasonStart) {
+			t.Errorf("SlashCmdSoTCompare failed, expected error: %s, got: %v", ErrNoSeasonStart, err)
+		}
+	}
+}
+
+// compareWindowInteraction returns an interaction of the /compare command with the given window
+func compareWindowInteraction(uid, window string) *discordgo.InteractionCreate {
+	i := compareInteraction(uid, "")
+	i.Data = discordgo.ApplicationCommandInteractionData{
+		Name: "compare",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "window", Type: discordgo.ApplicationCommandOptionString, Value: window},
+		},
+	}
+	return i
+}
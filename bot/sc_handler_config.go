@@ -16,7 +16,7 @@ const (
 
 // SlashCmdConfig handles the /config slash command
 // All /config commands require admin or moderate-members permissions on the guild
-func (b *Bot) SlashCmdConfig(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdConfig(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	ll := b.Log.With().Str("context", "bot.SlashCmdConfig").Logger()
 	ol := i.ApplicationCommandData().Options
 
@@ -31,10 +31,13 @@ func (b *Bot) SlashCmdConfig(s *discordgo.Session, i *discordgo.InteractionCreat
 	}
 
 	// Define list of config option methods
-	co := map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate) error{
-		"flameheart-spam":      b.configFlameheart,
-		"announce-sot-summary": b.configAnnounceSoTPlaySummary,
-		"announce-channel":     b.overrideAnnounceChannel,
+	co := map[string]func(s DiscordAPI, i *discordgo.InteractionCreate) error{
+		"flameheart-spam":       b.configFlameheart,
+		"announce-sot-summary":  b.configAnnounceSoTPlaySummary,
+		"announce-achievements": b.configAnnounceAchievements,
+		"announce-deeds":        b.configAnnounceDeeds,
+		"announce-traderoutes":  b.configAnnounceTradeRoutes,
+		"announce-channel":      b.overrideAnnounceChannel,
 	}
 
 	// Check if provided command is available and process it
@@ -48,7 +51,7 @@ func (b *Bot) SlashCmdConfig(s *discordgo.Session, i *discordgo.InteractionCreat
 }
 
 // configFlameheart en-/disables the Flameheart spam for a Guild
-func (b *Bot) configFlameheart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) configFlameheart(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	nv, err := appCommandGetEnalbedDisabled(i.ApplicationCommandData().Options)
 	if err != nil {
 		return err
@@ -62,15 +65,16 @@ func (b *Bot) configFlameheart(s *discordgo.Session, i *discordgo.InteractionCre
 		return fmt.Errorf("failed to set flameheart preference in database: %w", err)
 	}
 
+	p := b.printer(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       TitleConfigUpdated,
-			Description: "The bot will not spam the server with Captain Flameheart quotes",
+			Title:       p.Sprintf(TitleConfigUpdated),
+			Description: p.Sprintf("The bot will not spam the server with Captain Flameheart quotes"),
 		},
 	}
 	if nv {
-		e[0].Description = "The bot will spam the server with Captain Flameheart quotes"
+		e[0].Description = p.Sprintf("The bot will spam the server with Captain Flameheart quotes")
 	}
 
 	// Edit the deferred message
@@ -82,7 +86,7 @@ func (b *Bot) configFlameheart(s *discordgo.Session, i *discordgo.InteractionCre
 }
 
 // overrideAnnounceChannel overrides the default system channel with a guild specific channel
-func (b *Bot) overrideAnnounceChannel(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) overrideAnnounceChannel(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	mo := i.ApplicationCommandData().Options
 	if len(mo) <= 0 {
 		return fmt.Errorf("no options found")
@@ -110,11 +114,12 @@ func (b *Bot) overrideAnnounceChannel(s *discordgo.Session, i *discordgo.Interac
 	}
 
 	// Edit the deferred message
+	p := b.printer(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       TitleConfigUpdated,
-			Description: fmt.Sprintf("The annoucment channel for this server has been set to: <#%s>", ch),
+			Title:       p.Sprintf(TitleConfigUpdated),
+			Description: p.Sprintf("The annoucment channel for this server has been set to: <#%s>", ch),
 		},
 	}
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -125,7 +130,7 @@ func (b *Bot) overrideAnnounceChannel(s *discordgo.Session, i *discordgo.Interac
 }
 
 // configAnnounceSoTPlaySummary en-/disables the announcing of SoT play summaries
-func (b *Bot) configAnnounceSoTPlaySummary(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) configAnnounceSoTPlaySummary(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	nv, err := appCommandGetEnalbedDisabled(i.ApplicationCommandData().Options)
 	if err != nil {
 		return err
@@ -139,15 +144,16 @@ func (b *Bot) configAnnounceSoTPlaySummary(s *discordgo.Session, i *discordgo.In
 		return fmt.Errorf("failed to set announce-sot-summary preference in database: %w", err)
 	}
 
+	p := b.printer(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       TitleConfigUpdated,
-			Description: "The bot will not announce a user's summary after they played SoT",
+			Title:       p.Sprintf(TitleConfigUpdated),
+			Description: p.Sprintf("The bot will not announce a user's summary after they played SoT"),
 		},
 	}
 	if nv {
-		e[0].Description = "The bot will announce a user's summary after they played SoT"
+		e[0].Description = p.Sprintf("The bot will announce a user's summary after they played SoT")
 	}
 
 	// Edit the deferred message
@@ -158,6 +164,124 @@ func (b *Bot) configAnnounceSoTPlaySummary(s *discordgo.Session, i *discordgo.In
 	return nil
 }
 
+// configAnnounceAchievements en-/disables the announcing of newly unlocked SoT achievements
+func (b *Bot) configAnnounceAchievements(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	nv, err := appCommandGetEnalbedDisabled(i.ApplicationCommandData().Options)
+	if err != nil {
+		return err
+	}
+
+	g, err := b.Model.Guild.GetByGuildID(i.GuildID)
+	if err != nil {
+		return fmt.Errorf(ErrFailedGuildLookupDB, err)
+	}
+	if err = b.Model.Guild.SetPref(g, model.GuildPrefAnnounceAchievements, nv); err != nil {
+		return fmt.Errorf("failed to set announce-achievements preference in database: %w", err)
+	}
+
+	p := b.printer(i.Interaction)
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf(TitleConfigUpdated),
+			Description: p.Sprintf("The bot will not announce newly unlocked SoT achievements of the members"),
+		},
+	}
+	if nv {
+		e[0].Description = p.Sprintf("The bot will announce newly unlocked SoT achievements of the members")
+	}
+
+	// Edit the deferred message
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /config announce-achievements request: %w", err)
+	}
+
+	return nil
+}
+
+// configAnnounceDeeds en-/disables the announcing of new deeds and expiring swift deeds
+func (b *Bot) configAnnounceDeeds(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	return b.configAnnounceWithRole(s, i, model.GuildPrefAnnounceDeeds, model.GuildPrefAnnounceDeedsRole,
+		"The bot will not announce new deeds",
+		"The bot will announce new deeds and remind of swift deeds shortly before they end")
+}
+
+// configAnnounceTradeRoutes en-/disables the announcing of new trade route rotations
+func (b *Bot) configAnnounceTradeRoutes(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	return b.configAnnounceWithRole(s, i, model.GuildPrefAnnounceTradeRoutes, model.GuildPrefAnnounceTradeRoutesRole,
+		"The bot will not announce new trade routes",
+		"The bot will announce new and changed trade routes")
+}
+
+// configAnnounceWithRole en-/disables the announcement preference k. When enabled, the optional role
+// is stored in the preference rk, so that it is mentioned in the announcements
+func (b *Bot) configAnnounceWithRole(s DiscordAPI, i *discordgo.InteractionCreate, k, rk model.GuildPrefKey,
+	dd, ed string,
+) error {
+	ol := i.ApplicationCommandData().Options
+	nv, err := appCommandGetEnalbedDisabled(ol)
+	if err != nil {
+		return err
+	}
+	var rid string
+	for _, o := range ol[0].Options[0].Options {
+		if o.Name == "role" {
+			rid = fmt.Sprint(o.Value)
+		}
+	}
+
+	g, err := b.Model.Guild.GetByGuildID(i.GuildID)
+	if err != nil {
+		return fmt.Errorf(ErrFailedGuildLookupDB, err)
+	}
+	if err = b.Model.Guild.SetPref(g, k, nv); err != nil {
+		return fmt.Errorf("failed to set %s preference in database: %w", k, err)
+	}
+	if rid != "" {
+		err = b.Model.Guild.SetPref(g, rk, rid)
+	} else {
+		err = b.Model.Guild.DeletePref(g, rk)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s preference in database: %w", rk, err)
+	}
+
+	p := b.printer(i.Interaction)
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf(TitleConfigUpdated),
+			Description: p.Sprintf(dd),
+		},
+	}
+	if nv {
+		e[0].Description = p.Sprintf(ed)
+		if rid != "" {
+			e[0].Description += "\n" + p.Sprintf("The announcements will mention <@&%s>", rid)
+		}
+	}
+
+	// Edit the deferred message
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /config %s request: %w", ol[0].Name, err)
+	}
+
+	return nil
+}
+
+// announceRoleOptions returns the options of the enable sub-commands of announcements that can mention
+// a role
+func announceRoleOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "role",
+			Description: "The role to mention in the announcements",
+			Required:    false,
+		},
+	}
+}
+
 // getEnabledDisabled takes the applicationcommand options and checks wether enabled or disabled was selected
 func appCommandGetEnalbedDisabled(os []*discordgo.ApplicationCommandInteractionDataOption) (bool, error) {
 	if len(os) <= 0 {
@@ -0,0 +1,382 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// GoalMaxOpen is the maximum number of goals a user can work on at the same time
+const GoalMaxOpen = 10
+
+// GoalRateWindow is the period of the recent progress rate that the projected completion of a goal is
+// based on
+const GoalRateWindow = time.Hour * 24 * 7
+
+// goalBarWidth is the number of characters of the progress bar of a goal
+const goalBarWidth = 20
+
+// List of /goal specific errors
+var (
+	ErrGoalMetric   = errors.New("this metric can not be used for goals")
+	ErrGoalFaction  = errors.New("please choose the faction of the reputation goal")
+	ErrGoalLimit    = errors.New("you have reached the maximum number of open goals. Please remove a goal first")
+	ErrGoalReached  = errors.New("you have already reached this goal")
+	ErrGoalNoData   = errors.New("there are no stored stats for this goal yet. Please try again later")
+	ErrGoalNotFound = errors.New("there is no goal with this ID. Use **/goal list** to see the IDs of " +
+		"your goals")
+)
+
+// SlashCmdGoal handles the /goal slash command
+func (b *Bot) SlashCmdGoal(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no sub-command provided")
+	}
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	p := b.display(i.Interaction)
+
+	var e []*discordgo.MessageEmbed
+	switch ol[0].Name {
+	case "add":
+		g, err := b.addGoal(r.User, p, ol[0].Options)
+		if err != nil {
+			return err
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:   discordgo.EmbedTypeRich,
+				Title:  p.Sprintf("New goal added"),
+				Fields: []*discordgo.MessageEmbedField{b.goalField(r.User, p, g)},
+			},
+		}
+	case "list":
+		gl, err := b.Model.UserGoal.GetByUserID(r.User.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read user goals from DB: %w", err)
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:  discordgo.EmbedTypeRich,
+				Title: p.Sprintf("Your Sea of Thieves goals"),
+			},
+		}
+		if len(gl) <= 0 {
+			e[0].Description = p.Sprintf("You have not set any goals yet. Use **/goal add** to set one.")
+		}
+		for n := len(gl) - 1; n >= 0 && len(e[0].Fields) < 25; n-- {
+			e[0].Fields = append([]*discordgo.MessageEmbedField{b.goalField(r.User, p, gl[n])}, e[0].Fields...)
+		}
+	case "remove":
+		var gi int64
+		for _, o := range ol[0].Options {
+			if o.Name == "id" {
+				gi = o.IntValue()
+			}
+		}
+		if err := b.Model.UserGoal.Delete(r.User.ID, gi); err != nil {
+			if errors.Is(err, model.ErrUserGoalNotExistent) {
+				return ErrGoalNotFound
+			}
+			return fmt.Errorf("failed to remove user goal from DB: %w", err)
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:        discordgo.EmbedTypeArticle,
+				Title:       p.Sprintf("Goal removed"),
+				Description: p.Sprintf("Your goal #%d has been removed.", gi),
+			},
+		}
+	default:
+		return fmt.Errorf("unknown sub-command: %s", ol[0].Name)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /goal request: %w", err)
+	}
+	return nil
+}
+
+// addGoal stores a new goal of the given user based on the options of /goal add
+func (b *Bot) addGoal(u *model.User, p *Display, ol []*discordgo.ApplicationCommandInteractionDataOption,
+) (*model.UserGoal, error) {
+	g := &model.UserGoal{UserID: u.ID}
+	for _, o := range ol {
+		switch o.Name {
+		case "metric":
+			g.Metric = o.StringValue()
+		case "target":
+			g.Target = o.IntValue()
+		case "faction":
+			g.Emissary = o.StringValue()
+		}
+	}
+	me, ok := goalMetric(g.Metric)
+	if !ok {
+		return nil, ErrGoalMetric
+	}
+	switch me.Value {
+	case "reputation":
+		if g.Emissary == "" {
+			return nil, ErrGoalFaction
+		}
+	case "distance":
+		g.Target = int64(math.Round(float64(g.Target) * metresPerUnit[p.DistanceUnit]))
+		g.Emissary = ""
+	default:
+		g.Emissary = ""
+	}
+
+	ogl, err := b.Model.UserGoal.GetOpenByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user goals from DB: %w", err)
+	}
+	if len(ogl) >= GoalMaxOpen {
+		return nil, ErrGoalLimit
+	}
+	v, _, err := b.goalValue(u, g)
+	if err != nil {
+		return nil, err
+	}
+	if v >= g.Target {
+		return nil, ErrGoalReached
+	}
+	g.StartValue, g.Value = v, v
+	if err := b.Model.UserGoal.Insert(g); err != nil {
+		return nil, fmt.Errorf("failed to store user goal in DB: %w", err)
+	}
+	return g, nil
+}
+
+// goalField returns the embed field with the progress bar and the projected completion of the given goal
+func (b *Bot) goalField(u *model.User, p *Display, g *model.UserGoal) *discordgo.MessageEmbedField {
+	f := 1.0
+	if g.Target > g.StartValue {
+		f = float64(g.Value-g.StartValue) / float64(g.Target-g.StartValue)
+	}
+	f = math.Max(0, math.Min(1, f))
+	if g.CompleteTime != nil {
+		f = 1
+	}
+	fc := int(math.Round(f * goalBarWidth))
+	v := p.Sprintf("`%s%s` %s%% (%s / %s)", strings.Repeat("█", fc), strings.Repeat("░", goalBarWidth-fc),
+		p.Int(int64(f*100)), goalValueString(p, g, g.Value), goalValueString(p, g, g.Target))
+
+	switch r := b.goalRate(u, g); {
+	case g.CompleteTime != nil:
+		v += "\n" + p.Sprintf("Completed: %s", p.Time(*g.CompleteTime))
+	case r > 0:
+		et := b.clock.Now().Add(time.Duration(float64(g.Target-g.Value) / r * float64(time.Second)))
+		v += "\n" + p.Sprintf("Projected completion: %s", p.Time(et))
+	default:
+		v += "\n" + p.Sprintf("No progress within the last 7 days")
+	}
+	return &discordgo.MessageEmbedField{Name: p.Sprintf("#%d %s", g.ID, goalName(p, g)), Value: v}
+}
+
+// goalValue returns the current value of the metric of the given goal and the time it was stored
+func (b *Bot) goalValue(u *model.User, g *model.UserGoal) (int64, time.Time, error) {
+	if g.Metric == "reputation" {
+		ur, err := b.Model.UserReputation.GetByUserID(u.ID, g.Emissary)
+		if err != nil {
+			if errors.Is(err, model.ErrUserRepNotExistent) {
+				return 0, time.Time{}, ErrGoalNoData
+			}
+			return 0, time.Time{}, fmt.Errorf("failed to read user reputation from DB: %w", err)
+		}
+		return ur.Level, ur.CreateTime, nil
+	}
+	me, ok := goalMetric(g.Metric)
+	if !ok {
+		return 0, time.Time{}, ErrGoalMetric
+	}
+	us, err := b.Model.UserStats.GetByUserID(u.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserStatNotExistent) {
+			return 0, time.Time{}, ErrGoalNoData
+		}
+		return 0, time.Time{}, fmt.Errorf("failed to read user stats from DB: %w", err)
+	}
+	return me.stat(us), us.CreateTime, nil
+}
+
+// goalRate returns the progress per second of the metric of the given goal within the GoalRateWindow
+func (b *Bot) goalRate(u *model.User, g *model.UserGoal) float64 {
+	v, t, err := b.goalValue(u, g)
+	if err != nil {
+		return 0
+	}
+	ft := b.clock.Now().Add(-GoalRateWindow)
+	var ov int64
+	var ot time.Time
+	if g.Metric == "reputation" {
+		ur, err := b.Model.UserReputation.GetByUserIDAtTime(u.ID, g.Emissary, ft)
+		if err != nil {
+			return 0
+		}
+		ov, ot = ur.Level, ur.CreateTime
+	} else {
+		us, err := b.Model.UserStats.GetByUserIDAtTime(u.ID, ft)
+		if err != nil {
+			return 0
+		}
+		me, _ := goalMetric(g.Metric)
+		ov, ot = me.stat(us), us.CreateTime
+	}
+	if !t.After(ot) {
+		return 0
+	}
+	return float64(v-ov) / t.Sub(ot).Seconds()
+}
+
+// evaluateGoals updates the progress of the open goals of the given user and notifies the user about
+// completed goals. Depending on rep, either the reputation or the user stats goals are evaluated. A
+// failed notification is logged and does not stop the evaluation of the remaining goals
+func (b *Bot) evaluateGoals(u *model.User, rep bool) error {
+	ll := b.Log.With().Str("context", "bot.evaluateGoals").Logger()
+	gl, err := b.Model.UserGoal.GetOpenByUserID(u.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read user goals from DB: %w", err)
+	}
+	for _, g := range gl {
+		if (g.Metric == "reputation") != rep {
+			continue
+		}
+		v, _, err := b.goalValue(u, g)
+		if err != nil {
+			if errors.Is(err, ErrGoalNoData) {
+				continue
+			}
+			return err
+		}
+		if v == g.Value && v < g.Target {
+			continue
+		}
+		g.Value = v
+		if v >= g.Target {
+			t := b.clock.Now()
+			g.CompleteTime = &t
+		}
+		if err := b.Model.UserGoal.Update(g); err != nil {
+			return fmt.Errorf("failed to update user goal in DB: %w", err)
+		}
+		if g.CompleteTime == nil {
+			continue
+		}
+
+		p := b.userDisplay(u)
+		no := &notify.Notification{
+			Type: notify.TypeMilestone,
+			User: u,
+			Embed: &discordgo.MessageEmbed{
+				Type:  discordgo.EmbedTypeArticle,
+				Title: p.Sprintf("Goal reached!"),
+				Description: p.Sprintf("Congratulations! You reached your goal **%s**, which you have set on %s.",
+					goalName(p, g), p.Time(g.CreateTime)),
+			},
+			DedupKey: fmt.Sprintf("goal-%d", g.ID),
+		}
+		if _, err := b.notifier().Notify(no); err != nil {
+			ll.Error().Msgf("failed to send notification for goal %d: %s", g.ID, err)
+		}
+	}
+	return nil
+}
+
+// goalMetric returns the GraphMetric of the given goal metric. The ledger can not be used for goals
+func goalMetric(v string) (GraphMetric, bool) {
+	for _, gm := range GraphMetrics {
+		if gm.Value == v && gm.Value != "ledger" {
+			return gm, true
+		}
+	}
+	return GraphMetric{}, false
+}
+
+// goalName returns the human-readable description of the given goal
+func goalName(p *Display, g *model.UserGoal) string {
+	if g.Metric == "reputation" {
+		return p.Sprintf("%s level %s", dbEmissaryToName(g.Emissary), p.Int(g.Target))
+	}
+	me, _ := goalMetric(g.Metric)
+	return p.Sprintf("%s: %s", p.Sprintf(me.Name), goalValueString(p, g, g.Target))
+}
+
+// goalValueString returns the given value of the metric of the given goal in the display format of the user
+func goalValueString(p *Display, g *model.UserGoal, v int64) string {
+	if g.Metric == "distance" {
+		return p.Distance(v)
+	}
+	return p.Int(v)
+}
+
+// goalCommandOptions returns the sub-commands of the /goal slash command
+func goalCommandOptions() []*discordgo.ApplicationCommandOption {
+	var mc []*discordgo.ApplicationCommandOptionChoice
+	for _, m := range GraphMetrics {
+		if _, ok := goalMetric(m.Value); ok {
+			mc = append(mc, &discordgo.ApplicationCommandOptionChoice{Name: m.Name, Value: m.Value})
+		}
+	}
+	tmin, imin := 1.0, 1.0
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Set a new personal goal",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "metric",
+					Description: "The metric of the goal",
+					Required:    true,
+					Choices:     mc,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "target",
+					Description: "The value to reach (distances in your distance unit)",
+					Required:    true,
+					MinValue:    &tmin,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "faction",
+					Description: "The faction of a reputation level goal",
+					Required:    false,
+					Choices:     factionChoices(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show the progress of your goals",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove one of your goals",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionInteger,
+					Name:         "id",
+					Description:  "The ID of the goal as shown by /goal list",
+					Required:     true,
+					MinValue:     &imin,
+					Autocomplete: true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+func TestBot_evaluateGoals_notifyFailure(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	b, s, db := newTestBot(t, now)
+	db.onArg("FROM user_goals", int64(7),
+		[]driver.Value{int64(11), int64(7), "gold", "", int64(1000), int64(0), int64(0), nil, now, now},
+		[]driver.Value{int64(12), int64(7), "kraken", "", int64(3), int64(0), int64(0), nil, now, now},
+	)
+	db.on("FROM user_stats", []driver.Value{
+		int64(1), int64(7), "Pirate", int64(5000), int64(0), int64(0), int64(4), int64(0), int64(0), int64(0),
+		int64(0), int64(0), "hourly", now,
+	})
+	db.on("UPDATE user_goals", []driver.Value{now})
+	errUpdate := errors.New("update failed")
+	db.onArgErr("UPDATE user_goals", int64(12), errUpdate)
+	errPref := errors.New("pref failed")
+	db.onArgErr("FROM user_prefs", model.UserPrefNotifyDelivery(string(notify.TypeMilestone)), errPref)
+
+	// The notification of the first goal fails, the evaluation has to go on with the second goal
+	err := b.evaluateGoals(&model.User{ID: 7, UserID: "200"}, false)
+	if !errors.Is(err, errUpdate) {
+		t.Errorf("evaluateGoals failed, expected the update error of the second goal, got: %v", err)
+	}
+	if len(s.Messages()) != 0 {
+		t.Errorf("evaluateGoals failed, expected no message, got: %d", len(s.Messages()))
+	}
+}
@@ -0,0 +1,337 @@
+package bot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/chart"
+	"github.com/wneessen/arrgo/model"
+)
+
+// GraphMaxMembers is the maximum number of guild members that can be overlayed in a /graph chart
+const GraphMaxMembers = 3
+
+// List of /graph specific errors
+var (
+	ErrGraphMember = errors.New("please pick the guild members from the suggestions of the member " +
+		"options")
+	ErrGraphNoData  = errors.New("there is no stored history for the chosen metric and period yet")
+	ErrGraphFaction = errors.New("please choose the faction of the reputation or ledger that should be " +
+		"charted")
+)
+
+// GraphMetric is a metric that can be charted with the /graph command
+type GraphMetric struct {
+	Name  string
+	Value string
+	stat  func(*model.UserStat) int64
+}
+
+// List of /graph metrics. The reputation and ledger metrics require a faction
+var GraphMetrics = []GraphMetric{
+	{Name: "Gold", Value: "gold", stat: func(s *model.UserStat) int64 { return s.Gold }},
+	{Name: "Doubloons", Value: "doubloons", stat: func(s *model.UserStat) int64 { return s.Doubloons }},
+	{Name: "Ancient Coins", Value: "ancient-coins", stat: func(s *model.UserStat) int64 { return s.AncientCoins }},
+	{Name: "Kraken defeated", Value: "kraken", stat: func(s *model.UserStat) int64 { return s.KrakenDefeated }},
+	{Name: "Megalodon encounters", Value: "megalodon",
+		stat: func(s *model.UserStat) int64 { return s.MegalodonEnounter }},
+	{Name: "Chests handed in", Value: "chests", stat: func(s *model.UserStat) int64 { return s.ChestsHandedIn }},
+	{Name: "Ships sunk", Value: "ships", stat: func(s *model.UserStat) int64 { return s.ShipsSunk }},
+	{Name: "Times vomitted", Value: "vomit", stat: func(s *model.UserStat) int64 { return s.VomittedTimes }},
+	{Name: "Distance sailed", Value: "distance", stat: func(s *model.UserStat) int64 { return s.DistanceSailed }},
+	{Name: "Reputation level", Value: "reputation"},
+	{Name: "Emissary ledger value", Value: "ledger"},
+}
+
+// GraphPeriod is a period that can be charted with the /graph command
+type GraphPeriod struct {
+	Name     string
+	Value    string
+	Duration time.Duration
+}
+
+// List of /graph periods. The first period is the default
+var GraphPeriods = []GraphPeriod{
+	{Name: "Last 7 days", Value: "7d", Duration: time.Hour * 24 * 7},
+	{Name: "Last 24 hours", Value: "1d", Duration: time.Hour * 24},
+	{Name: "Last 30 days", Value: "30d", Duration: time.Hour * 24 * 30},
+	{Name: "Last 90 days", Value: "90d", Duration: time.Hour * 24 * 90},
+	{Name: "Last year", Value: "365d", Duration: time.Hour * 24 * 365},
+}
+
+// graphMember is a guild member whose history is charted
+type graphMember struct {
+	name string
+	user *model.User
+}
+
+// SlashCmdGraph handles the /graph slash command
+func (b *Bot) SlashCmdGraph(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	rn := ""
+	if i.Member != nil {
+		rn = memberName(i.Member, i.Member.User)
+	}
+	if i.User != nil {
+		rn = i.User.Username
+	}
+	ml := []graphMember{{name: rn, user: r.User}}
+
+	var me GraphMetric
+	pe := GraphPeriods[0]
+	fa := ""
+	cd := i.ApplicationCommandData()
+	for _, o := range cd.Options {
+		switch o.Name {
+		case "metric":
+			for _, gm := range GraphMetrics {
+				if gm.Value == o.StringValue() {
+					me = gm
+				}
+			}
+		case "period":
+			for _, gp := range GraphPeriods {
+				if gp.Value == o.StringValue() {
+					pe = gp
+				}
+			}
+		case "faction":
+			fa = o.StringValue()
+		default:
+			if !strings.HasPrefix(o.Name, "member-") || len(ml) > GraphMaxMembers {
+				continue
+			}
+			gm, err := b.graphMember(i, o.StringValue())
+			if err != nil {
+				return err
+			}
+			if gm.user.UserID == r.User.UserID {
+				continue
+			}
+			ml = append(ml, gm)
+		}
+	}
+	if me.Value == "" {
+		return fmt.Errorf("unknown metric")
+	}
+	if (me.Value == "reputation" || me.Value == "ledger") && fa == "" {
+		return ErrGraphFaction
+	}
+	if me.Value == "ledger" && !isLedgerEmissary(fa) {
+		return ErrGraphFaction
+	}
+
+	p := b.display(i.Interaction)
+	t := b.clock.Now()
+	f := t.Add(-pe.Duration)
+	c := &chart.LineChart{
+		From: f,
+		To:   t,
+		YFormat: func(v float64) string {
+			if v == math.Trunc(v) {
+				return p.Int(int64(v))
+			}
+			return p.Float(v, 1)
+		},
+		XFormat: func(xt time.Time) string {
+			if pe.Duration <= time.Hour*24 {
+				if p.Clock == Clock12h {
+					return xt.In(p.tz).Format("3:04 PM")
+				}
+				return xt.In(p.tz).Format("15:04")
+			}
+			return xt.In(p.tz).Format("2006-01-02")
+		},
+	}
+	for _, m := range ml {
+		pl, err := b.graphPoints(m.user, me, fa, p, f, t)
+		if err != nil {
+			return err
+		}
+		c.Series = append(c.Series, chart.Series{Name: m.name, Points: pl})
+	}
+
+	var buf bytes.Buffer
+	if err := c.Render(&buf); err != nil {
+		if errors.Is(err, chart.ErrNoData) {
+			return ErrGraphNoData
+		}
+		return fmt.Errorf("failed to render chart: %w", err)
+	}
+
+	ti := p.Sprintf("%s - %s", p.Sprintf(me.Name), p.Sprintf(pe.Name))
+	if fa != "" && (me.Value == "reputation" || me.Value == "ledger") {
+		ti = p.Sprintf("%s (%s) - %s", p.Sprintf(me.Name), dbEmissaryToName(fa), p.Sprintf(pe.Name))
+	}
+	if me.Value == "distance" {
+		ti = p.Sprintf("%s (%s) - %s", p.Sprintf(me.Name), p.DistanceUnit, p.Sprintf(pe.Name))
+	}
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:  discordgo.EmbedTypeImage,
+			Title: ti,
+			Image: &discordgo.MessageEmbedImage{URL: "attachment://graph.png"},
+		},
+	}
+	fl := []*discordgo.File{{Name: "graph.png", ContentType: "image/png", Reader: &buf}}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e, Files: fl}); err != nil {
+		return fmt.Errorf("failed to edit /graph request: %w", err)
+	}
+	return nil
+}
+
+// graphPoints returns the stored history of the given metric of a user between f and t
+func (b *Bot) graphPoints(u *model.User, me GraphMetric, fa string, p *Display, f, t time.Time) ([]chart.Point,
+	error,
+) {
+	var pl []chart.Point
+	switch me.Value {
+	case "reputation":
+		rl, err := b.Model.UserReputation.GetRangeByUserID(u.ID, fa, f, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user reputation history from DB: %w", err)
+		}
+		for _, r := range rl {
+			pl = append(pl, chart.Point{Time: r.CreateTime, Value: float64(r.Level)})
+		}
+	case "ledger":
+		ll, err := b.Model.UserLedger.GetRangeByUserID(u.ID, fa, f, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user ledger history from DB: %w", err)
+		}
+		for _, l := range ll {
+			pl = append(pl, chart.Point{Time: l.CreateTime, Value: float64(l.Score)})
+		}
+	default:
+		sl, err := b.Model.UserStats.GetRangeByUserID(u.ID, f, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user stats history from DB: %w", err)
+		}
+		for _, us := range sl {
+			v := float64(me.stat(us))
+			if me.Value == "distance" {
+				v = math.Round(v / metresPerUnit[p.DistanceUnit])
+			}
+			pl = append(pl, chart.Point{Time: us.CreateTime, Value: v})
+		}
+	}
+	return pl, nil
+}
+
+// graphCommandOptions returns the options of the /graph slash command
+func graphCommandOptions() []*discordgo.ApplicationCommandOption {
+	var mc, pc []*discordgo.ApplicationCommandOptionChoice
+	for _, m := range GraphMetrics {
+		mc = append(mc, &discordgo.ApplicationCommandOptionChoice{Name: m.Name, Value: m.Value})
+	}
+	for _, gp := range GraphPeriods {
+		pc = append(pc, &discordgo.ApplicationCommandOptionChoice{Name: gp.Name, Value: gp.Value})
+	}
+	ol := []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "metric",
+			Description: "The metric that should be charted",
+			Required:    true,
+			Choices:     mc,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "period",
+			Description: "The period that should be charted (default: last 7 days)",
+			Required:    false,
+			Choices:     pc,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "faction",
+			Description: "The faction of the reputation or ledger metric",
+			Required:    false,
+			Choices:     factionChoices(),
+		},
+	}
+	for n := 1; n <= GraphMaxMembers; n++ {
+		ol = append(ol, &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         fmt.Sprintf("member-%d", n),
+			Description:  "A registered guild member to compare with",
+			Required:     false,
+			Autocomplete: true,
+		})
+	}
+	return ol
+}
+
+// factionChoices returns the faction choices of the reputation and ledger options
+func factionChoices() []*discordgo.ApplicationCommandOptionChoice {
+	return []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "Athena's Fortune", Value: "athenasfortune"},
+		{Name: "Gold Hoarder", Value: "goldhoarders"},
+		{Name: "Merchant Alliance", Value: "merchantalliance"},
+		{Name: "Order of Souls", Value: "orderofsouls"},
+		{Name: "Reaper's Bone", Value: "reapersbones"},
+		{Name: "Hunter's Call", Value: "hunterscall"},
+		{Name: "Servants of the Flame", Value: "factionb"},
+		{Name: "Guardians of Fortune", Value: "factiong"},
+	}
+}
+
+// isLedgerEmissary returns true if the ledger history is stored for the given faction
+func isLedgerEmissary(fa string) bool {
+	for _, le := range LedgerEmissaries {
+		if le == fa {
+			return true
+		}
+	}
+	return false
+}
+
+// graphMember resolves the value of a /graph member option. The value has to be the user ID of a
+// registered member of the guild of the interaction, as offered by AutocompleteMembers. Other values,
+// like a name that was typed without picking a suggestion, are not resolved
+func (b *Bot) graphMember(i *discordgo.InteractionCreate, v string) (graphMember, error) {
+	v = strings.TrimSpace(v)
+	if i.GuildID == "" || v == "" {
+		return graphMember{}, ErrGraphMember
+	}
+	for _, c := range v {
+		if c < '0' || c > '9' {
+			return graphMember{}, ErrGraphMember
+		}
+	}
+	m, err := b.guildMember(i.GuildID, v)
+	if err != nil || m.User == nil {
+		return graphMember{}, ErrGraphMember
+	}
+	u, err := b.Model.User.GetByUserID(v)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotExistent) {
+			return graphMember{}, fmt.Errorf("%s is not registered with ArrGo", memberName(m, m.User))
+		}
+		return graphMember{}, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if err := b.checkStatsPrivacy(u); err != nil {
+		return graphMember{}, err
+	}
+	return graphMember{name: memberName(m, m.User), user: u}, nil
+}
+
+// memberName returns the guild nickname of a member or the username if no nickname is set
+func memberName(m *discordgo.Member, u *discordgo.User) string {
+	if m != nil && m.Nick != "" {
+		return m.Nick
+	}
+	if u != nil {
+		return u.Username
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+
+	"github.com/wneessen/arrgo/locale"
+	"github.com/wneessen/arrgo/model"
+)
+
+// LanguageAuto is the /language choice that removes the language override of the user
+const LanguageAuto = "auto"
+
+// SlashCmdLanguage handles the /language slash command
+func (b *Bot) SlashCmdLanguage(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no language provided")
+	}
+
+	switch v := ol[0].StringValue(); v {
+	case LanguageAuto:
+		if err := b.Model.User.DeletePref(r.User, model.UserPrefLanguage); err != nil {
+			return fmt.Errorf("failed to remove language from DB: %w", err)
+		}
+	default:
+		t, err := language.Parse(v)
+		if err != nil || locale.Match(v) != t {
+			return fmt.Errorf("unsupported language: %s", v)
+		}
+		if err := b.Model.User.SetPref(r.User, model.UserPrefLanguage, t.String()); err != nil {
+			return fmt.Errorf("failed to store language in DB: %w", err)
+		}
+	}
+
+	b.forgetInteractionLanguage(i.ID)
+	l := b.interactionLanguage(i.Interaction)
+	p := locale.NewPrinter(l)
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Title:       p.Sprintf("Language updated"),
+			Description: p.Sprintf("I will respond to you in %s from now on.", display.Self.Name(l)),
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /language request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// List of supported data export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// UserDataExport represents everything the bot stores about a user
+type UserDataExport struct {
+	ExportTime    time.Time                   `json:"exportTime"`
+	User          *model.User                 `json:"user"`
+	Preferences   []*model.UserPref           `json:"preferences"`
+	Stats         []*model.UserStat           `json:"stats"`
+	Reputation    []*model.UserReputation     `json:"reputation"`
+	Ledger        []*model.UserLedger         `json:"ledger"`
+	Goals         []*model.UserGoal           `json:"goals"`
+	Achievements  []*model.UserAchievement    `json:"achievements"`
+	Seasons       []*model.UserSeasonProgress `json:"seasons"`
+	Subscriptions []*model.UserSubscription   `json:"subscriptions"`
+}
+
+// SlashCmdMyData handles the /mydata slash command
+func (b *Bot) SlashCmdMyData(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no sub-command provided")
+	}
+
+	u, err := b.interactionUser(s, i)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return nil
+	}
+
+	switch ol[0].Name {
+	case "export":
+		f := ExportFormatJSON
+		for _, o := range ol[0].Options {
+			if o.Name == "format" {
+				f = o.StringValue()
+			}
+		}
+		return b.sendUserDataExport(s, i, u, f)
+	default:
+		return fmt.Errorf("unknown sub-command: %s", ol[0].Name)
+	}
+}
+
+// interactionUser looks up the registered user of an interaction. If the user is not registered, the
+// interaction response is edited accordingly and nil is returned
+func (b *Bot) interactionUser(s DiscordAPI, i *discordgo.InteractionCreate) (*model.User, error) {
+	var us string
+	if i.Interaction.User != nil {
+		us = i.Interaction.User.ID
+	}
+	if i.Member != nil && i.Member.User != nil {
+		us = i.Member.User.ID
+	}
+	if us == "" {
+		return nil, ErrUserNil
+	}
+	u, err := b.Model.User.GetByUserID(us)
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotExistent) {
+			return nil, fmt.Errorf("failed to look up user: %w", err)
+		}
+		p := b.printer(i.Interaction)
+		e := []*discordgo.MessageEmbed{
+			{
+				Type:        discordgo.EmbedTypeArticle,
+				Title:       p.Sprintf("Not registered"),
+				Description: p.Sprintf("You are not registered with ArrGo. There is no data stored about you."),
+			},
+		}
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return u, nil
+}
+
+// sendUserDataExport collects all data stored about the user and sends it as DM attachment in the
+// requested format
+func (b *Bot) sendUserDataExport(s DiscordAPI, i *discordgo.InteractionCreate, u *model.User, f string) error {
+	ex, err := b.userDataExport(u)
+	if err != nil {
+		return err
+	}
+
+	var fd []byte
+	var ct string
+	switch f {
+	case ExportFormatCSV:
+		fd, err = ex.CSV()
+		ct = "text/csv"
+	default:
+		f = ExportFormatJSON
+		fd, err = json.MarshalIndent(ex, "", "  ")
+		ct = "application/json"
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode data export: %w", err)
+	}
+
+	ch, err := s.UserChannelCreate(u.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to create DM channel: %w", err)
+	}
+	p := b.printer(i.Interaction)
+	ms := &discordgo.MessageSend{
+		Content: p.Sprintf("Here is all the data that ArrGo has stored about you. Secrets like your Sea of " +
+			"Thieves authentication cookie are redacted."),
+		Files: []*discordgo.File{
+			{
+				Name:        fmt.Sprintf("arrgo-export-%s.%s", ex.ExportTime.Format("20060102-150405"), f),
+				ContentType: ct,
+				Reader:      bytes.NewReader(fd),
+			},
+		},
+	}
+	if _, err := s.ChannelMessageSendComplex(ch.ID, ms); err != nil {
+		return fmt.Errorf("failed to send data export via DM: %w", err)
+	}
+
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf("Data export sent"),
+			Description: p.Sprintf("I've sent you a DM with all the data that ArrGo has stored about you."),
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// userDataExport collects all data stored about the given user
+func (b *Bot) userDataExport(u *model.User) (*UserDataExport, error) {
+	var err error
+	ex := &UserDataExport{ExportTime: b.clock.Now(), User: u}
+	ex.Preferences, err = b.Model.User.GetPrefs(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user preferences: %w", err)
+	}
+	ex.Stats, err = b.Model.UserStats.GetAllByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user stats: %w", err)
+	}
+	ex.Reputation, err = b.Model.UserReputation.GetAllByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user reputation: %w", err)
+	}
+	ex.Ledger, err = b.Model.UserLedger.GetAllByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user ledger: %w", err)
+	}
+	ex.Goals, err = b.Model.UserGoal.GetByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user goals: %w", err)
+	}
+	ex.Achievements, err = b.Model.UserAchievement.GetAllByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user achievements: %w", err)
+	}
+	ex.Seasons, err = b.Model.UserSeason.GetAllByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user season progress: %w", err)
+	}
+	ex.Subscriptions, err = b.Model.UserSubscription.GetByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user subscriptions: %w", err)
+	}
+	return ex, nil
+}
+
+// CSV returns the UserDataExport as CSV. Since the different records do not share a common set
+// of columns, every field of a record is written as a single "section,record,field,value" row
+func (ex *UserDataExport) CSV() ([]byte, error) {
+	var bb bytes.Buffer
+	cw := csv.NewWriter(&bb)
+	if err := cw.Write([]string{"section", "record", "field", "value"}); err != nil {
+		return nil, err
+	}
+
+	wr := func(sn string, rn int, r interface{}) error {
+		jd, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		var fm map[string]interface{}
+		jr := json.NewDecoder(bytes.NewReader(jd))
+		jr.UseNumber()
+		if err := jr.Decode(&fm); err != nil {
+			return err
+		}
+		fl := make([]string, 0, len(fm))
+		for k := range fm {
+			fl = append(fl, k)
+		}
+		sort.Strings(fl)
+		for _, k := range fl {
+			if err := cw.Write([]string{sn, fmt.Sprintf("%d", rn), k, fmt.Sprintf("%v", fm[k])}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := wr("user", 0, ex.User); err != nil {
+		return nil, err
+	}
+	for n, r := range ex.Preferences {
+		if err := wr("preferences", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Stats {
+		if err := wr("stats", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Reputation {
+		if err := wr("reputation", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Ledger {
+		if err := wr("ledger", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Goals {
+		if err := wr("goals", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Achievements {
+		if err := wr("achievements", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Seasons {
+		if err := wr("seasons", n, r); err != nil {
+			return nil, err
+		}
+	}
+	for n, r := range ex.Subscriptions {
+		if err := wr("subscriptions", n, r); err != nil {
+			return nil, err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, err
+	}
+	return bb.Bytes(), nil
+}
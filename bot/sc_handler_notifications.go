@@ -0,0 +1,294 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// notifyTypeNames maps the notification types to human-readable names
+var notifyTypeNames = map[notify.Type]string{
+	notify.TypeCookieExpiry:      "Cookie expiry",
+	notify.TypeSessionSummary:    "Voyage summary",
+	notify.TypeMilestone:         "Milestone",
+	notify.TypeSubscriptionMatch: "Subscription match",
+}
+
+// SlashCmdNotifications handles the /notifications slash command
+func (b *Bot) SlashCmdNotifications(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no sub-command provided")
+	}
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+
+	so := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, o := range ol[0].Options {
+		so[o.Name] = o
+	}
+
+	switch ol[0].Name {
+	case "show":
+	case "set":
+		t, d, cid, rid, err := notifyRouteOptions(i, so)
+		if err != nil {
+			return err
+		}
+		if err := b.setUserNotifyRoute(s, i, r.User, t, d, cid, rid); err != nil {
+			return err
+		}
+	case "quiet-hours":
+		if err := b.setQuietHours(r.User, so); err != nil {
+			return err
+		}
+	case "guild":
+		if r.Member == nil || (!r.IsAdmin() && !r.CanModerateMembers()) {
+			return fmt.Errorf("the guild notification settings are only accessible for admin-users")
+		}
+		t, d, cid, rid, err := notifyRouteOptions(i, so)
+		if err != nil {
+			return err
+		}
+		if d == notify.DeliveryDM {
+			return fmt.Errorf("direct messages can only be configured per user")
+		}
+		if err := b.setGuildNotifyRoute(i.GuildID, t, d, cid, rid); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown sub-command: %s", ol[0].Name)
+	}
+
+	return b.showNotificationSettings(s, i, r.User)
+}
+
+// notifyRouteOptions returns the notification type, delivery, channel and role of the /notifications
+// set and guild sub-commands
+func notifyRouteOptions(i *discordgo.InteractionCreate, so map[string]*discordgo.ApplicationCommandInteractionDataOption,
+) (notify.Type, notify.Delivery, string, string, error) {
+	var cid, rid string
+	o, ok := so["type"]
+	if !ok {
+		return "", "", "", "", fmt.Errorf("no notification type provided")
+	}
+	t, err := notify.ParseType(o.StringValue())
+	if err != nil {
+		return "", "", "", "", err
+	}
+	d := notify.DeliveryDefault
+	if o, ok := so["delivery"]; ok {
+		d = notify.Delivery(o.StringValue())
+	}
+	if o, ok := so["channel"]; ok {
+		cid = o.ChannelValue(nil).ID
+	}
+	if o, ok := so["role"]; ok {
+		rid = o.RoleValue(nil, i.GuildID).ID
+	}
+	if (d == notify.DeliveryChannel || d == notify.DeliveryRole) && cid == "" {
+		cid = i.ChannelID
+	}
+	if (d == notify.DeliveryChannel || d == notify.DeliveryRole) && i.GuildID == "" {
+		return "", "", "", "", fmt.Errorf("channel and role notifications can only be configured on a guild")
+	}
+	if d == notify.DeliveryRole && rid == "" {
+		return "", "", "", "", fmt.Errorf("please provide the role that should be mentioned")
+	}
+	if d != notify.DeliveryChannel && d != notify.DeliveryRole {
+		cid, rid = "", ""
+	}
+	return t, d, cid, rid, nil
+}
+
+// setUserNotifyRoute validates the delivery preferences the user requested with the given interaction
+// and stores them. Users can't configure role mentions and can only have notifications delivered to
+// channels they are allowed to send messages to
+func (b *Bot) setUserNotifyRoute(s DiscordAPI, i *discordgo.InteractionCreate, u *model.User, t notify.Type,
+	d notify.Delivery, cid, rid string,
+) error {
+	if d == notify.DeliveryRole {
+		return fmt.Errorf("role mentions can only be configured by guild admins with /notifications guild")
+	}
+	if d == notify.DeliveryChannel && !b.canSendToChannel(s, i, cid) {
+		return fmt.Errorf("you are not allowed to send messages to this channel")
+	}
+	return b.setNotifyRoute(u, t, d, cid, rid)
+}
+
+// canSendToChannel returns true if the member that invoked the interaction is allowed to view and send
+// messages in the given channel
+func (b *Bot) canSendToChannel(s DiscordAPI, i *discordgo.InteractionCreate, cid string) bool {
+	if i.Member == nil || i.Member.User == nil {
+		return false
+	}
+	pm, err := s.UserChannelPermissions(i.Member.User.ID, cid)
+	if err != nil {
+		b.Log.Warn().Msgf("failed to look up channel permissions of user: %s", err)
+		return false
+	}
+	rp := int64(discordgo.PermissionViewChannel | discordgo.PermissionSendMessages)
+	return pm&discordgo.PermissionAdministrator != 0 || pm&rp == rp
+}
+
+// setQuietHours stores the quiet hours of the user based on the options of the /notifications
+// quiet-hours sub-command
+func (b *Bot) setQuietHours(u *model.User, so map[string]*discordgo.ApplicationCommandInteractionDataOption) error {
+	o, ok := so["hours"]
+	if !ok {
+		return fmt.Errorf("no quiet hours provided")
+	}
+	if strings.EqualFold(o.StringValue(), "off") {
+		if err := b.Model.User.DeletePref(u, model.UserPrefNotifyQuietHours); err != nil {
+			return fmt.Errorf("failed to remove quiet hours from DB: %w", err)
+		}
+		return nil
+	}
+	tz := ""
+	if o, ok := so["timezone"]; ok {
+		tz = o.StringValue()
+	}
+	qh, err := notify.ParseQuietHours(o.StringValue(), tz)
+	if err != nil {
+		return err
+	}
+	if err := b.Model.User.SetPref(u, model.UserPrefNotifyQuietHours, o.StringValue()); err != nil {
+		return fmt.Errorf("failed to store quiet hours in DB: %w", err)
+	}
+	if err := b.Model.User.SetPref(u, model.UserPrefNotifyQuietTZ, qh.Location.String()); err != nil {
+		return fmt.Errorf("failed to store quiet hours in DB: %w", err)
+	}
+	return nil
+}
+
+// setGuildNotifyRoute stores the delivery preferences of the guild for the given notification type
+func (b *Bot) setGuildNotifyRoute(gid string, t notify.Type, d notify.Delivery, cid, rid string) error {
+	g, err := b.Model.Guild.GetByGuildID(gid)
+	if err != nil {
+		return fmt.Errorf(ErrFailedGuildLookupDB, err)
+	}
+	pl := map[model.GuildPrefKey]string{
+		model.GuildPrefNotifyDelivery(string(t)): string(d),
+		model.GuildPrefNotifyChannel(string(t)):  cid,
+		model.GuildPrefNotifyRole(string(t)):     rid,
+	}
+	if d == notify.DeliveryDefault {
+		pl[model.GuildPrefNotifyDelivery(string(t))] = ""
+	}
+	for k, v := range pl {
+		if v == "" {
+			if err := b.Model.Guild.DeletePref(g, k); err != nil {
+				return fmt.Errorf("failed to remove guild notification preference from DB: %w", err)
+			}
+			continue
+		}
+		if err := b.Model.Guild.SetPref(g, k, v); err != nil {
+			return fmt.Errorf("failed to store guild notification preference in DB: %w", err)
+		}
+	}
+	return nil
+}
+
+// showNotificationSettings edits the interaction response with the effective notification settings of
+// the user
+func (b *Bot) showNotificationSettings(s DiscordAPI, i *discordgo.InteractionCreate, u *model.User) error {
+	nt := b.notifier()
+	p := b.printer(i.Interaction)
+	var ef []*discordgo.MessageEmbedField
+	for _, t := range notify.Types {
+		rt, err := nt.Route(&notify.Notification{Type: t, User: u, GuildID: i.GuildID})
+		if err != nil {
+			return err
+		}
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name: p.Sprintf(notifyTypeNames[t]), Value: routeString(p, rt),
+			Inline: true,
+		})
+	}
+	qv := p.Sprintf("off")
+	qh, err := nt.QuietHours(u)
+	if err != nil {
+		return err
+	}
+	if qh != nil {
+		qv = qh.String()
+	}
+	ef = append(ef, &discordgo.MessageEmbedField{Name: p.Sprintf("Quiet hours"), Value: qv, Inline: true})
+	for len(ef)%3 != 0 {
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Value:  "\U0000FEFF",
+			Name:   "\U0000FEFF",
+			Inline: true,
+		})
+	}
+
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:   discordgo.EmbedTypeRich,
+			Title:  p.Sprintf("Your notification settings"),
+			Fields: ef,
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /notifications request: %w", err)
+	}
+	return nil
+}
+
+// notifyRouteCommandOptions returns the options of the /notifications set and guild sub-commands. Role
+// mentions are only offered for the guild sub-command, which is restricted to guild admins
+func notifyRouteCommandOptions(guild bool) []*discordgo.ApplicationCommandOption {
+	var tc []*discordgo.ApplicationCommandOptionChoice
+	for _, t := range notify.Types {
+		tc = append(tc, &discordgo.ApplicationCommandOptionChoice{Name: notifyTypeNames[t], Value: string(t)})
+	}
+	dc := []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "Default", Value: string(notify.DeliveryDefault)},
+		{Name: "Direct message", Value: string(notify.DeliveryDM)},
+		{Name: "Guild channel", Value: string(notify.DeliveryChannel)},
+	}
+	if guild {
+		dc = append(dc, &discordgo.ApplicationCommandOptionChoice{
+			Name: "Role mention in a guild channel", Value: string(notify.DeliveryRole),
+		})
+	}
+	dc = append(dc, &discordgo.ApplicationCommandOptionChoice{Name: "Muted", Value: string(notify.DeliveryMuted)})
+	ol := []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "type",
+			Description: "The type of notification",
+			Required:    true,
+			Choices:     tc,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "delivery",
+			Description: "How the notification is delivered",
+			Required:    true,
+			Choices:     dc,
+		},
+		{
+			Type:         discordgo.ApplicationCommandOptionChannel,
+			Name:         "channel",
+			Description:  "Guild channel for channel/role delivery (default: the current channel)",
+			Required:     false,
+			ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+		},
+	}
+	if guild {
+		ol = append(ol, &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "role",
+			Description: "Role that is mentioned for role delivery",
+			Required:    false,
+		})
+	}
+	return ol
+}
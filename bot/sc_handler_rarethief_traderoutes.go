@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,39 +32,147 @@ type RTRoute struct {
 	Surplus     string `json:"surplus"`
 }
 
-// SlashCmdSoTTradeRoutes handles the /balance slash command
-func (b *Bot) SlashCmdSoTTradeRoutes(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	if err := b.ScheduledEventUpdateTradeRoutes(); err != nil {
-		b.Log.Warn().Msgf("failed to update traderoutes in database: %s", err)
+// List of trade route rotations that can be shown by /traderoutes
+const (
+	RotationCurrent  = "current"
+	RotationPrevious = "previous"
+	RotationUpcoming = "upcoming"
+)
+
+// RTTimeZone is the time zone of the dates of the rarethief.com trade routes. Rotations start at
+// 00:00:00 of the first and end at 23:59:59 of the last day in this time zone
+var RTTimeZone = time.UTC
+
+// tradeRouteTitles are the embed titles of the different trade route rotations
+var tradeRouteTitles = map[string]string{
+	RotationCurrent:  "Trade Routes",
+	RotationPrevious: "Previous Trade Routes",
+	RotationUpcoming: "Upcoming Trade Routes",
+}
+
+// List of trade route specific errors
+var (
+	ErrTradeRoutesNone  = errors.New("no trade routes found in database")
+	ErrRotationUnknown  = errors.New("this trade route rotation is not known yet")
+	ErrOutpostNotFound  = errors.New("there is no trade route for an outpost with this name")
+	ErrCommodityUnknown = errors.New("this commodity is not traded on any of the current trade routes")
+)
+
+// SlashCmdSoTTradeRoutes handles the /traderoutes slash command
+func (b *Bot) SlashCmdSoTTradeRoutes(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	var on string
+	ro := RotationCurrent
+	for _, o := range i.ApplicationCommandData().Options {
+		switch o.Name {
+		case "outpost":
+			on = o.StringValue()
+		case "rotation":
+			ro = o.StringValue()
+		}
+	}
+	var tl []*model.TradeRoute
+	var err error
+	switch ro {
+	case RotationCurrent:
+		tl, err = b.currentTradeRoutes()
+	default:
+		tl, err = b.tradeRouteRotation(ro)
+	}
+	if err != nil {
 		return err
 	}
+	if on != "" {
+		nl := make([]string, len(tl))
+		for n, tr := range tl {
+			nl[n] = tr.Outpost
+		}
+		ml := fuzzyFind(on, nl)
+		if len(ml) <= 0 {
+			return ErrOutpostNotFound
+		}
+		tl = []*model.TradeRoute{tl[ml[0]]}
+	}
 
-	tl, err := b.Model.TradeRoute.GetTradeRoutes()
+	p := b.display(i.Interaction)
+	e := []*discordgo.MessageEmbed{tradeRoutesEmbed(p, p.Sprintf(tradeRouteTitles[ro]), tl)}
+	if on != "" {
+		e[0].Title = p.Sprintf("%s: %s", p.Sprintf(tradeRouteTitles[ro]), tl[0].Outpost)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SlashCmdSoTSell handles the /sell slash command. It lists the outposts at which the given commodity is
+// sought after, alongside the outposts at which it is in surplus
+func (b *Bot) SlashCmdSoTSell(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	tl, err := b.currentTradeRoutes()
 	if err != nil {
 		return err
 	}
-	if len(tl) <= 0 {
-		return fmt.Errorf("no trade routes found in database")
+	var cn string
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Name == "commodity" {
+			cn = o.StringValue()
+		}
 	}
 
-	var ef []*discordgo.MessageEmbedField
-	c := cases.Title(language.English)
+	var cl []string
+	cm := make(map[string]bool)
 	for _, tr := range tl {
-		ef = append(ef, &discordgo.MessageEmbedField{
-			Name: tr.Outpost,
-			Value: fmt.Sprintf("%s **%s**\n%s **%s**", IconArrowUp, c.String(tr.Surplus),
-				IconArrowDown, c.String(tr.SoughtAfter)),
-			Inline: true,
-		})
+		for _, co := range []string{tr.SoughtAfter, tr.Surplus} {
+			if co != "" && !cm[strings.ToLower(co)] {
+				cm[strings.ToLower(co)] = true
+				cl = append(cl, co)
+			}
+		}
+	}
+	ml := fuzzyFind(cn, cl)
+	if len(ml) <= 0 {
+		return ErrCommodityUnknown
 	}
+	co := cl[ml[0]]
 
+	p := b.display(i.Interaction)
+	var sa, su []string
+	for _, tr := range tl {
+		if strings.EqualFold(tr.SoughtAfter, co) {
+			sa = append(sa, tr.Outpost)
+		}
+		if strings.EqualFold(tr.Surplus, co) {
+			su = append(su, tr.Outpost)
+		}
+	}
+	sort.Strings(sa)
+	sort.Strings(su)
+	ol := func(l []string) string {
+		if len(l) <= 0 {
+			return p.Sprintf("No outpost")
+		}
+		return strings.Join(l, "\n")
+	}
+
+	c := cases.Title(language.English)
 	e := []*discordgo.MessageEmbed{
 		{
-			Title:       "Trade Routes",
-			Description: fmt.Sprintf("valid thru %s", tl[0].ValidThru.Format(time.RFC1123)),
+			Title:       p.Sprintf("Where to sell %s", c.String(co)),
+			Description: tradeRouteValidity(p, tl[0]),
 			Type:        discordgo.EmbedTypeRich,
-			Footer:      &discordgo.MessageEmbedFooter{Text: "Source: https://maps.seaofthieves.rarethief.com/"},
-			Fields:      ef,
+			Footer:      &discordgo.MessageEmbedFooter{Text: p.Sprintf("Source: %s", "https://maps.seaofthieves.rarethief.com/")},
+			Fields: []*discordgo.MessageEmbedField{
+				{
+					Name:   p.Sprintf("%s Sought after at", IconArrowDown),
+					Value:  ol(sa),
+					Inline: true,
+				},
+				{
+					Name:   p.Sprintf("%s In surplus at", IconArrowUp),
+					Value:  ol(su),
+					Inline: true,
+				},
+			},
 		},
 	}
 
@@ -73,19 +182,95 @@ func (b *Bot) SlashCmdSoTTradeRoutes(s *discordgo.Session, i *discordgo.Interact
 	return nil
 }
 
-// ScheduledEventUpdateTradeRoutes performs scheuled updates of the TR data from rarethief.com
-func (b *Bot) ScheduledEventUpdateTradeRoutes() error {
-	ll := b.Log.With().Str("context", "bot.ScheduledEventUpdateTradeRoutes").Logger()
-	rl, err := b.Model.TradeRoute.GetTradeRoutes()
+// currentTradeRoutes updates the trade routes if required and returns the current ones from the database
+func (b *Bot) currentTradeRoutes() ([]*model.TradeRoute, error) {
+	if err := b.updateTradeRoutes(false); err != nil {
+		b.Log.Warn().Msgf("failed to update traderoutes in database: %s", err)
+		return nil, err
+	}
+	tl, err := b.Model.TradeRoute.GetTradeRoutes(b.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(tl) <= 0 {
+		return nil, ErrTradeRoutesNone
+	}
+	return tl, nil
+}
+
+// tradeRouteRotation returns the trade routes of the previous or upcoming rotation from the database
+func (b *Bot) tradeRouteRotation(ro string) ([]*model.TradeRoute, error) {
+	rl, err := b.Model.TradeRoute.GetRotations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trade route rotations from DB: %w", err)
+	}
+	n := b.clock.Now()
+	ci := -1
+	for ri, r := range rl {
+		if !r.ValidFrom.After(n) {
+			ci = ri
+		}
+	}
+	ri := ci - 1
+	if ro == RotationUpcoming {
+		ri = ci + 1
+	}
+	if ri < 0 || ri >= len(rl) {
+		return nil, ErrRotationUnknown
+	}
+	tl, err := b.Model.TradeRoute.GetByRotation(rl[ri].ValidFrom)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve trade routes list from DB: %w", err)
+		return nil, fmt.Errorf("failed to read trade routes from DB: %w", err)
 	}
-	if len(rl) > 0 {
+	if len(tl) <= 0 {
+		return nil, ErrRotationUnknown
+	}
+	return tl, nil
+}
+
+// tradeRoutesEmbed returns an embed with the given title that lists the given trade routes
+func tradeRoutesEmbed(p *Display, t string, tl []*model.TradeRoute) *discordgo.MessageEmbed {
+	var ef []*discordgo.MessageEmbedField
+	c := cases.Title(language.English)
+	for _, tr := range tl {
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name: tr.Outpost,
+			Value: p.Sprintf("%s **%s**\n%s **%s**", IconArrowUp, c.String(tr.Surplus),
+				IconArrowDown, c.String(tr.SoughtAfter)),
+			Inline: true,
+		})
+	}
+	return &discordgo.MessageEmbed{
+		Title:       t,
+		Description: tradeRouteValidity(p, tl[0]),
+		Type:        discordgo.EmbedTypeRich,
+		Footer:      &discordgo.MessageEmbedFooter{Text: p.Sprintf("Source: %s", "https://maps.seaofthieves.rarethief.com/")},
+		Fields:      ef,
+	}
+}
+
+// tradeRouteValidity returns the validity window of the given trade route
+func tradeRouteValidity(p *Display, tr *model.TradeRoute) string {
+	return p.Sprintf("valid from %s thru %s", p.Time(tr.ValidFrom), p.Time(tr.ValidThru))
+}
+
+// ScheduledEventUpdateTradeRoutes performs scheuled updates of the TR data from rarethief.com. Other
+// than the updates on request, the scheduled updates fetch the trade routes even if the stored ones are
+// still valid, so that upcoming rotations are stored as soon as they are published
+func (b *Bot) ScheduledEventUpdateTradeRoutes() error {
+	return b.updateTradeRoutes(true)
+}
+
+// updateTradeRoutes fetches the current trade routes from rarethief.com and stores them as a rotation
+// in the database. Unless forced, nothing is fetched while the latest stored rotation is still valid
+func (b *Bot) updateTradeRoutes(f bool) error {
+	ll := b.Log.With().Str("context", "bot.updateTradeRoutes").Logger()
+	if !f {
 		dbv, err := b.Model.TradeRoute.ValidThru()
 		if err != nil {
 			return fmt.Errorf("failed to retrieve trade routes validity date from DB: %w", err)
 		}
-		if dbv.Unix() > time.Now().Unix() {
+		if dbv.After(b.clock.Now()) {
 			ll.Debug().Msgf("trade routes in DB are still valid. Skipping update")
 			return nil
 		}
@@ -94,24 +279,40 @@ func (b *Bot) ScheduledEventUpdateTradeRoutes() error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch traderoute: %w", err)
 	}
+	rl, err := b.Model.TradeRoute.GetRotations()
+	if err != nil {
+		return fmt.Errorf("failed to read trade route rotations from DB: %w", err)
+	}
+	nr := true
+	for _, r := range rl {
+		if r.ValidFrom.Equal(tr.ValidFrom) {
+			nr = false
+		}
+	}
+
+	var cl []*model.TradeRoute
 	for _, r := range tr.Routes {
-		dtr, err := b.Model.TradeRoute.GetByOutpost(r.Outpost)
-		dtr.Outpost = r.Outpost
-		dtr.SoughtAfter = r.SoughtAfter
-		dtr.Surplus = r.Surplus
-		dtr.ValidThru = tr.ValidThru
+		dtr := &model.TradeRoute{
+			Outpost:     r.Outpost,
+			SoughtAfter: r.SoughtAfter,
+			Surplus:     r.Surplus,
+			ValidFrom:   tr.ValidFrom,
+			ValidThru:   tr.ValidThru,
+		}
+		ch, err := b.Model.TradeRoute.Upsert(dtr)
 		if err != nil {
-			if !errors.Is(err, model.ErrTradeRouteNotExistent) {
-				ll.Error().Msgf("failed to retrieve trade route for %q from DB: %s", r.Outpost, err)
-				continue
-			}
-			if err := b.Model.TradeRoute.Insert(dtr); err != nil {
-				ll.Error().Msgf("failed to insert trade route for %q into DB: %s", r.Outpost, err)
-				continue
-			}
+			ll.Error().Msgf("failed to store trade route for %q in DB: %s", r.Outpost, err)
+			continue
 		}
-		if err := b.Model.TradeRoute.Update(dtr); err != nil {
-			ll.Error().Msgf("failed to update trade route for %q into DB: %s", r.Outpost, err)
+		if ch {
+			cl = append(cl, dtr)
+		}
+	}
+	if len(cl) > 0 && tr.ValidThru.After(b.clock.Now()) {
+		sort.Slice(cl, func(x, y int) bool { return cl[x].Outpost < cl[y].Outpost })
+		b.announceTradeRoutes(cl, nr)
+		if err := b.notifySubscriptions(TopicTradeRoute, nil, tradeRouteSubscriptionEvents(cl)); err != nil {
+			ll.Error().Msgf("failed to evaluate trade route subscriptions: %s", err)
 		}
 	}
 	return nil
@@ -120,7 +321,7 @@ func (b *Bot) ScheduledEventUpdateTradeRoutes() error {
 // RTGetTradeRoutes returns the parsed API response from the rarethief.com traderoutes API
 func (b *Bot) RTGetTradeRoutes() (RTTraderoute, error) {
 	var tr RTTraderoute
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return tr, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -144,18 +345,88 @@ func (b *Bot) RTGetTradeRoutes() (RTTraderoute, error) {
 	if err := json.Unmarshal([]byte(rj[1]), &tr); err != nil {
 		return tr, err
 	}
-	da := strings.SplitN(tr.Dates, " - ", 2)
-	vf, err := time.Parse("2006/01/02", fmt.Sprintf("%v/%v", time.Now().Year(), da[0]))
-	if err != nil {
-		return tr, fmt.Errorf("failed to parse valid from date")
-	}
-	vt, err := time.Parse("2006/01/02 15:04:05", fmt.Sprintf("%v/%v 23:59:59",
-		time.Now().Year(), da[1]))
+	vf, vt, err := parseTradeRouteDates(tr.Dates, b.clock.Now())
 	if err != nil {
-		return tr, fmt.Errorf("failed to parse valid thru date")
+		return tr, err
 	}
 	tr.ValidFrom = vf
 	tr.ValidThru = vt
 
 	return tr, nil
 }
+
+// parseTradeRouteDates parses the "MM/DD - MM/DD" validity window of the rarethief.com trade routes. Since
+// the dates come without a year, the start date is placed in the year that puts it closest to the given
+// time. An end date before the start date belongs to the following year
+func parseTradeRouteDates(d string, n time.Time) (time.Time, time.Time, error) {
+	var vf, vt time.Time
+	da := strings.SplitN(d, " - ", 2)
+	if len(da) != 2 {
+		return vf, vt, fmt.Errorf("failed to parse trade route dates: %q", d)
+	}
+	var fm, fd, tm, td int
+	if _, err := fmt.Sscanf(strings.TrimSpace(da[0]), "%d/%d", &fm, &fd); err != nil {
+		return vf, vt, fmt.Errorf("failed to parse valid from date: %w", err)
+	}
+	if _, err := fmt.Sscanf(strings.TrimSpace(da[1]), "%d/%d", &tm, &td); err != nil {
+		return vf, vt, fmt.Errorf("failed to parse valid thru date: %w", err)
+	}
+
+	n = n.In(RTTimeZone)
+	for _, y := range []int{n.Year() - 1, n.Year(), n.Year() + 1} {
+		c := time.Date(y, time.Month(fm), fd, 0, 0, 0, 0, RTTimeZone)
+		if vf.IsZero() || absDuration(c.Sub(n)) < absDuration(vf.Sub(n)) {
+			vf = c
+		}
+	}
+	vt = time.Date(vf.Year(), time.Month(tm), td, 23, 59, 59, 0, RTTimeZone)
+	if vt.Before(vf) {
+		vt = vt.AddDate(1, 0, 0)
+	}
+	return vf, vt, nil
+}
+
+// absDuration returns the absolute value of the given duration
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// tradeRoutesCommandOptions returns the options of the /traderoutes slash command
+func tradeRoutesCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "outpost",
+			Description:  "Only show the trade route of this outpost",
+			Required:     false,
+			Autocomplete: true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "rotation",
+			Description: "The rotation of the trade routes (default: current)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Current rotation", Value: RotationCurrent},
+				{Name: "Previous rotation", Value: RotationPrevious},
+				{Name: "Upcoming rotation", Value: RotationUpcoming},
+			},
+		},
+	}
+}
+
+// sellCommandOptions returns the options of the /sell slash command
+func sellCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "commodity",
+			Description:  "The commodity you want to sell",
+			Required:     true,
+			Autocomplete: true,
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTradeRouteDates(t *testing.T) {
+	tt := []struct {
+		name  string
+		dates string
+		now   time.Time
+		from  time.Time
+		thru  time.Time
+		fail  bool
+	}{
+		{
+			"within a year", "05/10 - 05/16", time.Date(2023, 5, 12, 10, 0, 0, 0, time.UTC),
+			time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC), time.Date(2023, 5, 16, 23, 59, 59, 0, time.UTC), false,
+		},
+		{
+			"rotation across new year", "12/28 - 01/03", time.Date(2023, 12, 30, 10, 0, 0, 0, time.UTC),
+			time.Date(2023, 12, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 23, 59, 59, 0, time.UTC), false,
+		},
+		{
+			"rotation across new year, fetched in january", "12/28 - 01/03",
+			time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), time.Date(2023, 12, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 3, 23, 59, 59, 0, time.UTC), false,
+		},
+		{
+			"upcoming rotation, fetched in december", "01/04 - 01/10",
+			time.Date(2023, 12, 30, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 10, 23, 59, 59, 0, time.UTC), false,
+		},
+		{"missing separator", "05/10", time.Now(), time.Time{}, time.Time{}, true},
+		{"invalid date", "05/10 - soon", time.Now(), time.Time{}, time.Time{}, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			vf, vt, err := parseTradeRouteDates(tc.dates, tc.now)
+			if tc.fail {
+				if err == nil {
+					t.Errorf("parseTradeRouteDates(%q) was supposed to fail", tc.dates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTradeRouteDates(%q) failed: %s", tc.dates, err)
+			}
+			if !vf.Equal(tc.from) || !vt.Equal(tc.thru) {
+				t.Errorf("parseTradeRouteDates(%q) failed, expected: %s - %s, got: %s - %s", tc.dates,
+					tc.from, tc.thru, vf, vt)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // SlashCmdRegister handles the /register slash command
-func (b *Bot) SlashCmdRegister(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdRegister(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	if i.Member == nil || i.Member.User == nil {
 		return ErrUserNil
 	}
@@ -20,12 +20,13 @@ func (b *Bot) SlashCmdRegister(s *discordgo.Session, i *discordgo.InteractionCre
 	if err != nil && !errors.Is(err, model.ErrUserNotExistent) {
 		return err
 	}
+	p := b.printer(i.Interaction)
 	if u.ID > 0 {
 		e := []*discordgo.MessageEmbed{
 			{
 				Type:        discordgo.EmbedTypeArticle,
-				Title:       "Welcome back!",
-				Description: "You are already registered with ArrGo. Thanks for double checking...",
+				Title:       p.Sprintf("Welcome back!"),
+				Description: p.Sprintf("You are already registered with ArrGo. Thanks for double checking..."),
 			},
 		}
 		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -52,9 +53,10 @@ func (b *Bot) SlashCmdRegister(s *discordgo.Session, i *discordgo.InteractionCre
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Type:        discordgo.EmbedTypeArticle,
-			Title:       "Welcome!",
-			Description: "You have successfully registered your account and are now able to use the full feature set",
+			Type:  discordgo.EmbedTypeArticle,
+			Title: p.Sprintf("Welcome!"),
+			Description: p.Sprintf("You have successfully registered your account and are now able to use " +
+				"the full feature set"),
 		},
 	}
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
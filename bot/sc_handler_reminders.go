@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// List of RAT cookie reminder delivery methods
+const (
+	ReminderDeliveryDM      = "dm"
+	ReminderDeliveryChannel = "channel"
+)
+
+// SlashCmdReminders handles the /reminders slash command. Without options it shows the current RAT cookie
+// reminder settings of the user
+func (b *Bot) SlashCmdReminders(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	u := r.User
+
+	var dm string
+	var ch *discordgo.Channel
+	for _, o := range i.ApplicationCommandData().Options {
+		switch o.Name {
+		case "stages":
+			sl, err := ParseRATReminderStages(o.StringValue())
+			if err != nil {
+				return err
+			}
+			var sv []string
+			for _, d := range sl {
+				sv = append(sv, d.String())
+			}
+			if err := b.Model.User.SetPref(u, model.UserPrefRATReminderStages, strings.Join(sv, ",")); err != nil {
+				return fmt.Errorf("failed to store reminder stages in DB: %w", err)
+			}
+		case "delivery":
+			dm = o.StringValue()
+		case "channel":
+			ch = o.ChannelValue(nil)
+		}
+	}
+
+	switch {
+	case dm == ReminderDeliveryDM:
+		if err := b.setUserNotifyRoute(s, i, u, notify.TypeCookieExpiry, notify.DeliveryDM, "", ""); err != nil {
+			return err
+		}
+	case dm == ReminderDeliveryChannel || ch != nil:
+		cid := i.ChannelID
+		if ch != nil {
+			cid = ch.ID
+		}
+		if i.GuildID == "" || cid == "" {
+			return fmt.Errorf("reminders can only be delivered to a guild channel. Please use this " +
+				"command on a guild")
+		}
+		if err := b.setUserNotifyRoute(s, i, u, notify.TypeCookieExpiry, notify.DeliveryChannel, cid,
+			""); err != nil {
+			return err
+		}
+	}
+
+	var sv []string
+	for _, d := range b.ratReminderStages(u) {
+		sv = append(sv, d.String())
+	}
+	rt, err := b.notifier().Route(&notify.Notification{Type: notify.TypeCookieExpiry, User: u})
+	if err != nil {
+		return err
+	}
+	p := b.printer(i.Interaction)
+	dv := routeString(p, rt)
+
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:  discordgo.EmbedTypeArticle,
+			Title: p.Sprintf("Your RAT cookie reminder settings"),
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: p.Sprintf("Remind me before expiry"), Value: strings.Join(sv, ", "), Inline: true},
+				{Name: p.Sprintf("Delivery"), Value: dv, Inline: true},
+			},
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /reminders request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// remindersInteraction returns an interaction of the /reminders command with channel delivery to the
+// given channel
+func remindersInteraction(uid, cid string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "500",
+		Type:      discordgo.InteractionApplicationCommand,
+		GuildID:   "300",
+		ChannelID: cid,
+		Locale:    discordgo.EnglishUS,
+		Member:    &discordgo.Member{User: &discordgo.User{ID: uid, Username: "bob"}},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "reminders",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "delivery", Type: discordgo.ApplicationCommandOptionString, Value: ReminderDeliveryChannel},
+			},
+		},
+	}}
+}
+
+func TestBot_SlashCmdReminders_channel(t *testing.T) {
+	tt := []struct {
+		name  string
+		perms int64
+		ok    bool
+	}{
+		{"allowed to send", discordgo.PermissionViewChannel | discordgo.PermissionSendMessages, true},
+		{"administrator", discordgo.PermissionAdministrator, true},
+		{"view only", discordgo.PermissionViewChannel, false},
+		{"no permissions", 0, false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC))
+			db.onArg("FROM users", "200", userRow(7, "200", nil))
+			s.SetChannelPermissions("200", "400", tc.perms)
+
+			err := b.SlashCmdReminders(s, remindersInteraction("200", "400"))
+			stored := db.executed("INSERT INTO user_prefs")
+			if tc.ok {
+				if err != nil {
+					t.Fatalf("SlashCmdReminders failed: %s", err)
+				}
+				if !stored {
+					t.Error("SlashCmdReminders failed, expected the delivery to be stored")
+				}
+				return
+			}
+			if err == nil || err.Error() != "you are not allowed to send messages to this channel" {
+				t.Errorf("SlashCmdReminders failed, expected the channel to be rejected, got: %v", err)
+			}
+			if stored {
+				t.Error("SlashCmdReminders failed, expected no delivery to be stored")
+			}
+		})
+	}
+}
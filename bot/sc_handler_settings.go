@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// SettingAuto is the /settings choice that removes a display preference of the user
+const SettingAuto = "auto"
+
+// List of /settings privacy choices. Stats are public by default
+const (
+	SettingPublic  = "public"
+	SettingPrivate = "private"
+)
+
+// SlashCmdSettings handles the /settings slash command. Without options it shows the current display
+// preferences of the user
+func (b *Bot) SlashCmdSettings(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	u := r.User
+
+	for _, o := range i.ApplicationCommandData().Options {
+		var k model.UserPrefKey
+		v := o.StringValue()
+		switch o.Name {
+		case "distance":
+			k = model.UserPrefDistanceUnit
+			if _, ok := metresPerUnit[v]; !ok {
+				return fmt.Errorf("unsupported distance unit: %s", v)
+			}
+		case "numbers":
+			k = model.UserPrefNumberGrouping
+		case "clock":
+			k = model.UserPrefClock
+		case "privacy":
+			k = model.UserPrefStatsPrivate
+			if v == SettingPrivate {
+				if err := b.Model.User.SetPref(u, k, true); err != nil {
+					return fmt.Errorf("failed to store privacy preference in DB: %w", err)
+				}
+				continue
+			}
+			v = SettingAuto
+		case "timezone":
+			k = model.UserPrefTimezone
+			if v != SettingAuto {
+				tz, err := time.LoadLocation(v)
+				if err != nil || v == "" || v == "Local" {
+					return fmt.Errorf("unknown time zone %q", v)
+				}
+				v = tz.String()
+			}
+		default:
+			continue
+		}
+		if v == SettingAuto {
+			if err := b.Model.User.DeletePref(u, k); err != nil {
+				return fmt.Errorf("failed to remove display preference from DB: %w", err)
+			}
+			continue
+		}
+		if err := b.Model.User.SetPref(u, k, v); err != nil {
+			return fmt.Errorf("failed to store display preference in DB: %w", err)
+		}
+	}
+
+	p := b.display(i.Interaction)
+	tz := p.Timezone
+	if tz == "" {
+		tz = p.Sprintf("Discord client")
+	}
+	cl := p.Clock
+	if cl == "" {
+		cl = p.Sprintf("Discord client")
+	}
+	pv := p.Sprintf("Public")
+	if err := b.checkStatsPrivacy(u); errors.Is(err, ErrStatsPrivate) {
+		pv = p.Sprintf("Private")
+	}
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf("Your display settings"),
+			Description: p.Sprintf("This is how your stats and times will look like from now on."),
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: p.Sprintf("Distance"), Value: p.Distance(1234567 * 1852 / 1000), Inline: true},
+				{Name: p.Sprintf("Numbers"), Value: p.Float(1234567.8, 1), Inline: true},
+				{Name: p.Sprintf("Time zone"), Value: tz, Inline: true},
+				{Name: p.Sprintf("Clock"), Value: cl, Inline: true},
+				{Name: p.Sprintf("Current time"), Value: p.Time(b.clock.Now()), Inline: true},
+				{Name: p.Sprintf("Stats privacy"), Value: pv, Inline: true},
+			},
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /settings request: %w", err)
+	}
+	return nil
+}
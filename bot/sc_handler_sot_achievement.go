@@ -2,11 +2,23 @@ package bot
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
 )
 
+// AchievementsPerPage is the number of achievements per page of the paginated /achievements list
+const AchievementsPerPage = 10
+
+// AchievementMaxAnnounce is the maximum number of newly unlocked achievements of a user that are
+// announced per sync. Achievements of the first sync of a user are never announced
+const AchievementMaxAnnounce = 5
+
 // SoTAchievementList represents the JSON structure of the Sea of Thieves achievements API response
 type SoTAchievementList struct {
 	Sorted []SoTSortedAchievement `json:"sorted"`
@@ -26,7 +38,7 @@ type SoTAchievement struct {
 }
 
 // SlashCmdSoTAchievement handles the /achievement slash command
-func (b *Bot) SlashCmdSoTAchievement(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTAchievement(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	r, err := b.NewRequester(i.Interaction)
 	if err != nil {
 		return err
@@ -40,9 +52,10 @@ func (b *Bot) SlashCmdSoTAchievement(s *discordgo.Session, i *discordgo.Interact
 	}
 
 	a := al.Sorted[0].Achievement
+	p := b.printer(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
-			Title:       fmt.Sprintf("Your latest Sea of Thieves achievement: %s", a.Name),
+			Title:       p.Sprintf("Your latest Sea of Thieves achievement: %s", a.Name),
 			Description: a.Description,
 			Image: &discordgo.MessageEmbedImage{
 				URL: a.MediaURL,
@@ -59,7 +72,7 @@ func (b *Bot) SlashCmdSoTAchievement(s *discordgo.Session, i *discordgo.Interact
 // SoTGetAchievements returns the parsed API response from the Sea of Thieves achievements API
 func (b *Bot) SoTGetAchievements(rq *Requester) (SoTAchievementList, error) {
 	var a SoTAchievementList
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return a, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -72,12 +85,189 @@ func (b *Bot) SoTGetAchievements(rq *Requester) (SoTAchievementList, error) {
 		return a, err
 	}
 	r.SetSOTRequest(c)
-	rd, _, err := hc.Fetch(r)
+	rd, ho, err := hc.Fetch(r)
 	if err != nil {
 		return a, err
 	}
+	if ho.StatusCode == http.StatusUnauthorized {
+		return a, ErrSOTUnauth
+	}
 	if err := json.Unmarshal(rd, &a); err != nil {
 		return a, err
 	}
 	return a, nil
 }
+
+// SlashCmdSoTAchievements handles the /achievements slash command
+func (b *Bot) SlashCmdSoTAchievements(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	if _, err := b.StoreSoTUserAchievements(r.User); err != nil {
+		return fmt.Errorf("failed to update user achievements in DB: %w", err)
+	}
+
+	var se string
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Name == "search" {
+			se = strings.TrimSpace(o.StringValue())
+		}
+	}
+	al, err := b.Model.UserAchievement.Search(r.User.ID, se)
+	if err != nil {
+		return fmt.Errorf("failed to read user achievements from DB: %w", err)
+	}
+
+	p := b.display(i.Interaction)
+	t := p.Sprintf("Your Sea of Thieves achievements")
+	if se != "" {
+		t = p.Sprintf("Your Sea of Thieves achievements matching %q", se)
+	}
+	if len(al) <= 0 {
+		return b.paginate(s, i, [][]*discordgo.MessageEmbed{{
+			{
+				Type:        discordgo.EmbedTypeRich,
+				Title:       t,
+				Description: p.Sprintf("No achievements found."),
+			},
+		}})
+	}
+	var e []*discordgo.MessageEmbed
+	for n := 0; n < len(al); n += AchievementsPerPage {
+		pl := al[n:]
+		if len(pl) > AchievementsPerPage {
+			pl = pl[:AchievementsPerPage]
+		}
+		var sb strings.Builder
+		for _, a := range pl {
+			sb.WriteString(p.Sprintf("**%s** (%s)\n%s\n", a.Name, p.Time(a.CreateTime), a.Description))
+		}
+		e = append(e, &discordgo.MessageEmbed{
+			Type:        discordgo.EmbedTypeRich,
+			Title:       t,
+			Description: sb.String(),
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: p.Sprintf("%s achievements", p.Int(int64(len(al)))),
+			},
+		})
+	}
+	return b.paginate(s, i, EmbedPages(e, 1))
+}
+
+// StoreSoTUserAchievements will retrieve the achievements of the user from the API and store the ones that
+// are not stored yet in the DB. Newly unlocked achievements are announced and returned
+func (b *Bot) StoreSoTUserAchievements(u *model.User) ([]*model.UserAchievement, error) {
+	r, err := NewRequesterFromUser(u, b.Model.User)
+	if err != nil {
+		return nil, err
+	}
+	al, err := b.SoTGetAchievements(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSOTUnauth):
+			if err := b.quarantineRATCookie(u); err != nil {
+				b.Log.Error().Msgf("failed to quarantine RAT cookie: %s", err)
+			}
+			return nil, ErrRATCookieInvalid
+		default:
+			return nil, fmt.Errorf("failed to fetch user achievements for user %s: %w", u.UserID, err)
+		}
+	}
+	n, err := b.Model.UserAchievement.Count(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user achievements in DB: %w", err)
+	}
+
+	var nl []*model.UserAchievement
+	for _, sa := range al.Sorted {
+		ua := &model.UserAchievement{
+			UserID:      u.ID,
+			Name:        sa.Achievement.Name,
+			Description: sa.Achievement.Description,
+			MediaURL:    sa.Achievement.MediaURL,
+			Sort:        sa.Achievement.Sort,
+		}
+		ok, err := b.Model.UserAchievement.Insert(ua)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store user achievement for user %q in DB: %w", u.UserID, err)
+		}
+		if ok {
+			nl = append(nl, ua)
+		}
+	}
+	if n > 0 && len(nl) > 0 {
+		b.announceAchievements(u, nl)
+	}
+	return nl, nil
+}
+
+// announceAchievements announces the given newly unlocked achievements of the user in the announce
+// channel of each guild of the user that enabled achievement announcements. Achievements of users that
+// keep their stats private are not announced
+func (b *Bot) announceAchievements(u *model.User, al []*model.UserAchievement) {
+	ll := b.Log.With().Str("context", "bot.announceAchievements").Str("user_id", u.UserID).Logger()
+	if err := b.checkStatsPrivacy(u); err != nil {
+		ll.Debug().Msgf("not announcing achievements: %s", err)
+		return
+	}
+	if len(al) > AchievementMaxAnnounce {
+		al = al[:AchievementMaxAnnounce]
+	}
+	gl, err := b.Model.Guild.GetGuildsWithPref(model.GuildPrefAnnounceAchievements)
+	if err != nil {
+		ll.Error().Msgf("failed to read guilds from DB: %s", err)
+		return
+	}
+	for _, g := range gl {
+		en, err := b.Model.Guild.GetPrefBool(g, model.GuildPrefAnnounceAchievements)
+		if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+			ll.Warn().Msgf("failed to read announce achievements preference from DB: %s", err)
+			continue
+		}
+		if !en {
+			continue
+		}
+		m, err := b.guildMember(g.GuildID, u.UserID)
+		if err != nil {
+			continue
+		}
+		p := b.guildPrinter(g)
+		for _, a := range al {
+			e := &discordgo.MessageEmbed{
+				Type:        discordgo.EmbedTypeImage,
+				Title:       p.Sprintf("%s unlocked a new Sea of Thieves achievement: %s", memberName(m, m.User), a.Name),
+				Description: a.Description,
+			}
+			if a.MediaURL != "" {
+				e.Image = &discordgo.MessageEmbedImage{URL: a.MediaURL}
+			}
+			if _, err := b.Session.ChannelMessageSendEmbed(b.Model.Guild.AnnouceChannel(g), e); err != nil {
+				ll.Error().Msgf("failed to send achievement announcement: %s", err)
+			}
+		}
+	}
+}
+
+// ScheduledEventUpdateUserAchievements performs scheduled syncs of the SoT achievements of each user
+func (b *Bot) ScheduledEventUpdateUserAchievements() error {
+	_, err := b.RunUserJob("update user achievements", b.clock.Now(), func(u *model.User) error {
+		if _, err := b.StoreSoTUserAchievements(u); err != nil {
+			return fmt.Errorf("failed to store user achievements in DB: %w", err)
+		}
+		return nil
+	})
+	return err
+}
+
+// achievementsCommandOptions returns the options of the /achievements slash command
+func achievementsCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "search",
+			Description: "Only list achievements whose name or description contain this text",
+			Required:    false,
+		},
+	}
+}
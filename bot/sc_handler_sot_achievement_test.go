@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+func TestBot_announceAchievements(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		name    string
+		private bool
+	}{
+		{"public stats", false},
+		{"private stats", true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			s.AddMember(&discordgo.Member{GuildID: "300", User: &discordgo.User{ID: "200", Username: "bob"}})
+			db.onArg("FROM guilds", "300", []driver.Value{
+				int64(5), "300", "Crew", "201", now, "401", []byte(testEncryptionKey), int64(1), now, now,
+			})
+			db.on("JOIN guild_prefs", []driver.Value{"300"})
+			db.pref(t, "guild_prefs", model.GuildPrefAnnounceAchievements, true)
+			db.pref(t, "guild_prefs", model.GuildPrefAnnounceChannel, "400")
+			db.pref(t, "user_prefs", model.UserPrefStatsPrivate, tc.private)
+			u := &model.User{ID: 7, UserID: "200"}
+
+			b.announceAchievements(u, []*model.UserAchievement{{UserID: 7, Name: "Kraken Slayer"}})
+			ml := s.ChannelMessages("400")
+			if tc.private {
+				if len(s.Messages()) != 0 {
+					t.Errorf("announceAchievements failed, expected no message, got: %d", len(s.Messages()))
+				}
+				return
+			}
+			if len(ml) != 1 || len(ml[0].Embeds) != 1 {
+				t.Fatalf("announceAchievements failed, expected 1 embed in the announce channel, got: %d",
+					len(ml))
+			}
+			want := "bob unlocked a new Sea of Thieves achievement: Kraken Slayer"
+			if ml[0].Embeds[0].Title != want {
+				t.Errorf("announceAchievements failed, expected title: %q, got: %q", want, ml[0].Embeds[0].Title)
+			}
+		})
+	}
+}
@@ -8,8 +8,6 @@ import (
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 )
 
 // SoTAllegianceJSON is the nested struct from the Sea of Thieves event hub response
@@ -30,7 +28,7 @@ type SoTAllegiance struct {
 }
 
 // SlashCmdSoTAllegiance handles the /allegiance slash command
-func (b *Bot) SlashCmdSoTAllegiance(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTAllegiance(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	eo := i.ApplicationCommandData().Options
 	if len(eo) <= 0 {
 		return fmt.Errorf("no option given")
@@ -60,27 +58,27 @@ func (b *Bot) SlashCmdSoTAllegiance(s *discordgo.Session, i *discordgo.Interacti
 		return err
 	}
 
-	p := message.NewPrinter(language.German)
+	p := b.display(i.Interaction)
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Ships Sunk",
-		Value:  fmt.Sprintf("%s **%d** Total", IconShip, a.ShipsSunk),
+		Name:   p.Sprintf("Ships Sunk"),
+		Value:  p.Sprintf("%s **%s** Total", IconShip, p.Int(int64(a.ShipsSunk))),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Highest Streak",
-		Value:  fmt.Sprintf("%s **%d** Ships", IconGauge, a.MaxStreak),
+		Name:   p.Sprintf("Highest Streak"),
+		Value:  p.Sprintf("%s **%s** Ships", IconGauge, p.Int(int64(a.MaxStreak))),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Highest Hourglass Value",
-		Value:  fmt.Sprintf("%s **%s** Gold", IconGold, p.Sprintf("%d", a.TotalGold)),
+		Name:   p.Sprintf("Highest Hourglass Value"),
+		Value:  p.Sprintf("%s **%s** Gold", IconGold, p.Int(int64(a.TotalGold))),
 		Inline: true,
 	})
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title: fmt.Sprintf("Your current allegiance values for the **%s**:", a.Allegiance),
+			Title: p.Sprintf("Your current allegiance values for the **%s**:", a.Allegiance),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf("%s/factions/%s.png", AssetsBaseURL, a.Icon),
 			},
@@ -99,7 +97,7 @@ func (b *Bot) SlashCmdSoTAllegiance(s *discordgo.Session, i *discordgo.Interacti
 func (b *Bot) SoTGetAllegiance(rq *Requester, at string) (SoTAllegiance, error) {
 	var a SoTAllegiance
 	var al SoTAllegianceJSON
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return a, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
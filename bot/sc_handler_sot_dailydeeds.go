@@ -14,6 +14,9 @@ import (
 	"github.com/wneessen/arrgo/model"
 )
 
+// DeedsPerPage is the number of deeds per page of the /dailydeeds response
+const DeedsPerPage = 4
+
 // SoTEventHubJSON is the nested struct from the Sea of Thieves event hub response
 type SoTEventHubJSON struct {
 	Data struct {
@@ -43,8 +46,8 @@ type SoTDeed struct {
 }
 
 // SlashCmdSoTDailyDeeds handles the /dailydeed slash command
-func (b *Bot) SlashCmdSoTDailyDeeds(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	dl, err := b.Model.Deed.GetByDeedsAtTime(time.Now())
+func (b *Bot) SlashCmdSoTDailyDeeds(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	dl, err := b.Model.Deed.GetByDeedsAtTime(b.clock.Now())
 	if err != nil {
 		return err
 	}
@@ -52,45 +55,51 @@ func (b *Bot) SlashCmdSoTDailyDeeds(s *discordgo.Session, i *discordgo.Interacti
 		return fmt.Errorf("no deeds found for today in database")
 	}
 
+	p := b.display(i.Interaction)
 	var e []*discordgo.MessageEmbed
-	c := cases.Title(language.English)
 	for _, d := range dl {
-		var t, rg string
-		switch d.DeedType {
-		case model.DeedTypeStandard:
-			t = "Standard Deed"
-		case model.DeedTypeDailyStandard:
-			t = "Standard Daily Deed"
-		case model.DeedTypeDailySwift:
-			t = "Daily Swift Deed"
-		}
-		switch d.RewardIcon {
-		case "s":
-			rg = "Small renown"
-		case "m":
-			rg = "Medium renown"
-		}
-		de := fmt.Sprintf("%s\n\n**Valid from:** <t:%d>\n**Valid thru:** <t:%d>\n**Reward:** %d %s\n"+
-			"**Renown gain:** %s",
-			d.Description, d.ValidFrom.Unix(), d.ValidThru.Unix(), d.RewardAmount,
-			c.String(string(d.RewardType)), rg)
-		if t != "" {
-			ce := &discordgo.MessageEmbed{
-				Title:       t,
-				Description: de,
-				Type:        discordgo.EmbedTypeArticle,
-				Image: &discordgo.MessageEmbedImage{
-					URL: d.ImageURL,
-				},
-			}
+		if ce := deedEmbed(p, d); ce != nil {
 			e = append(e, ce)
 		}
 	}
 
-	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
-		return err
+	return b.paginate(s, i, EmbedPages(e, DeedsPerPage))
+}
+
+// deedEmbed returns the embed of the given deed. For deeds of unknown type nil is returned
+func deedEmbed(p *Display, d *model.Deed) *discordgo.MessageEmbed {
+	var t, rg string
+	switch d.DeedType {
+	case model.DeedTypeStandard:
+		t = p.Sprintf("Standard Deed")
+	case model.DeedTypeDailyStandard:
+		t = p.Sprintf("Standard Daily Deed")
+	case model.DeedTypeDailySwift:
+		t = p.Sprintf("Daily Swift Deed")
+	default:
+		return nil
+	}
+	switch d.RewardIcon {
+	case "s":
+		rg = p.Sprintf("Small renown")
+	case "m":
+		rg = p.Sprintf("Medium renown")
+	}
+	rt := cases.Title(language.English).String(string(d.RewardType))
+	if d.RewardType == model.RewardDoubloons {
+		rt = p.Sprintf("Doubloons")
+	}
+	de := p.Sprintf("%s\n\n**Valid from:** %s\n**Valid thru:** %s\n**Reward:** %s %s\n"+
+		"**Renown gain:** %s",
+		d.Description, p.Time(d.ValidFrom), p.Time(d.ValidThru), p.Int(int64(d.RewardAmount)), rt, rg)
+	return &discordgo.MessageEmbed{
+		Title:       t,
+		Description: de,
+		Type:        discordgo.EmbedTypeArticle,
+		Image: &discordgo.MessageEmbedImage{
+			URL: d.ImageURL,
+		},
 	}
-	return nil
 }
 
 // ScheduledEventUpdateDailyDeeds performs scheuled updates of the SoT daily deeds
@@ -101,6 +110,7 @@ func (b *Bot) ScheduledEventUpdateDailyDeeds() error {
 		return fmt.Errorf("failed to fetch deeds from event hub: %w", err)
 	}
 
+	var nl []*model.Deed
 	for _, d := range dl {
 		dbd := &model.Deed{
 			Description: d.BodyText,
@@ -131,23 +141,34 @@ func (b *Bot) ScheduledEventUpdateDailyDeeds() error {
 		if d.EndDateAPI != nil {
 			dbd.ValidThru = time.Time(*d.EndDateAPI)
 		}
-		if err := b.Model.Deed.Insert(dbd); err != nil && !errors.Is(err, model.ErrDeedDuplicate) {
+		err := b.Model.Deed.Insert(dbd)
+		switch {
+		case errors.Is(err, model.ErrDeedDuplicate):
+		case err != nil:
 			ll.Error().Msgf("failed to insert deed into database: %s", err)
+		case dbd.ValidThru.After(b.clock.Now()):
+			nl = append(nl, dbd)
 		}
 	}
+	if len(nl) > 0 {
+		b.announceDeeds(nl)
+	}
+	if err := b.notifySubscriptions(TopicDeed, nil, deedSubscriptionEvents(nl)); err != nil {
+		ll.Error().Msgf("failed to evaluate deed subscriptions: %s", err)
+	}
 	return nil
 }
 
 // SoTGetDailyDeeds returns the parsed API response from the Sea of Thieves event-hub API
 func (b *Bot) SoTGetDailyDeeds() ([]SoTDeed, error) {
 	var dl []SoTDeed
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return dl, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
 
 	// We need a valid RAT token first
-	ul, err := b.Model.User.GetUsers()
+	ul, err := b.Model.User.GetUsersWithRATCookie(0, 10, b.clock.Now())
 	if err != nil {
 		return dl, fmt.Errorf("failed to retrieve user list from DB: %w", err)
 	}
@@ -6,14 +6,15 @@ import (
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
 
 	"github.com/wneessen/arrgo/crypto"
 	"github.com/wneessen/arrgo/model"
 )
 
 // SlashCmdSoTFlameheart handles the /flameheart slash command
-func (b *Bot) SlashCmdSoTFlameheart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	e, err := b.getFlameheartEmbed()
+func (b *Bot) SlashCmdSoTFlameheart(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	e, err := b.getFlameheartEmbed(b.printer(i.Interaction))
 	if err != nil {
 		return err
 	}
@@ -44,7 +45,7 @@ func (b *Bot) ScheduledEventSoTFlameheart() error {
 			en = true
 		}
 		if en {
-			e, err := b.getFlameheartEmbed()
+			e, err := b.getFlameheartEmbed(b.guildPrinter(g))
 			if err != nil {
 				continue
 			}
@@ -56,8 +57,9 @@ func (b *Bot) ScheduledEventSoTFlameheart() error {
 	return nil
 }
 
-// getFlameheartEmbed returns a embed slice for use in slash commands or SendMessageEmbeds
-func (b *Bot) getFlameheartEmbed() ([]*discordgo.MessageEmbed, error) {
+// getFlameheartEmbed returns a embed slice for use in slash commands or SendMessageEmbeds. The quotes
+// are in-game quotes and therefore not translated
+func (b *Bot) getFlameheartEmbed(p *message.Printer) ([]*discordgo.MessageEmbed, error) {
 	q := []string{
 		`You're starting to annoy me.`,
 		`Surely you don't expect to triumph?`,
@@ -102,7 +104,7 @@ func (b *Bot) getFlameheartEmbed() ([]*discordgo.MessageEmbed, error) {
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       "Captain Flameheart yells at you:",
+			Title:       p.Sprintf("Captain Flameheart yells at you:"),
 			Description: fmt.Sprintf(`«*%s*»`, strings.ToUpper(q[rn])),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf(`%s/flameheart.png`, AssetsBaseURL),
@@ -2,13 +2,36 @@ package bot
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
 )
 
+// LedgerEmissaries maps the emissaries of the ledger API to the emissary names of the reputation API,
+// which are used to store the ledger history
+var LedgerEmissaries = map[string]string{
+	"athena":   "athenasfortune",
+	"hoarder":  "goldhoarders",
+	"merchant": "merchantalliance",
+	"order":    "orderofsouls",
+	"reaper":   "reapersbones",
+}
+
+// ledgerBandTitles holds the titles of the ledger bands of each emissary, starting with the highest band
+var ledgerBandTitles = map[string][]string{
+	"athena":   {"Legend", "Guardian", "Voyager", "Seeker"},
+	"hoarder":  {"Captain", "Marauder", "Seafarer", "Castaway"},
+	"merchant": {"Admiral", "Commander", "Cadet", "Sailor"},
+	"order":    {"Grandee", "Chief", "Mercenary", "Apprentice"},
+	"reaper":   {"Master", "Keeper", "Servant", "Follower"},
+}
+
 // SoTLedger represents the JSON structure of the Sea of Thieves leder positions within a season API response
 type SoTLedger struct {
 	Current SoTCurrentLedger `json:"current"`
@@ -36,7 +59,7 @@ type SoTEmissaryLedger struct {
 }
 
 // SlashCmdSoTLedger handles the /ledger slash command
-func (b *Bot) SlashCmdSoTLedger(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTLedger(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	eo := i.ApplicationCommandData().Options
 	if len(eo) <= 0 {
 		return fmt.Errorf("no option given")
@@ -65,37 +88,41 @@ func (b *Bot) SlashCmdSoTLedger(s *discordgo.Session, i *discordgo.InteractionCr
 	if err != nil {
 		return err
 	}
+	if err := b.storeSoTUserLedger(r.User, em, l); err != nil {
+		b.Log.Warn().Msgf("failed to store user ledger data to database: %s", err)
+	}
 
+	p := b.display(i.Interaction)
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Faction/Company",
+		Name:   p.Sprintf("Faction/Company"),
 		Value:  l.Name,
 		Inline: false,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Current Title",
+		Name:   p.Sprintf("Current Title"),
 		Value:  l.BandTitle,
 		Inline: false,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Emissary value",
-		Value:  fmt.Sprintf("%s **%d**", IconAncientCoin, l.Score),
+		Name:   p.Sprintf("Emissary value"),
+		Value:  p.Sprintf("%s **%s**", IconAncientCoin, p.Int(int64(l.Score))),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Ledger position",
-		Value:  fmt.Sprintf("%s **%d**", IconGauge, l.Rank),
+		Name:   p.Sprintf("Ledger position"),
+		Value:  p.Sprintf("%s **%s**", IconGauge, p.Int(int64(l.Rank))),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Next level in",
-		Value:  fmt.Sprintf("%s **%d** points", IconIncrease, l.ToNextRank),
+		Name:   p.Sprintf("Next level in"),
+		Value:  p.Sprintf("%s **%s** points", IconIncrease, p.Int(int64(l.ToNextRank))),
 		Inline: true,
 	})
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title: "Your global ledger in Sea of Thieves:",
+			Title: p.Sprintf("Your global ledger in Sea of Thieves:"),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf("%s/ledger/%s%d.png", AssetsBaseURL, em, 4-l.Band),
 			},
@@ -113,7 +140,7 @@ func (b *Bot) SlashCmdSoTLedger(s *discordgo.Session, i *discordgo.InteractionCr
 func (b *Bot) SoTGetLedger(rq *Requester, em string) (SoTEmissaryLedger, error) {
 	var l SoTEmissaryLedger
 	var al SoTLedger
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return l, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -144,10 +171,13 @@ func (b *Bot) SoTGetLedger(rq *Requester, em string) (SoTEmissaryLedger, error)
 		return l, err
 	}
 	r.SetSOTRequest(c)
-	rd, _, err := hc.Fetch(r)
+	rd, ho, err := hc.Fetch(r)
 	if err != nil {
 		return l, err
 	}
+	if ho.StatusCode == http.StatusUnauthorized {
+		return l, ErrSOTUnauth
+	}
 
 	if err := json.Unmarshal(rd, &al); err != nil {
 		return l, err
@@ -156,26 +186,97 @@ func (b *Bot) SoTGetLedger(rq *Requester, em string) (SoTEmissaryLedger, error)
 	l = al.Current.Friends.User
 	switch strings.ToLower(em) {
 	case "athena":
-		tl := []string{"Legend", "Guardian", "Voyager", "Seeker"}
 		l.Name = "Athena's Fortune"
-		l.BandTitle = tl[l.Band]
 	case "hoarder":
-		tl := []string{"Captain", "Marauder", "Seafarer", "Castaway"}
 		l.Name = "Gold Hoarders"
-		l.BandTitle = tl[l.Band]
 	case "merchant":
-		tl := []string{"Admiral", "Commander", "Cadet", "Sailor"}
 		l.Name = "Merchant Alliance"
-		l.BandTitle = tl[l.Band]
 	case "order":
-		tl := []string{"Grandee", "Chief", "Mercenary", "Apprentice"}
 		l.Name = "Order of Souls"
-		l.BandTitle = tl[l.Band]
 	case "reaper":
-		tl := []string{"Master", "Keeper", "Servant", "Follower"}
 		l.Name = "Reaper's Bones"
-		l.BandTitle = tl[l.Band]
 	}
+	l.BandTitle = ledgerBandTitle(em, l.Band)
 
 	return l, nil
 }
+
+// ledgerBandTitle returns the title of the given ledger band of the given emissary
+func ledgerBandTitle(em string, b int) string {
+	tl := ledgerBandTitles[strings.ToLower(em)]
+	if b < 0 || b >= len(tl) {
+		return ""
+	}
+	return tl[b]
+}
+
+// StoreSoTUserLedger will retrieve the latest ledger positions of all emissaries from the API and store
+// them in the DB
+func (b *Bot) StoreSoTUserLedger(u *model.User) error {
+	r, err := NewRequesterFromUser(u, b.Model.User)
+	if err != nil {
+		b.Log.Warn().Msgf("failed to create new requester: %s", err)
+		return err
+	}
+	for em := range LedgerEmissaries {
+		l, err := b.SoTGetLedger(r, em)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrSOTUnauth):
+				if err := b.quarantineRATCookie(u); err != nil {
+					b.Log.Error().Msgf("failed to quarantine RAT cookie: %s", err)
+				}
+				return ErrRATCookieInvalid
+			default:
+				return fmt.Errorf("failed to fetch %s ledger for user %s: %w", em, u.UserID, err)
+			}
+		}
+		if err := b.storeSoTUserLedger(u, em, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeSoTUserLedger stores the given ledger position of a user in the DB
+func (b *Bot) storeSoTUserLedger(u *model.User, em string, l SoTEmissaryLedger) error {
+	ul := &model.UserLedger{
+		UserID:   u.ID,
+		Emissary: LedgerEmissaries[strings.ToLower(em)],
+		Band:     int64(l.Band),
+		Rank:     int64(l.Rank),
+		Score:    int64(l.Score),
+		NextRank: int64(l.ToNextRank),
+	}
+	pl, err := b.Model.UserLedger.GetByUserID(u.ID, ul.Emissary)
+	switch {
+	case errors.Is(err, model.ErrUserLedgerNotExistent):
+		pl = nil
+	case err != nil:
+		return fmt.Errorf("failed to read previous user ledger for user %q from DB: %w", u.UserID, err)
+	}
+	if err := b.Model.UserLedger.Insert(ul); err != nil {
+		return fmt.Errorf("failed to store user ledger for user %q in DB: %w", u.UserID, err)
+	}
+
+	var pbt string
+	if pl != nil {
+		pbt = ledgerBandTitle(em, int(pl.Band))
+	}
+	ev := ledgerSubscriptionEvent(l.Name, ul, l.BandTitle, pl, pbt)
+	if err := b.notifySubscriptions(TopicLedger, u, []subscriptionEvent{ev}); err != nil {
+		b.Log.Warn().Msgf("failed to evaluate ledger subscriptions of user %q: %s", u.UserID, err)
+	}
+	return nil
+}
+
+// ScheduledEventUpdateUserLedger performs scheuled updates of the SoT ledger positions for each user
+func (b *Bot) ScheduledEventUpdateUserLedger() error {
+	_, err := b.RunUserJob("update user ledger", b.clock.Now(), func(u *model.User) error {
+		if err := b.StoreSoTUserLedger(u); err != nil {
+			return fmt.Errorf("failed to store user ledger in DB: %w", err)
+		}
+		return nil
+	})
+	return err
+}
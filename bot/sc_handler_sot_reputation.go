@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/wneessen/arrgo/crypto"
 	"github.com/wneessen/arrgo/model"
 
 	"github.com/bwmarrin/discordgo"
@@ -43,7 +42,7 @@ type SoTFactionNextLevel struct {
 }
 
 // SlashCmdSoTReputation handles the /reputation slash command
-func (b *Bot) SlashCmdSoTReputation(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTReputation(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	fo := i.ApplicationCommandData().Options
 	if len(fo) <= 0 {
 		return fmt.Errorf("no option given")
@@ -74,7 +73,7 @@ func (b *Bot) SlashCmdSoTReputation(s *discordgo.Session, i *discordgo.Interacti
 	if err != nil {
 		return err
 	}
-	if ur.CreateTime.Unix() < time.Now().Add(time.Minute*-30).Unix() {
+	if ur.CreateTime.Unix() < b.clock.Now().Add(time.Minute*-30).Unix() {
 		if err := b.StoreSoTUserReputation(r.User); err != nil {
 			b.Log.Warn().Msgf("failed to store user reputation data to database")
 		}
@@ -84,33 +83,35 @@ func (b *Bot) SlashCmdSoTReputation(s *discordgo.Session, i *discordgo.Interacti
 		}
 	}
 
+	p := b.display(i.Interaction)
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Motto",
+		Name:   p.Sprintf("Motto"),
 		Value:  ur.Motto,
 		Inline: false,
 	})
 	if ur.Rank != "" {
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   "Rank",
+			Name:   p.Sprintf("Rank"),
 			Value:  ur.Rank,
 			Inline: false,
 		})
 	}
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Level",
-		Value:  fmt.Sprintf("%s **%d**", IconGauge, ur.Level),
+		Name:   p.Sprintf("Level"),
+		Value:  p.Sprintf("%s **%s**", IconGauge, p.Int(ur.Level)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "XP in current level",
-		Value:  fmt.Sprintf("%s **%d/%d**", IconIncrease, ur.Experience, ur.ExperienceNextLevel),
+		Name: p.Sprintf("XP in current level"),
+		Value: p.Sprintf("%s **%s/%s**", IconIncrease, p.Int(ur.Experience),
+			p.Int(ur.ExperienceNextLevel)),
 		Inline: true,
 	})
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title: fmt.Sprintf("Your user reputation with **%s**", dbEmissaryToName(ur.Emissary)),
+			Title: p.Sprintf("Your user reputation with **%s**", dbEmissaryToName(ur.Emissary)),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf("%s/factions/%s.png", AssetsBaseURL, fa),
 			},
@@ -128,7 +129,7 @@ func (b *Bot) SlashCmdSoTReputation(s *discordgo.Session, i *discordgo.Interacti
 // SoTGetReputation returns the parsed API response from the Sea of Thieves reputation API
 func (b *Bot) SoTGetReputation(rq *Requester) (SoTReputation, error) {
 	var re SoTReputation
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return re, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -165,8 +166,10 @@ func (b *Bot) StoreSoTUserReputation(u *model.User) error {
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrSOTUnauth):
-			b.Log.Warn().Msgf("failed to fetch user reputation - RAT token is expired")
-			return nil
+			if err := b.quarantineRATCookie(u); err != nil {
+				b.Log.Error().Msgf("failed to quarantine RAT cookie: %s", err)
+			}
+			return ErrRATCookieInvalid
 		default:
 			return fmt.Errorf("failed to fetch user reputation for user %s: %w", u.UserID, err)
 		}
@@ -192,26 +195,19 @@ func (b *Bot) StoreSoTUserReputation(u *model.User) error {
 			return fmt.Errorf("failed to store user reputation for user %q in DB: %w", u.UserID, err)
 		}
 	}
+	if err := b.evaluateGoals(u, true); err != nil {
+		b.Log.Warn().Msgf("failed to evaluate goals of user %q: %s", u.UserID, err)
+	}
 	return nil
 }
 
 // ScheduledEventUpdateUserReputation performs scheuled updates of the SoT user reputation for each user
 func (b *Bot) ScheduledEventUpdateUserReputation() error {
-	ll := b.Log.With().Str("context", "bot.ScheduledEventUpdateUserReputation").Logger()
-	ul, err := b.Model.User.GetUsers()
-	if err != nil {
-		return fmt.Errorf("failed to retrieve user list from DB: %w", err)
-	}
-	for _, u := range ul {
+	_, err := b.RunUserJob("update user reputation", b.clock.Now(), func(u *model.User) error {
 		if err := b.StoreSoTUserReputation(u); err != nil {
-			ll.Error().Msgf("failed to store user reputation in DB: %s", err)
-			continue
-		}
-		rd, err := crypto.RandDuration(10, "s")
-		if err != nil {
-			rd = time.Second * 10
+			return fmt.Errorf("failed to store user reputation in DB: %w", err)
 		}
-		time.Sleep(rd)
-	}
-	return nil
+		return nil
+	})
+	return err
 }
@@ -2,13 +2,30 @@ package bot
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
 )
 
+// SeasonRateWindow is the period of the recent renown rate that the projected next tier is based on
+const SeasonRateWindow = time.Hour * 24 * 7
+
+// SeasonProgressReuse is the age up to which the stored season progress is reused by /season progress
+// instead of storing a new progress row on every request
+const SeasonProgressReuse = time.Minute * 5
+
+// ErrNoSeasonProgress is returned if the SoT API did not return any season progress
+var ErrNoSeasonProgress = errors.New("no SoT season progress found")
+
 // SoTSeasonList represents the JSON structure of the Sea of Thieves seasons API response
 type SoTSeasonList []SoTSeasonProgress
 
@@ -58,7 +75,7 @@ type SoTSeasonReward struct {
 }
 
 // SlashCmdSoTSeasonProgress handles the /season slash command
-func (b *Bot) SlashCmdSoTSeasonProgress(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTSeasonProgress(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	r, err := b.NewRequester(i.Interaction)
 	if err != nil {
 		return err
@@ -68,7 +85,11 @@ func (b *Bot) SlashCmdSoTSeasonProgress(s *discordgo.Session, i *discordgo.Inter
 		return err
 	}
 	if len(sl) <= 0 {
-		return fmt.Errorf("no SoT season progress found")
+		return ErrNoSeasonProgress
+	}
+	cs, err := b.recentSoTUserSeason(r.User, sl)
+	if err != nil {
+		return fmt.Errorf("failed to store season progress in DB: %w", err)
 	}
 
 	// Roman numerals map
@@ -86,54 +107,68 @@ func (b *Bot) SlashCmdSoTSeasonProgress(s *discordgo.Session, i *discordgo.Inter
 	}
 
 	sp := sl[len(sl)-1]
+	p := b.display(i.Interaction)
+
+	// A new season starts at tier 0, which has no entry in the tier list
+	var ct SoTSeasonTier
+	if sp.Tier >= 1 && sp.Tier <= len(sp.Tiers) {
+		ct = sp.Tiers[sp.Tier-1]
+	}
+	tt := ct.Title
+	if tt == "" {
+		tt = "-"
+	}
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Current title",
-		Value:  sp.Tiers[sp.Tier-1].Title,
+		Name:   p.Sprintf("Current title"),
+		Value:  tt,
 		Inline: false,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Renown Level",
-		Value:  fmt.Sprintf("🌡️ %.1f%%", sp.LevelProgress),
+		Name:   p.Sprintf("Renown Level"),
+		Value:  p.Sprintf("🌡️ %s%%", p.Float(sp.LevelProgress, 1)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Renown Tier",
-		Value:  fmt.Sprintf("📜 %d", sp.Tier),
+		Name:   p.Sprintf("Renown Tier"),
+		Value:  p.Sprintf("📜 %d", sp.Tier),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   "Challenges",
-		Value:  fmt.Sprintf("☑️ %d/%d completed", sp.CompletedChallenges, sp.TotalChallenges),
+		Name:   p.Sprintf("Challenges"),
+		Value:  p.Sprintf("☑️ %d/%d completed", sp.CompletedChallenges, sp.TotalChallenges),
 		Inline: true,
 	})
+	ef = append(ef, b.seasonProgressFields(r.User, p, sp, cs)...)
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title: fmt.Sprintf("Your progress in Sea of Thieves %s", sp.SeasonTitle),
-			Thumbnail: &discordgo.MessageEmbedThumbnail{
-				URL: fmt.Sprintf("%s/numerals/%s.png", AssetsBaseURL, rn[sp.Tier]),
-			},
+			Title:  p.Sprintf("Your progress in Sea of Thieves %s", sp.SeasonTitle),
 			Type:   discordgo.EmbedTypeRich,
 			Fields: ef,
 		},
 	}
-	l := sp.Tiers[sp.Tier-1].Levels
+	if n, ok := rn[sp.Tier]; ok {
+		e[0].Thumbnail = &discordgo.MessageEmbedThumbnail{
+			URL: fmt.Sprintf("%s/numerals/%s.png", AssetsBaseURL, n),
+		}
+	}
+	l := ct.Levels
 	if len(l) > 0 {
 		pl := int(math.Floor(sp.LevelProgress))
 		for _, cl := range l {
 			if cl.Number == pl {
 				if len(cl.Rewards.Base) > 0 {
 					br := cl.Rewards.Base[0]
-					e = append(e, buildSoTRewardEmbed("Base", &br, sp.CDNPath))
+					e = append(e, buildSoTRewardEmbed(p.Printer, p.Sprintf("Base"), &br, sp.CDNPath))
 				}
 				if len(cl.Rewards.Legendary) > 0 {
 					br := cl.Rewards.Legendary[0]
-					e = append(e, buildSoTRewardEmbed("Legendary", &br, sp.CDNPath))
+					e = append(e, buildSoTRewardEmbed(p.Printer, p.Sprintf("Legendary"), &br, sp.CDNPath))
 				}
 				if len(cl.Rewards.SeasonPass) > 0 {
 					br := cl.Rewards.SeasonPass[0]
-					e = append(e, buildSoTRewardEmbed("Season Pass", &br, sp.CDNPath))
+					e = append(e, buildSoTRewardEmbed(p.Printer, p.Sprintf("Season Pass"), &br, sp.CDNPath))
 				}
 			}
 		}
@@ -148,7 +183,7 @@ func (b *Bot) SlashCmdSoTSeasonProgress(s *discordgo.Session, i *discordgo.Inter
 // SoTGetSeasonProgress returns the parsed API response from the Sea of Thieves season progress API
 func (b *Bot) SoTGetSeasonProgress(rq *Requester) (SoTSeasonList, error) {
 	var s SoTSeasonList
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return s, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -161,38 +196,200 @@ func (b *Bot) SoTGetSeasonProgress(rq *Requester) (SoTSeasonList, error) {
 		return s, err
 	}
 	r.SetSOTRequest(c)
-	rd, _, err := hc.Fetch(r)
+	rd, ho, err := hc.Fetch(r)
 	if err != nil {
 		return s, err
 	}
+	if ho.StatusCode == http.StatusUnauthorized {
+		return s, ErrSOTUnauth
+	}
 	if err := json.Unmarshal(rd, &s); err != nil {
 		return s, err
 	}
 	return s, nil
 }
 
+// seasonProgressFields returns the embed fields with the renown levels gained this week and the projected time
+// the next tier is reached
+func (b *Bot) seasonProgressFields(u *model.User, p *Display, sp SoTSeasonProgress,
+	cs *model.UserSeasonProgress,
+) []*discordgo.MessageEmbedField {
+	n := b.clock.Now()
+	wg := 0.0
+	if w, err := ParseWindow(PeriodThisWeek, n, p.tz); err == nil {
+		if os, err := b.Model.UserSeason.GetByUserIDAtTime(u.ID, cs.Season, w.From); err == nil {
+			wg = cs.LevelProgress - os.LevelProgress
+		}
+	}
+
+	nt := p.Sprintf("No progress within the last 7 days")
+	switch {
+	case sp.Tier >= len(sp.Tiers):
+		nt = p.Sprintf("Final tier reached")
+	case sp.Tier < 0:
+	case len(sp.Tiers[sp.Tier].Levels) > 0:
+		os, err := b.Model.UserSeason.GetByUserIDAtTime(u.ID, cs.Season, n.Add(-SeasonRateWindow))
+		if err != nil || !cs.CreateTime.After(os.CreateTime) || cs.LevelProgress <= os.LevelProgress {
+			break
+		}
+		r := (cs.LevelProgress - os.LevelProgress) / cs.CreateTime.Sub(os.CreateTime).Seconds()
+		tl := float64(sp.Tiers[sp.Tier].Levels[0].Number)
+		et := n.Add(time.Duration((tl - cs.LevelProgress) / r * float64(time.Second)))
+		nt = p.Time(et)
+	}
+
+	return []*discordgo.MessageEmbedField{
+		{
+			Name:   p.Sprintf("Levels this week"),
+			Value:  p.Sprintf("%s+%s levels", IconIncrease, p.Float(wg, 1)),
+			Inline: true,
+		},
+		{
+			Name:   p.Sprintf("Next tier"),
+			Value:  nt,
+			Inline: true,
+		},
+	}
+}
+
+// StoreSoTUserSeason will retrieve the latest season progress from the API and store it in the DB
+func (b *Bot) StoreSoTUserSeason(u *model.User) error {
+	r, err := NewRequesterFromUser(u, b.Model.User)
+	if err != nil {
+		return err
+	}
+	sl, err := b.SoTGetSeasonProgress(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSOTUnauth):
+			if err := b.quarantineRATCookie(u); err != nil {
+				b.Log.Error().Msgf("failed to quarantine RAT cookie: %s", err)
+			}
+			return ErrRATCookieInvalid
+		default:
+			return fmt.Errorf("failed to fetch season progress for user %s: %w", u.UserID, err)
+		}
+	}
+	if len(sl) <= 0 {
+		return ErrNoSeasonProgress
+	}
+	_, err = b.storeSoTUserSeason(u, sl)
+	return err
+}
+
+// recentSoTUserSeason returns the latest stored season progress of the user if it belongs to the current
+// season and is not older than SeasonProgressReuse. Otherwise the current progress is stored
+func (b *Bot) recentSoTUserSeason(u *model.User, sl SoTSeasonList) (*model.UserSeasonProgress, error) {
+	ps, err := b.Model.UserSeason.GetLatestByUserID(u.ID)
+	if err == nil && ps.Season == sl[len(sl)-1].SeasonTitle &&
+		b.clock.Now().Sub(ps.CreateTime) <= SeasonProgressReuse {
+		return ps, nil
+	}
+	return b.storeSoTUserSeason(u, sl)
+}
+
+// storeSoTUserSeason stores the progress of the current (last) season of the given season list in the
+// DB. If the stored progress belongs to a past season, the past season is archived. Tier and level-ups
+// are announced to the user
+func (b *Bot) storeSoTUserSeason(u *model.User, sl SoTSeasonList) (*model.UserSeasonProgress, error) {
+	sp := sl[len(sl)-1]
+	ps, err := b.Model.UserSeason.GetLatestByUserID(u.ID)
+	if err != nil && !errors.Is(err, model.ErrUserSeasonNotExistent) {
+		return nil, fmt.Errorf("failed to read season progress from DB: %w", err)
+	}
+	if err != nil {
+		ps = nil
+	}
+	if ps != nil && ps.Season != sp.SeasonTitle {
+		if err := b.Model.UserSeason.Archive(ps); err != nil {
+			return nil, fmt.Errorf("failed to archive season %q: %w", ps.Season, err)
+		}
+		ps = nil
+	}
+
+	cs := &model.UserSeasonProgress{
+		UserID:          u.ID,
+		Season:          sp.SeasonTitle,
+		Tier:            sp.Tier,
+		LevelProgress:   sp.LevelProgress,
+		ChallengesDone:  sp.CompletedChallenges,
+		ChallengesTotal: sp.TotalChallenges,
+	}
+	if err := b.Model.UserSeason.Insert(cs); err != nil {
+		return nil, fmt.Errorf("failed to store season progress for user %q in DB: %w", u.UserID, err)
+	}
+	if ps != nil {
+		b.announceSeasonProgress(u, sp, ps, cs)
+	}
+	return cs, nil
+}
+
+// announceSeasonProgress notifies the user about a tier or level-up between the previous and the current
+// season progress
+func (b *Bot) announceSeasonProgress(u *model.User, sp SoTSeasonProgress, ps, cs *model.UserSeasonProgress) {
+	cl, pl := int(math.Floor(cs.LevelProgress)), int(math.Floor(ps.LevelProgress))
+	if cs.Tier <= ps.Tier && cl <= pl {
+		return
+	}
+	p := b.userPrinter(u)
+	no := &notify.Notification{
+		Type: notify.TypeMilestone,
+		User: u,
+		Embed: &discordgo.MessageEmbed{
+			Type:        discordgo.EmbedTypeArticle,
+			Title:       p.Sprintf("Level up!"),
+			Description: p.Sprintf("You reached renown level %d in Sea of Thieves %s.", cl, cs.Season),
+		},
+		DedupKey: fmt.Sprintf("season-%s-level-%d", cs.Season, cl),
+	}
+	if cs.Tier > ps.Tier {
+		tt := ""
+		if cs.Tier > 0 && cs.Tier <= len(sp.Tiers) {
+			tt = sp.Tiers[cs.Tier-1].Title
+		}
+		no.Embed.Title = p.Sprintf("Tier up!")
+		no.Embed.Description = p.Sprintf("You reached renown tier %d (%s) in Sea of Thieves %s.", cs.Tier, tt,
+			cs.Season)
+		no.DedupKey = fmt.Sprintf("season-%s-tier-%d", cs.Season, cs.Tier)
+	}
+	if _, err := b.notifier().Notify(no); err != nil {
+		b.Log.Error().Msgf("failed to send season progress notification: %s", err)
+	}
+}
+
+// ScheduledEventUpdateUserSeason performs scheduled updates of the SoT season progress for each user
+func (b *Bot) ScheduledEventUpdateUserSeason() error {
+	_, err := b.RunUserJob("update user season progress", b.clock.Now(), func(u *model.User) error {
+		if err := b.StoreSoTUserSeason(u); err != nil {
+			return fmt.Errorf("failed to store user season progress in DB: %w", err)
+		}
+		return nil
+	})
+	return err
+}
+
 // buildRewardEmbed returns a discordgo.MessageEmbed object for different reward types
-func buildSoTRewardEmbed(t string, r *SoTSeasonReward, cp string) *discordgo.MessageEmbed {
+func buildSoTRewardEmbed(p *message.Printer, t string, r *SoTSeasonReward, cp string) *discordgo.MessageEmbed {
 	e := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("Your latest reward in the %q tier", t),
+		Title:       p.Sprintf("Your latest reward in the %q tier", t),
 		Description: r.EntitlementText,
 		URL:         fmt.Sprintf("%s/%s", cp, r.EntitlementURL),
 		Type:        discordgo.EmbedTypeImage,
 	}
 	if strings.HasPrefix(r.CurrencyType, "gold-") {
-		e.Description = "A nice stack of Gold!"
+		e.Description = p.Sprintf("A nice stack of Gold!")
 		e.Thumbnail = &discordgo.MessageEmbedThumbnail{
 			URL: fmt.Sprintf("%s/season/%s.png", AssetsBaseURL, r.CurrencyType),
 		}
 	}
 	if strings.HasPrefix(r.CurrencyType, "doubloons-") {
-		e.Description = "A nice stack of Doubloons!"
+		e.Description = p.Sprintf("A nice stack of Doubloons!")
 		e.Thumbnail = &discordgo.MessageEmbedThumbnail{
 			URL: fmt.Sprintf("%s/season/%s.png", AssetsBaseURL, r.CurrencyType),
 		}
 	}
 	if strings.HasPrefix(r.CurrencyType, "coins-") {
-		e.Description = "A nice stack of Ancient Coins!"
+		e.Description = p.Sprintf("A nice stack of Ancient Coins!")
 		e.Thumbnail = &discordgo.MessageEmbedThumbnail{
 			URL: fmt.Sprintf("%s/season/%s.png", AssetsBaseURL, r.CurrencyType),
 		}
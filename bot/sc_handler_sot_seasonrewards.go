@@ -0,0 +1,219 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// seasonRewardsMaxEmbeds is the maximum number of reward embeds on a page of /season rewards (Discord
+// allows 10 embeds per message, one is used for the page summary)
+const seasonRewardsMaxEmbeds = 9
+
+// ErrSeasonTier is returned if the requested renown tier does not exist in the current season
+var ErrSeasonTier = errors.New("this renown tier does not exist in the current season")
+
+// seasonRewardType is one of the reward tracks of a season level
+type seasonRewardType struct {
+	name    string
+	rewards func(SoTSeasonRewards) []SoTSeasonReward
+}
+
+// seasonRewardTypes is the list of reward tracks shown by /season rewards
+var seasonRewardTypes = []seasonRewardType{
+	{name: "Base", rewards: func(r SoTSeasonRewards) []SoTSeasonReward { return r.Base }},
+	{name: "Legendary", rewards: func(r SoTSeasonRewards) []SoTSeasonReward { return r.Legendary }},
+	{name: "Season Pass", rewards: func(r SoTSeasonRewards) []SoTSeasonReward { return r.SeasonPass }},
+}
+
+// SlashCmdSoTSeason handles the /season slash command
+func (b *Bot) SlashCmdSoTSeason(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no sub-command provided")
+	}
+	switch ol[0].Name {
+	case "progress":
+		return b.SlashCmdSoTSeasonProgress(s, i)
+	case "rewards":
+		return b.seasonRewards(s, i, ol[0].Options)
+	default:
+		return fmt.Errorf("unknown sub-command: %s", ol[0].Name)
+	}
+}
+
+// seasonRewards handles the /season rewards sub-command. It shows the rewards of the current level in the
+// requested (default: current) tier. The rewards of the other levels of the tier are browsed with the
+// paginator, so the season progress is only fetched once per request
+func (b *Bot) seasonRewards(s DiscordAPI, i *discordgo.InteractionCreate,
+	ol []*discordgo.ApplicationCommandInteractionDataOption,
+) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	sp, err := b.seasonRewardsProgress(r)
+	if err != nil {
+		return err
+	}
+
+	t := sp.Tier
+	if t < 1 {
+		t = 1
+	}
+	for _, o := range ol {
+		if o.Name == "tier" {
+			t = int(o.IntValue())
+		}
+	}
+	if t < 1 || t > len(sp.Tiers) {
+		return ErrSeasonTier
+	}
+	pg := 0
+	cl := int(math.Floor(sp.LevelProgress))
+	for n, l := range sp.Tiers[t-1].Levels {
+		if l.Number <= cl {
+			pg = n
+		}
+	}
+
+	return b.paginateFrom(s, i, seasonRewardsPages(b.display(i.Interaction), sp, t), pg)
+}
+
+// seasonRewardsProgress fetches the progress of the current season of the requester from the SoT API
+func (b *Bot) seasonRewardsProgress(r *Requester) (SoTSeasonProgress, error) {
+	sl, err := b.SoTGetSeasonProgress(r)
+	if err != nil {
+		return SoTSeasonProgress{}, err
+	}
+	if len(sl) <= 0 {
+		return SoTSeasonProgress{}, ErrNoSeasonProgress
+	}
+	return sl[len(sl)-1], nil
+}
+
+// seasonRewardsPages returns one page of reward embeds for each level of the given tier
+func seasonRewardsPages(p *Display, sp SoTSeasonProgress, t int) [][]*discordgo.MessageEmbed {
+	st := sp.Tiers[t-1]
+	lf := seasonRewardsLockedField(p, sp)
+	if len(st.Levels) <= 0 {
+		return [][]*discordgo.MessageEmbed{{
+			{
+				Type:        discordgo.EmbedTypeRich,
+				Title:       p.Sprintf("Sea of Thieves %s rewards", sp.SeasonTitle),
+				Description: p.Sprintf("There are no rewards in tier %d (%s).", t, st.Title),
+				Fields:      []*discordgo.MessageEmbedField{lf},
+			},
+		}}
+	}
+
+	pl := make([][]*discordgo.MessageEmbed, 0, len(st.Levels))
+	for _, sl := range st.Levels {
+		e := []*discordgo.MessageEmbed{
+			{
+				Type:        discordgo.EmbedTypeRich,
+				Title:       p.Sprintf("Sea of Thieves %s rewards", sp.SeasonTitle),
+				Description: p.Sprintf("Tier %d (%s), level %d", t, st.Title, sl.Number),
+				Fields:      []*discordgo.MessageEmbedField{lf},
+			},
+		}
+		for _, rt := range seasonRewardTypes {
+			for _, r := range rt.rewards(sl.Rewards) {
+				if len(e) > seasonRewardsMaxEmbeds {
+					break
+				}
+				rn := p.Sprintf(rt.name)
+				if r.EntitlementText != "" {
+					rn = fmt.Sprintf("%s: %s", rn, r.EntitlementText)
+				}
+				re := &discordgo.MessageEmbed{
+					Type:        discordgo.EmbedTypeRich,
+					Title:       rn,
+					Description: seasonRewardState(p, r),
+					Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: seasonRewardImage(r, sp.CDNPath)},
+				}
+				if r.EntitlementDescription != "" {
+					re.Description += "\n" + r.EntitlementDescription
+				}
+				e = append(e, re)
+			}
+		}
+		pl = append(pl, e)
+	}
+	return pl
+}
+
+// seasonRewardsLockedField returns the embed field with the number of still locked rewards of each reward
+// track in the whole season
+func seasonRewardsLockedField(p *Display, sp SoTSeasonProgress) *discordgo.MessageEmbedField {
+	var v string
+	for _, rt := range seasonRewardTypes {
+		var lc, tc int64
+		for _, st := range sp.Tiers {
+			for _, sl := range st.Levels {
+				for _, r := range rt.rewards(sl.Rewards) {
+					tc++
+					if r.Locked {
+						lc++
+					}
+				}
+			}
+		}
+		v += p.Sprintf("**%s**: %s of %s", p.Sprintf(rt.name), p.Int(lc), p.Int(tc)) + "\n"
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  p.Sprintf("Still locked"),
+		Value: v,
+	}
+}
+
+// seasonRewardState returns the owned/locked state of the given reward
+func seasonRewardState(p *Display, r SoTSeasonReward) string {
+	switch {
+	case r.Owned:
+		return p.Sprintf("✅ Owned")
+	case r.Locked:
+		return p.Sprintf("🔒 Locked")
+	default:
+		return p.Sprintf("🔓 Unlocked")
+	}
+}
+
+// seasonRewardImage returns the image URL of the given reward. Currency rewards use the bot's own assets
+func seasonRewardImage(r SoTSeasonReward, cp string) string {
+	for _, cu := range []string{"gold-", "doubloons-", "coins-"} {
+		if strings.HasPrefix(r.CurrencyType, cu) {
+			return fmt.Sprintf("%s/season/%s.png", AssetsBaseURL, r.CurrencyType)
+		}
+	}
+	return fmt.Sprintf("%s/%s", cp, r.EntitlementURL)
+}
+
+// seasonCommandOptions returns the options of the /season slash command
+func seasonCommandOptions() []*discordgo.ApplicationCommandOption {
+	tmin := 1.0
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "progress",
+			Description: "Shows your renown progress in the current season",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "rewards",
+			Description: "Browse the rewards of the current season",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "tier",
+					Description: "The renown tier to browse (default: your current tier)",
+					Required:    false,
+					MinValue:    &tmin,
+				},
+			},
+		},
+	}
+}
@@ -5,12 +5,27 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 
 	"github.com/wneessen/arrgo/model"
 )
 
+// DefaultRATCookieLifetime is the assumed lifetime of a RAT cookie that was provided without expiration
+const DefaultRATCookieLifetime = time.Hour * 24 * 14
+
+// List of /setrat specific errors
+var (
+	ErrRATCookieFormat = errors.New("the provided Sea of Thieves authentication cookie could not be " +
+		"parsed. Please provide the string of the SoT-RAT-Extractor, the rat= cookie value or the full " +
+		"Cookie header")
+	ErrRATCookieRejected = errors.New("the provided Sea of Thieves authentication cookie has been rejected " +
+		"by the Sea of Thieves API. Please log in to the Sea of Thieves website again and provide a new cookie")
+)
+
 // SoTRATCookie represents the JSON formated Sea of Thieves authentication cookie
 type SoTRATCookie struct {
 	Value      string `json:"Value"`
@@ -18,7 +33,7 @@ type SoTRATCookie struct {
 }
 
 // SlashCmdSetRAT handles the /setrat slash command
-func (b *Bot) SlashCmdSetRAT(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSetRAT(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	ol := i.ApplicationCommandData().Options
 
 	var us string
@@ -33,12 +48,13 @@ func (b *Bot) SlashCmdSetRAT(s *discordgo.Session, i *discordgo.InteractionCreat
 		if !errors.Is(err, model.ErrUserNotExistent) {
 			return fmt.Errorf("failed to look up user: %w", err)
 		}
+		p := b.display(i.Interaction)
 		e := []*discordgo.MessageEmbed{
 			{
 				Type:  discordgo.EmbedTypeArticle,
-				Title: "Please register your user first!",
-				Description: "To use the Sea of Thieves bot features, please first use the **/register** " +
-					"command to register your user with the bot",
+				Title: p.Sprintf("Please register your user first!"),
+				Description: p.Sprintf("To use the Sea of Thieves bot features, please first use the **/register** " +
+					"command to register your user with the bot"),
 			},
 		}
 		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -62,14 +78,13 @@ func (b *Bot) SlashCmdSetRAT(s *discordgo.Session, i *discordgo.InteractionCreat
 		return fmt.Errorf("provided rat-cookie cannot be empty")
 	}
 
-	// Base64-decode and JSON unmarshall the cookie
-	var src SoTRATCookie
-	rc, err := base64.StdEncoding.DecodeString(ov)
+	src, err := ParseSoTRATCookie(ov, b.clock.Now())
 	if err != nil {
-		return fmt.Errorf("failed to base64 decode RAT cookie: %w", err)
+		return err
 	}
-	if err := json.Unmarshal(rc, &src); err != nil {
-		return fmt.Errorf("failed to JSON unmarshall RAT cookie: %w", err)
+	gt, err := b.verifySoTRATCookie(src.Value)
+	if err != nil {
+		return err
 	}
 
 	if err := b.Model.User.SetPrefEnc(u, model.UserPrefSoTAuthToken, src.Value); err != nil {
@@ -78,15 +93,30 @@ func (b *Bot) SlashCmdSetRAT(s *discordgo.Session, i *discordgo.InteractionCreat
 	if err := b.Model.User.SetPrefEnc(u, model.UserPrefSoTAuthTokenExpiration, src.Expiration); err != nil {
 		return fmt.Errorf("failed to store RAT cookie expiration date in DB: %w", err)
 	}
+	if err := b.Model.User.SetRATExpire(u, time.Unix(src.Expiration, 0)); err != nil {
+		return fmt.Errorf("failed to store RAT cookie expiration date in DB: %w", err)
+	}
 	if err := b.Model.User.SetPref(u, model.UserPrefSoTAuthTokenNotified, false); err != nil {
 		return fmt.Errorf("failed to update RAT cookie notified in DB: %w", err)
 	}
+	if err := b.Model.User.DeletePref(u, model.UserPrefSoTAuthTokenInvalid); err != nil {
+		return fmt.Errorf("failed to lift RAT cookie quarantine in DB: %w", err)
+	}
+	if err := b.Model.User.DeletePrefsWithPrefix(u, model.UserPrefRATReminderNotifiedPrefix); err != nil {
+		return fmt.Errorf("failed to reset RAT cookie reminders in DB: %w", err)
+	}
 
+	p := b.display(i.Interaction)
+	ed := p.Sprintf("Thank you for storing/updating your Sea of Thieves authentication cookie. It "+
+		"expires %s (%s).", discordTimestamp(src.Expiration, "R"), p.Time(time.Unix(src.Expiration, 0)))
+	if gt != "" {
+		ed = p.Sprintf("Ahoy, **%s**! %s", gt, ed)
+	}
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       "Sea of Thieves authentication cookie stored/updated",
-			Description: "Thank you for storing/updating your Sea of Thieves authentication cookie.",
+			Title:       p.Sprintf("Sea of Thieves authentication cookie stored/updated"),
+			Description: ed,
 		},
 	}
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -94,3 +124,84 @@ func (b *Bot) SlashCmdSetRAT(s *discordgo.Session, i *discordgo.InteractionCreat
 	}
 	return nil
 }
+
+// ParseSoTRATCookie parses the RAT cookie as provided to the /setrat command. Supported formats are the
+// base64-encoded JSON as provided by the SoT-RAT-Extractor, a raw "rat=" cookie value and a full Cookie
+// header. If the format does not provide an expiration, DefaultRATCookieLifetime from t is assumed.
+// The returned errors never contain the cookie itself
+func ParseSoTRATCookie(v string, t time.Time) (SoTRATCookie, error) {
+	var src SoTRATCookie
+	v = strings.TrimSpace(v)
+	if len(v) >= 7 && strings.EqualFold(v[:7], "cookie:") {
+		v = strings.TrimSpace(v[7:])
+	}
+	if v == "" {
+		return src, ErrRATCookieFormat
+	}
+
+	rc, err := base64.StdEncoding.DecodeString(v)
+	if err != nil || json.Unmarshal(rc, &src) != nil || src.Value == "" {
+		src = SoTRATCookie{}
+		switch {
+		case strings.Contains(v, "rat="):
+			hr := &http.Request{Header: http.Header{"Cookie": {v}}}
+			c, err := hr.Cookie("rat")
+			if err != nil || c.Value == "" {
+				return src, ErrRATCookieFormat
+			}
+			src.Value = c.Value
+		case strings.ContainsAny(v, " \t;,\""):
+			return src, ErrRATCookieFormat
+		default:
+			src.Value = v
+		}
+	}
+	if src.Expiration <= 0 {
+		src.Expiration = t.Add(DefaultRATCookieLifetime).Unix()
+	}
+	if src.Expiration <= t.Unix() {
+		return src, ErrRATCookieExpired
+	}
+	return src, nil
+}
+
+// verifySoTRATCookie performs a live request against the SoT API with the given RAT cookie, to make sure
+// it is valid before it is stored. It returns the gamertag of the cookie's owner, if it could be detected
+func (b *Bot) verifySoTRATCookie(c string) (string, error) {
+	hc, err := b.sotClient()
+	if err != nil {
+		return "", fmt.Errorf(ErrFailedHTTPClient, err)
+	}
+	r, err := hc.HTTPReq(APIURLSoTUserOverview, ReqMethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	r.SetSOTRequest(c)
+	_, ho, err := hc.Fetch(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify RAT cookie with the Sea of Thieves API: %w", err)
+	}
+	switch {
+	case ho.StatusCode == http.StatusUnauthorized || ho.StatusCode == http.StatusForbidden:
+		return "", ErrRATCookieRejected
+	case ho.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("failed to verify RAT cookie with the Sea of Thieves API: unexpected "+
+			"HTTP status %d", ho.StatusCode)
+	}
+
+	// The gamertag is only informational, so failing to fetch it is not an error
+	var ub SoTUserBalance
+	r, err = hc.HTTPReq(APIURLSoTUserBalance, ReqMethodGet, nil)
+	if err != nil {
+		return "", nil
+	}
+	r.SetSOTRequest(c)
+	rd, ho, err := hc.Fetch(r)
+	if err != nil || ho.StatusCode != http.StatusOK {
+		return "", nil
+	}
+	if err := json.Unmarshal(rd, &ub); err != nil {
+		return "", nil
+	}
+	return ub.GamerTag, nil
+}
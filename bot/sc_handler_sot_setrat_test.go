@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSoTRATCookie(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	exp := now.Add(time.Hour * 48).Unix()
+	enc := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	tt := []struct {
+		name  string
+		v     string
+		value string
+		exp   int64
+		err   error
+	}{
+		{
+			"base64-encoded JSON", enc(`{"Value":"abc123","Expiration":` + itoa(exp) + `}`), "abc123",
+			exp, nil,
+		},
+		{
+			"base64-encoded JSON without expiration", enc(`{"Value":"abc123"}`), "abc123",
+			now.Add(DefaultRATCookieLifetime).Unix(), nil,
+		},
+		{"raw cookie value", "abc123", "abc123", now.Add(DefaultRATCookieLifetime).Unix(), nil},
+		{"rat cookie", "rat=abc123", "abc123", now.Add(DefaultRATCookieLifetime).Unix(), nil},
+		{
+			"cookie header", "Cookie: foo=bar; rat=abc123; baz=1", "abc123",
+			now.Add(DefaultRATCookieLifetime).Unix(), nil,
+		},
+		{"surrounding whitespace", "  abc123\n", "abc123", now.Add(DefaultRATCookieLifetime).Unix(), nil},
+		{"empty", "", "", 0, ErrRATCookieFormat},
+		{"empty cookie header", "Cookie:  ", "", 0, ErrRATCookieFormat},
+		{"empty rat cookie", "foo=bar; rat=", "", 0, ErrRATCookieFormat},
+		{"header without rat cookie", "foo=bar; baz=1", "", 0, ErrRATCookieFormat},
+		{
+			"expired", enc(`{"Value":"abc123","Expiration":` + itoa(now.Add(-time.Hour).Unix()) + `}`),
+			"", 0, ErrRATCookieExpired,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseSoTRATCookie(tc.v, now)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("ParseSoTRATCookie failed, expected error: %s, got: %v", tc.err, err)
+				}
+				if err != nil && strings.Contains(err.Error(), "abc123") {
+					t.Errorf("ParseSoTRATCookie failed, error contains the cookie: %s", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSoTRATCookie failed: %s", err)
+			}
+			if c.Value != tc.value {
+				t.Errorf("ParseSoTRATCookie failed, expected value: %q, got: %q", tc.value, c.Value)
+			}
+			if c.Expiration != tc.exp {
+				t.Errorf("ParseSoTRATCookie failed, expected expiration: %d, got: %d", tc.exp, c.Expiration)
+			}
+		})
+	}
+}
+
+// itoa returns the given int64 as string
+func itoa(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
@@ -5,13 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/bwmarrin/discordgo"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 
-	"github.com/wneessen/arrgo/crypto"
 	"github.com/wneessen/arrgo/model"
 )
 
@@ -40,7 +36,7 @@ type SoTUserBalance struct {
 }
 
 // SlashCmdSoTOverview handles the /balance slash command
-func (b *Bot) SlashCmdSoTOverview(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTOverview(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	u, err := b.Model.User.GetByUserID(i.Member.User.ID)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve user from DB: %w", err)
@@ -57,37 +53,37 @@ func (b *Bot) SlashCmdSoTOverview(s *discordgo.Session, i *discordgo.Interaction
 		return err
 	}
 
-	p := message.NewPrinter(language.German)
+	p := b.display(i.Interaction)
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("%s Kraken", IconKraken),
-		Value:  fmt.Sprintf("**%s** defeated", p.Sprintf("%d", us.KrakenDefeated)),
+		Name:   p.Sprintf("%s Kraken", IconKraken),
+		Value:  p.Sprintf("**%s** defeated", p.Int(us.KrakenDefeated)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("%s Megalodon", IconMegalodon),
-		Value:  fmt.Sprintf("**%s** encounter(s)", p.Sprintf("%d", us.MegalodonEnounter)),
+		Name:   p.Sprintf("%s Megalodon", IconMegalodon),
+		Value:  p.Sprintf("**%s** encounter(s)", p.Int(us.MegalodonEnounter)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("%s Chests", IconChest),
-		Value:  fmt.Sprintf("**%s** handed in", p.Sprintf("%d", us.ChestsHandedIn)),
+		Name:   p.Sprintf("%s Chests", IconChest),
+		Value:  p.Sprintf("**%s** handed in", p.Int(us.ChestsHandedIn)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("%s Other Ships", IconShip),
-		Value:  fmt.Sprintf("**%s** sunk", p.Sprintf("%d", us.ShipsSunk)),
+		Name:   p.Sprintf("%s Other Ships", IconShip),
+		Value:  p.Sprintf("**%s** sunk", p.Int(us.ShipsSunk)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("%s Vomitted", IconVomit),
-		Value:  fmt.Sprintf("**%s** times", p.Sprintf("%d", us.VomittedTimes)),
+		Name:   p.Sprintf("%s Vomitted", IconVomit),
+		Value:  p.Sprintf("**%s** times", p.Int(us.VomittedTimes)),
 		Inline: true,
 	})
 	if us.DistanceSailed > 0 {
 		ef = append(ef, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s Distance", IconDistance),
-			Value:  fmt.Sprintf("**%s** nmi sailed", p.Sprintf("%d", us.DistanceSailed/1852)),
+			Name:   p.Sprintf("%s Distance", IconDistance),
+			Value:  p.Sprintf("**%s** sailed", p.Distance(us.DistanceSailed)),
 			Inline: true,
 		})
 	} else {
@@ -100,7 +96,7 @@ func (b *Bot) SlashCmdSoTOverview(s *discordgo.Session, i *discordgo.Interaction
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title:  "Your current user statistics overview in Sea of Thieves:",
+			Title:  p.Sprintf("Your current user statistics overview in Sea of Thieves:"),
 			Type:   discordgo.EmbedTypeRich,
 			Fields: ef,
 		},
@@ -113,7 +109,7 @@ func (b *Bot) SlashCmdSoTOverview(s *discordgo.Session, i *discordgo.Interaction
 }
 
 // SlashCmdSoTBalance handles the /balance slash command
-func (b *Bot) SlashCmdSoTBalance(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdSoTBalance(s DiscordAPI, i *discordgo.InteractionCreate) error {
 	r, err := b.NewRequester(i.Interaction)
 	if err != nil {
 		return err
@@ -126,30 +122,27 @@ func (b *Bot) SlashCmdSoTBalance(s *discordgo.Session, i *discordgo.InteractionC
 		return err
 	}
 
-	p := message.NewPrinter(language.German)
+	p := b.display(i.Interaction)
 	var ef []*discordgo.MessageEmbedField
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name: fmt.Sprintf("%s Gold", IconGold),
-		Value: fmt.Sprintf("%s **%s** Gold", changeIcon(ub.Gold),
-			p.Sprintf("%d", ub.Gold)),
+		Name:   p.Sprintf("%s Gold", IconGold),
+		Value:  p.Sprintf("%s **%s** Gold", changeIcon(ub.Gold), p.Int(ub.Gold)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name: fmt.Sprintf("%s Doubloons", IconDoubloon),
-		Value: fmt.Sprintf("%s **%s** Doubloons", changeIcon(ub.Doubloons),
-			p.Sprintf("%d", ub.Doubloons)),
+		Name:   p.Sprintf("%s Doubloons", IconDoubloon),
+		Value:  p.Sprintf("%s **%s** Doubloons", changeIcon(ub.Doubloons), p.Int(ub.Doubloons)),
 		Inline: true,
 	})
 	ef = append(ef, &discordgo.MessageEmbedField{
-		Name: fmt.Sprintf("%s Ancient Coins", IconAncientCoin),
-		Value: fmt.Sprintf("%s **%s** Ancient Coins", changeIcon(ub.AncientCoins),
-			p.Sprintf("%d", ub.AncientCoins)),
+		Name:   p.Sprintf("%s Ancient Coins", IconAncientCoin),
+		Value:  p.Sprintf("%s **%s** Ancient Coins", changeIcon(ub.AncientCoins), p.Int(ub.AncientCoins)),
 		Inline: true,
 	})
 
 	e := []*discordgo.MessageEmbed{
 		{
-			Title: "Your current balance in Sea of Thieves:",
+			Title: p.Sprintf("Your current balance in Sea of Thieves:"),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf("%s/season/gold-s.png", AssetsBaseURL),
 			},
@@ -158,7 +151,7 @@ func (b *Bot) SlashCmdSoTBalance(s *discordgo.Session, i *discordgo.InteractionC
 		},
 	}
 	if ub.Title != "" {
-		e[0].Description = fmt.Sprintf("**Current Title:** %s", ub.Title)
+		e[0].Description = p.Sprintf("**Current Title:** %s", ub.Title)
 	}
 
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
@@ -170,7 +163,7 @@ func (b *Bot) SlashCmdSoTBalance(s *discordgo.Session, i *discordgo.InteractionC
 // SoTGetUserBalance returns the parsed API response from the Sea of Thieves gold/coins balance API
 func (b *Bot) SoTGetUserBalance(rq *Requester) (SoTUserBalance, error) {
 	var ub SoTUserBalance
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return ub, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -199,7 +192,7 @@ func (b *Bot) SoTGetUserBalance(rq *Requester) (SoTUserBalance, error) {
 // SoTGetUserOverview returns the parsed API response from the Sea of Thieves gold/coins balance API
 func (b *Bot) SoTGetUserOverview(rq *Requester) (SoTUserStats, error) {
 	var us SoTUserOverview
-	hc, err := NewHTTPClient()
+	hc, err := b.sotClient()
 	if err != nil {
 		return SoTUserStats{}, fmt.Errorf(ErrFailedHTTPClient, err)
 	}
@@ -224,28 +217,17 @@ func (b *Bot) SoTGetUserOverview(rq *Requester) (SoTUserStats, error) {
 
 // ScheduledEventUpdateUserStats performs scheuled updates of the SoT user stats for each user
 func (b *Bot) ScheduledEventUpdateUserStats() error {
-	ll := b.Log.With().Str("context", "bot.ScheduledEventUpdateUserStats").Logger()
-	ul, err := b.Model.User.GetUsers()
-	if err != nil {
-		return fmt.Errorf("failed to retrieve user list from DB: %w", err)
-	}
-	for _, u := range ul {
+	_, err := b.RunUserJob("update user stats", b.clock.Now(), func(u *model.User) error {
 		r, err := NewRequesterFromUser(u, b.Model.User)
 		if err != nil {
-			ll.Error().Msgf("failed to create new requester: %s", err)
-			continue
+			return fmt.Errorf("failed to create new requester: %w", err)
 		}
 		if err := b.StoreSoTUserStats(r); err != nil {
-			ll.Error().Msgf("failed to store user stats in DB: %s", err)
-			continue
+			return fmt.Errorf("failed to store user stats in DB: %w", err)
 		}
-		rd, err := crypto.RandDuration(10, "s")
-		if err != nil {
-			rd = time.Second * 10
-		}
-		time.Sleep(rd)
-	}
-	return nil
+		return nil
+	})
+	return err
 }
 
 // StoreSoTUserStats will retrieve the latest user stats from the API and store them in the DB
@@ -254,8 +236,10 @@ func (b *Bot) StoreSoTUserStats(rq *Requester) error {
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrSOTUnauth):
-			b.Log.Warn().Msgf("failed to fetch user balance - RAT token is expired")
-			return nil
+			if err := b.quarantineRATCookie(rq.User); err != nil {
+				b.Log.Error().Msgf("failed to quarantine RAT cookie: %s", err)
+			}
+			return ErrRATCookieInvalid
 		default:
 			return fmt.Errorf("failed to fetch user balance for user %s: %w", rq.UserID, err)
 		}
@@ -277,8 +261,22 @@ func (b *Bot) StoreSoTUserStats(rq *Requester) error {
 		VomittedTimes:     int64(us.VomitedTotal),
 		DistanceSailed:    int64(us.MetresSailed),
 	}
+	pus, err := b.Model.UserStats.GetByUserID(rq.ID)
+	switch {
+	case errors.Is(err, model.ErrUserStatNotExistent):
+		pus = nil
+	case err != nil:
+		return fmt.Errorf("failed to read previous user stats for user %q from DB: %w", rq.UserID, err)
+	}
 	if err := b.Model.UserStats.Insert(dus); err != nil {
 		return fmt.Errorf("failed to store user stats for user %q in DB: %w", rq.UserID, err)
 	}
+	if err := b.evaluateGoals(rq.User, false); err != nil {
+		b.Log.Warn().Msgf("failed to evaluate goals of user %q: %s", rq.UserID, err)
+	}
+	ev := statsSubscriptionEvent(b.userDisplay(rq.User), dus, pus)
+	if err := b.notifySubscriptions(TopicStats, rq.User, []subscriptionEvent{ev}); err != nil {
+		b.Log.Warn().Msgf("failed to evaluate stats subscriptions of user %q: %s", rq.UserID, err)
+	}
 	return nil
 }
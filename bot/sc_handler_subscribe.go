@@ -0,0 +1,517 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/notify"
+)
+
+// SubscriptionMaxPerUser is the maximum number of subscriptions of a user
+const SubscriptionMaxPerUser = 15
+
+// List of subscription topics
+const (
+	TopicDeed       = "deed"
+	TopicTradeRoute = "traderoute"
+	TopicLedger     = "ledger"
+	TopicStats      = "stats"
+)
+
+// List of ledger band changes of the ledger subscription topic
+const (
+	LedgerChangeRise = "rise"
+	LedgerChangeDrop = "drop"
+	LedgerChangeNone = "none"
+)
+
+// SubscriptionTopic is a topic that users can subscribe to with the /subscribe command
+type SubscriptionTopic struct {
+	Name  string
+	Value string
+
+	// Fields holds the filter fields of the topic and whether they are numeric
+	Fields map[string]bool
+
+	// State topics describe the current state of a user. Their subscriptions only match when the filter
+	// starts to match, not on every update that it keeps matching
+	State bool
+}
+
+// SubscriptionTopics is the list of all subscription topics
+var SubscriptionTopics = []SubscriptionTopic{
+	{
+		Name: "New deeds", Value: TopicDeed,
+		Fields: map[string]bool{"type": false, "reward": false, "amount": true, "renown": false},
+	},
+	{
+		Name: "New trade routes", Value: TopicTradeRoute,
+		Fields: map[string]bool{"outpost": false, "sought": false, "surplus": false},
+	},
+	{
+		Name: "Emissary ledger", Value: TopicLedger, State: true,
+		Fields: map[string]bool{"faction": false, "band": false, "rank": true, "change": false},
+	},
+	{
+		Name: "Stats", Value: TopicStats, State: true,
+		Fields: statsSubscriptionFields(),
+	},
+}
+
+// List of /subscribe specific errors
+var (
+	ErrSubscriptionTopic    = errors.New("unknown subscription topic")
+	ErrSubscriptionEmpty    = errors.New("please provide a filter for this topic, e.g. gold>=1000000")
+	ErrSubscriptionLimit    = errors.New("you have reached the maximum number of subscriptions. Please remove one first")
+	ErrSubscriptionNotFound = errors.New("there is no subscription with this ID. Use **/subscribe list** to see " +
+		"the IDs of your subscriptions")
+)
+
+// subscriptionEvent is an update that is matched against the subscriptions of a topic
+type subscriptionEvent struct {
+	// key deduplicates the notifications of the event per user
+	key string
+
+	// values holds the filter field values of the event, prev the values of the previous update of
+	// state topics (nil if there was none)
+	values map[string]interface{}
+	prev   map[string]interface{}
+
+	// embed returns the description of the event for the notification
+	embed func(p *Display) *discordgo.MessageEmbed
+}
+
+// SlashCmdSubscribe handles the /subscribe slash command
+func (b *Bot) SlashCmdSubscribe(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	ol := i.ApplicationCommandData().Options
+	if len(ol) <= 0 {
+		return fmt.Errorf("no sub-command provided")
+	}
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	p := b.display(i.Interaction)
+
+	var e []*discordgo.MessageEmbed
+	switch ol[0].Name {
+	case "add":
+		us, err := b.addSubscription(r.User, ol[0].Options)
+		if err != nil {
+			return err
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:  discordgo.EmbedTypeRich,
+				Title: p.Sprintf("Subscription added"),
+				Description: p.Sprintf("You will receive a notification whenever an update matches your " +
+					"subscription."),
+				Fields: []*discordgo.MessageEmbedField{subscriptionField(p, us)},
+			},
+		}
+	case "list":
+		sl, err := b.Model.UserSubscription.GetByUserID(r.User.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read user subscriptions from DB: %w", err)
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:  discordgo.EmbedTypeRich,
+				Title: p.Sprintf("Your subscriptions"),
+			},
+		}
+		if len(sl) <= 0 {
+			e[0].Description = p.Sprintf("You have not subscribed to any topics yet. Use **/subscribe add** " +
+				"to subscribe to one.")
+		}
+		for _, us := range sl {
+			e[0].Fields = append(e[0].Fields, subscriptionField(p, us))
+		}
+	case "remove":
+		var si int64
+		for _, o := range ol[0].Options {
+			if o.Name == "id" {
+				si = o.IntValue()
+			}
+		}
+		if err := b.Model.UserSubscription.Delete(r.User.ID, si); err != nil {
+			if errors.Is(err, model.ErrUserSubscriptionNotExistent) {
+				return ErrSubscriptionNotFound
+			}
+			return fmt.Errorf("failed to remove user subscription from DB: %w", err)
+		}
+		e = []*discordgo.MessageEmbed{
+			{
+				Type:        discordgo.EmbedTypeArticle,
+				Title:       p.Sprintf("Subscription removed"),
+				Description: p.Sprintf("Your subscription #%d has been removed.", si),
+			},
+		}
+	default:
+		return fmt.Errorf("unknown sub-command: %s", ol[0].Name)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /subscribe request: %w", err)
+	}
+	return nil
+}
+
+// addSubscription stores a new subscription of the given user based on the options of /subscribe add
+func (b *Bot) addSubscription(u *model.User, ol []*discordgo.ApplicationCommandInteractionDataOption,
+) (*model.UserSubscription, error) {
+	us := &model.UserSubscription{UserID: u.ID}
+	for _, o := range ol {
+		switch o.Name {
+		case "topic":
+			us.Topic = o.StringValue()
+		case "filter":
+			us.Filter = o.StringValue()
+		}
+	}
+	t, ok := subscriptionTopic(us.Topic)
+	if !ok {
+		return nil, ErrSubscriptionTopic
+	}
+	f, err := ParseSubscriptionFilter(us.Filter, t.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if t.State && len(f) <= 0 {
+		return nil, ErrSubscriptionEmpty
+	}
+	us.Filter = f.String()
+
+	sl, err := b.Model.UserSubscription.GetByUserID(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user subscriptions from DB: %w", err)
+	}
+	if len(sl) >= SubscriptionMaxPerUser {
+		return nil, ErrSubscriptionLimit
+	}
+	if err := b.Model.UserSubscription.Insert(us); err != nil {
+		return nil, fmt.Errorf("failed to store user subscription in DB: %w", err)
+	}
+	return us, nil
+}
+
+// notifySubscriptions matches the given events against the subscriptions of the given topic and notifies
+// the users of the matching subscriptions. If a user is given, only the subscriptions of this user are
+// matched, otherwise the subscriptions of all users. Each user receives a single notification per event,
+// even if multiple subscriptions match
+func (b *Bot) notifySubscriptions(tv string, u *model.User, el []subscriptionEvent) error {
+	if len(el) <= 0 {
+		return nil
+	}
+	t, ok := subscriptionTopic(tv)
+	if !ok {
+		return ErrSubscriptionTopic
+	}
+	var sl []*model.UserSubscription
+	var err error
+	if u != nil {
+		sl, err = b.Model.UserSubscription.GetByUserIDAndTopic(u.ID, t.Value)
+	} else {
+		sl, err = b.Model.UserSubscription.GetByTopic(t.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s subscriptions from DB: %w", t.Value, err)
+	}
+
+	ll := b.Log.With().Str("context", "bot.notifySubscriptions").Str("topic", t.Value).Logger()
+	um := make(map[int64][]*model.UserSubscription)
+	var ul []int64
+	for _, us := range sl {
+		if _, ok := um[us.UserID]; !ok {
+			ul = append(ul, us.UserID)
+		}
+		um[us.UserID] = append(um[us.UserID], us)
+	}
+	for _, ui := range ul {
+		su := u
+		if su == nil {
+			su, err = b.Model.User.GetByID(ui)
+			if err != nil {
+				ll.Warn().Msgf("failed to look up user %d of subscriptions: %s", ui, err)
+				continue
+			}
+		}
+		for _, ev := range el {
+			ml := matchSubscriptions(t, um[ui], ev)
+			if len(ml) <= 0 {
+				continue
+			}
+			p := b.userDisplay(su)
+			e := ev.embed(p)
+			fl := make([]string, len(ml))
+			for n, us := range ml {
+				fl[n] = subscriptionName(p, us)
+			}
+			e.Fields = append(e.Fields, &discordgo.MessageEmbedField{
+				Name:  p.Sprintf("Matching subscriptions"),
+				Value: strings.Join(fl, "\n"),
+			})
+			no := &notify.Notification{
+				Type:     notify.TypeSubscriptionMatch,
+				User:     su,
+				Embed:    e,
+				DedupKey: fmt.Sprintf("subscription-%s-%s", t.Value, ev.key),
+			}
+			if _, err := b.notifier().Notify(no); err != nil {
+				ll.Error().Msgf("failed to send subscription notification to user %d: %s", ui, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchSubscriptions returns the subscriptions of the given list that match the given event
+func matchSubscriptions(t SubscriptionTopic, sl []*model.UserSubscription, ev subscriptionEvent,
+) []*model.UserSubscription {
+	var ml []*model.UserSubscription
+	for _, us := range sl {
+		f, err := ParseSubscriptionFilter(us.Filter, t.Fields)
+		if err != nil || !f.Match(ev.values) {
+			continue
+		}
+		if t.State && ev.prev != nil && f.Match(ev.prev) {
+			continue
+		}
+		ml = append(ml, us)
+	}
+	return ml
+}
+
+// deedSubscriptionEvents returns the subscription events of the given newly published deeds
+func deedSubscriptionEvents(dl []*model.Deed) []subscriptionEvent {
+	var el []subscriptionEvent
+	for _, d := range dl {
+		d := d
+		var dt, rn string
+		switch d.DeedType {
+		case model.DeedTypeStandard:
+			dt = "standard"
+		case model.DeedTypeDailyStandard:
+			dt = "daily"
+		case model.DeedTypeDailySwift:
+			dt = "swift"
+		default:
+			continue
+		}
+		switch d.RewardIcon {
+		case "s":
+			rn = "small"
+		case "m":
+			rn = "medium"
+		}
+		el = append(el, subscriptionEvent{
+			key: fmt.Sprintf("%d", d.ID),
+			values: map[string]interface{}{
+				"type": dt, "reward": string(d.RewardType), "amount": float64(d.RewardAmount), "renown": rn,
+			},
+			embed: func(p *Display) *discordgo.MessageEmbed {
+				e := deedEmbed(p, d)
+				e.Title = p.Sprintf("New deed: %s", e.Title)
+				return e
+			},
+		})
+	}
+	return el
+}
+
+// tradeRouteSubscriptionEvents returns the subscription events of the given new or changed trade routes
+func tradeRouteSubscriptionEvents(tl []*model.TradeRoute) []subscriptionEvent {
+	var el []subscriptionEvent
+	for _, tr := range tl {
+		tr := tr
+		el = append(el, subscriptionEvent{
+			key: fmt.Sprintf("%d-%d", tr.ID, tr.Version),
+			values: map[string]interface{}{
+				"outpost": tr.Outpost, "sought": tr.SoughtAfter, "surplus": tr.Surplus,
+			},
+			embed: func(p *Display) *discordgo.MessageEmbed {
+				return tradeRoutesEmbed(p, p.Sprintf("Trade route: %s", tr.Outpost), []*model.TradeRoute{tr})
+			},
+		})
+	}
+	return el
+}
+
+// ledgerSubscriptionEvent returns the subscription event of the given ledger position of a user with the
+// given emissary name and band title. The previous position is nil if the ledger was not stored before
+func ledgerSubscriptionEvent(fn string, ul *model.UserLedger, bt string, pl *model.UserLedger, pbt string,
+) subscriptionEvent {
+	ev := subscriptionEvent{
+		key: fmt.Sprintf("%d", ul.ID),
+		values: map[string]interface{}{
+			"faction": fn, "band": bt, "rank": float64(ul.Rank), "change": LedgerChangeNone,
+		},
+		embed: func(p *Display) *discordgo.MessageEmbed {
+			return &discordgo.MessageEmbed{
+				Type:  discordgo.EmbedTypeArticle,
+				Title: p.Sprintf("%s ledger update", fn),
+				Description: p.Sprintf("**Band:** %s\n**Rank:** %s\n**Score:** %s", bt, p.Int(ul.Rank),
+					p.Int(ul.Score)),
+			}
+		},
+	}
+	if pl != nil {
+		switch {
+		case ul.Band > pl.Band:
+			ev.values["change"] = LedgerChangeDrop
+		case ul.Band < pl.Band:
+			ev.values["change"] = LedgerChangeRise
+		}
+		ev.prev = map[string]interface{}{
+			"faction": fn, "band": pbt, "rank": float64(pl.Rank), "change": LedgerChangeNone,
+		}
+	}
+	return ev
+}
+
+// statsSubscriptionEvent returns the subscription event of the given user stats. The previous stats are
+// nil if there were none stored before. Distances are compared in the distance unit of the given Display
+func statsSubscriptionEvent(dp *Display, us, pus *model.UserStat) subscriptionEvent {
+	ev := subscriptionEvent{
+		key:    fmt.Sprintf("%d", us.ID),
+		values: statsSubscriptionValues(dp, us),
+		embed: func(p *Display) *discordgo.MessageEmbed {
+			var ef []*discordgo.MessageEmbedField
+			for _, gm := range GraphMetrics {
+				if gm.stat == nil {
+					continue
+				}
+				v := p.Int(gm.stat(us))
+				if gm.Value == "distance" {
+					v = p.Distance(gm.stat(us))
+				}
+				ef = append(ef, &discordgo.MessageEmbedField{Name: p.Sprintf(gm.Name), Value: v, Inline: true})
+			}
+			return &discordgo.MessageEmbed{
+				Type:   discordgo.EmbedTypeRich,
+				Title:  p.Sprintf("Your Sea of Thieves stats"),
+				Fields: ef,
+			}
+		},
+	}
+	if pus != nil {
+		ev.prev = statsSubscriptionValues(dp, pus)
+	}
+	return ev
+}
+
+// statsSubscriptionValues returns the filter field values of the given user stats. Distances are
+// converted to the distance unit of the user
+func statsSubscriptionValues(p *Display, us *model.UserStat) map[string]interface{} {
+	vm := make(map[string]interface{})
+	for _, gm := range GraphMetrics {
+		if gm.stat == nil {
+			continue
+		}
+		v := float64(gm.stat(us))
+		if gm.Value == "distance" {
+			v /= metresPerUnit[p.DistanceUnit]
+		}
+		vm[gm.Value] = v
+	}
+	return vm
+}
+
+// statsSubscriptionFields returns the filter fields of the stats topic
+func statsSubscriptionFields() map[string]bool {
+	fm := make(map[string]bool)
+	for _, gm := range GraphMetrics {
+		if gm.stat != nil {
+			fm[gm.Value] = true
+		}
+	}
+	return fm
+}
+
+// subscriptionTopic returns the SubscriptionTopic of the given topic value
+func subscriptionTopic(v string) (SubscriptionTopic, bool) {
+	for _, t := range SubscriptionTopics {
+		if t.Value == v {
+			return t, true
+		}
+	}
+	return SubscriptionTopic{}, false
+}
+
+// subscriptionName returns the human-readable description of the given subscription
+func subscriptionName(p *Display, us *model.UserSubscription) string {
+	t, _ := subscriptionTopic(us.Topic)
+	if us.Filter == "" {
+		return p.Sprintf("#%d %s", us.ID, p.Sprintf(t.Name))
+	}
+	return p.Sprintf("#%d %s: %s", us.ID, p.Sprintf(t.Name), us.Filter)
+}
+
+// subscriptionField returns the embed field of the given subscription
+func subscriptionField(p *Display, us *model.UserSubscription) *discordgo.MessageEmbedField {
+	t, _ := subscriptionTopic(us.Topic)
+	f := us.Filter
+	if f == "" {
+		f = p.Sprintf("Everything")
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  p.Sprintf("#%d %s", us.ID, p.Sprintf(t.Name)),
+		Value: p.Sprintf("`%s`\nSubscribed: %s", f, p.Time(us.CreateTime)),
+	}
+}
+
+// subscribeCommandOptions returns the sub-commands of the /subscribe slash command
+func subscribeCommandOptions() []*discordgo.ApplicationCommandOption {
+	var tc []*discordgo.ApplicationCommandOptionChoice
+	for _, t := range SubscriptionTopics {
+		tc = append(tc, &discordgo.ApplicationCommandOptionChoice{Name: t.Name, Value: t.Value})
+	}
+	imin := 1.0
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Subscribe to a topic",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "topic",
+					Description: "The topic to subscribe to",
+					Required:    true,
+					Choices:     tc,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "filter",
+					Description: "Only notify if all conditions match, e.g. type=swift reward=doubloons",
+					Required:    false,
+					MaxLength:   SubscriptionFilterMaxLen,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show your subscriptions",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove one of your subscriptions",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionInteger,
+					Name:         "id",
+					Description:  "The ID of the subscription as shown by /subscribe list",
+					Required:     true,
+					MinValue:     &imin,
+					Autocomplete: true,
+				},
+			},
+		},
+	}
+}
@@ -1,19 +1,17 @@
 package bot
 
 import (
-	"fmt"
-	"time"
-
 	"github.com/bwmarrin/discordgo"
 )
 
 // SlashCmdTime handles the /time slash command
-func (b *Bot) SlashCmdTime(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdTime(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	p := b.display(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:        discordgo.EmbedTypeArticle,
-			Title:       "It's time, Matey!",
-			Description: fmt.Sprintf("The current bot time is: <t:%d>", time.Now().Unix()),
+			Title:       p.Sprintf("It's time, Matey!"),
+			Description: p.Sprintf("The current bot time is: %s", p.Time(b.clock.Now())),
 		},
 	}
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
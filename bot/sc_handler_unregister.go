@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// List of component custom IDs used by the /unregister command
+const (
+	ComponentUnregisterConfirm = "unregister_confirm"
+	ComponentUnregisterCancel  = "unregister_cancel"
+)
+
+// SlashCmdUnregister handles the /unregister slash command. The user is not removed right away, but
+// has to confirm the deletion via button first
+func (b *Bot) SlashCmdUnregister(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	u, err := b.interactionUser(s, i)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return nil
+	}
+
+	p := b.printer(i.Interaction)
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:  discordgo.EmbedTypeArticle,
+			Title: p.Sprintf("Are you sure?"),
+			Description: p.Sprintf("This will remove your user, your preferences, your Sea of Thieves " +
+				"authentication cookie and your complete stats, reputation and ledger history from ArrGo. This " +
+				"cannot be undone. If you want to keep a copy of your data, use **/mydata export** first."),
+		},
+	}
+	c := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Delete my data"),
+					Style:    discordgo.DangerButton,
+					CustomID: ComponentUnregisterConfirm,
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Cancel"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: ComponentUnregisterCancel,
+				},
+			},
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e,
+		Components: &c}); err != nil {
+		return fmt.Errorf("failed to edit /unregister request: %w", err)
+	}
+	return nil
+}
+
+// ComponentUnregister handles the confirm and cancel buttons of the /unregister command
+func (b *Bot) ComponentUnregister(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	// The language has to be determined before the user and its language override are removed
+	p := b.printer(i.Interaction)
+	e := &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeArticle,
+		Title:       p.Sprintf("Unregistration cancelled"),
+		Description: p.Sprintf("Nothing has been removed. Glad you're staying aboard!"),
+	}
+
+	if i.MessageComponentData().CustomID == ComponentUnregisterConfirm {
+		var us string
+		if i.Interaction.User != nil {
+			us = i.Interaction.User.ID
+		}
+		if i.Member != nil && i.Member.User != nil {
+			us = i.Member.User.ID
+		}
+		u, err := b.Model.User.GetByUserID(us)
+		if err != nil && !errors.Is(err, model.ErrUserNotExistent) {
+			return fmt.Errorf("failed to look up user: %w", err)
+		}
+		if err == nil {
+			if err := b.Model.User.Delete(u); err != nil {
+				return fmt.Errorf("failed to delete user: %w", err)
+			}
+		}
+		e = &discordgo.MessageEmbed{
+			Type:  discordgo.EmbedTypeArticle,
+			Title: p.Sprintf("Farewell!"),
+			Description: p.Sprintf("Your user and all data stored about you have been removed from ArrGo. " +
+				"You can **/register** again at any time."),
+		}
+	}
+
+	r := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{e},
+			Components: []discordgo.MessageComponent{},
+		},
+	}
+	if err := s.InteractionRespond(i.Interaction, r); err != nil {
+		return fmt.Errorf("failed to respond to /unregister confirmation: %w", err)
+	}
+	return nil
+}
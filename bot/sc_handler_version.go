@@ -1,18 +1,17 @@
 package bot
 
 import (
-	"fmt"
-
 	"github.com/bwmarrin/discordgo"
 )
 
 // SlashCmdVersion handles the /version slash command
-func (b *Bot) SlashCmdVersion(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) SlashCmdVersion(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	p := b.printer(i.Interaction)
 	e := []*discordgo.MessageEmbed{
 		{
 			Type:  discordgo.EmbedTypeArticle,
-			Title: "Oh look! It's me!",
-			Description: fmt.Sprintf("I am ArrBot (Version v%s)! Your Sea of Thieves themed discord bot. "+
+			Title: p.Sprintf("Oh look! It's me!"),
+			Description: p.Sprintf("I am ArrBot (Version v%s)! Your Sea of Thieves themed discord bot. "+
 				"Nice to meet you!", Version),
 		},
 	}
@@ -0,0 +1,276 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// UserCmdVersus is the name of the user context-menu command of /versus
+const UserCmdVersus = "Compare SoT stats"
+
+// List of /versus specific errors
+var (
+	ErrVersusSelf    = errors.New("please choose another registered guild member to compare with")
+	ErrVersusNoStats = errors.New("there are no stored Sea of Thieves stats for one of the pirates yet")
+	ErrStatsPrivate  = errors.New("this pirate keeps their Sea of Thieves stats private")
+)
+
+// versusStat is a stat that is lined up by /versus
+type versusStat struct {
+	name string
+	icon string
+	stat func(*model.UserStat) int64
+}
+
+// versusStats is the list of stats that are lined up by /versus
+var versusStats = []versusStat{
+	{name: "Gold", icon: IconGold, stat: func(s *model.UserStat) int64 { return s.Gold }},
+	{name: "Doubloons", icon: IconDoubloon, stat: func(s *model.UserStat) int64 { return s.Doubloons }},
+	{name: "Ancient Coins", icon: IconAncientCoin, stat: func(s *model.UserStat) int64 { return s.AncientCoins }},
+	{name: "Kraken", icon: IconKraken, stat: func(s *model.UserStat) int64 { return s.KrakenDefeated }},
+	{name: "Megalodon", icon: IconMegalodon, stat: func(s *model.UserStat) int64 { return s.MegalodonEnounter }},
+	{name: "Chests", icon: IconChest, stat: func(s *model.UserStat) int64 { return s.ChestsHandedIn }},
+	{name: "Other Ships", icon: IconShip, stat: func(s *model.UserStat) int64 { return s.ShipsSunk }},
+	{name: "Vomitted", icon: IconVomit, stat: func(s *model.UserStat) int64 { return s.VomittedTimes }},
+	{name: "Distance", icon: IconDistance, stat: func(s *model.UserStat) int64 { return s.DistanceSailed }},
+}
+
+// versusPirate holds the stats of one of the users lined up by /versus
+type versusPirate struct {
+	name string
+	cur  *model.UserStat
+	old  *model.UserStat
+	rep  map[string]int64
+}
+
+// SlashCmdVersus handles the /versus slash command
+func (b *Bot) SlashCmdVersus(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	cd := i.ApplicationCommandData()
+	var du *discordgo.User
+	pe := ComparePeriods[0].Value
+	for _, o := range cd.Options {
+		switch o.Name {
+		case "member":
+			if cd.Resolved != nil {
+				du = cd.Resolved.Users[o.UserValue(nil).ID]
+			}
+		case "period":
+			pe = o.StringValue()
+		}
+	}
+	if du == nil {
+		return ErrVersusSelf
+	}
+	var dm *discordgo.Member
+	if cd.Resolved != nil {
+		dm = cd.Resolved.Members[du.ID]
+	}
+	return b.versus(s, i, du, dm, pe)
+}
+
+// UserCmdCompareStats handles the "Compare SoT stats" user context-menu command
+func (b *Bot) UserCmdCompareStats(s DiscordAPI, i *discordgo.InteractionCreate) error {
+	cd := i.ApplicationCommandData()
+	if cd.Resolved == nil || cd.Resolved.Users[cd.TargetID] == nil {
+		return ErrVersusSelf
+	}
+	return b.versus(s, i, cd.Resolved.Users[cd.TargetID], cd.Resolved.Members[cd.TargetID],
+		ComparePeriods[0].Value)
+}
+
+// versus lines up the stats of the requesting user and the given guild member
+func (b *Bot) versus(s DiscordAPI, i *discordgo.InteractionCreate, du *discordgo.User, dm *discordgo.Member,
+	pe string,
+) error {
+	r, err := b.NewRequester(i.Interaction)
+	if err != nil {
+		return err
+	}
+	if du.ID == r.User.UserID || du.Bot {
+		return ErrVersusSelf
+	}
+	tu, err := b.Model.User.GetByUserID(du.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotExistent) {
+			return fmt.Errorf("%s is not registered with ArrGo", du.Username)
+		}
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+	if err := b.checkStatsPrivacy(tu); err != nil {
+		return err
+	}
+
+	p := b.display(i.Interaction)
+	wl := pe
+	for _, cp := range ComparePeriods {
+		if cp.Value == pe {
+			wl = p.Sprintf(cp.Name)
+		}
+	}
+	w, err := ParseWindow(pe, b.clock.Now(), p.tz)
+	if err != nil {
+		return err
+	}
+
+	rn := r.User.UserID
+	if i.Member != nil {
+		rn = memberName(i.Member, i.Member.User)
+	}
+	if i.User != nil {
+		rn = i.User.Username
+	}
+	var pl []versusPirate
+	for _, vu := range []struct {
+		name string
+		user *model.User
+	}{{name: rn, user: r.User}, {name: memberName(dm, du), user: tu}} {
+		vp, err := b.versusPirate(vu.user, vu.name, w)
+		if err != nil {
+			return err
+		}
+		pl = append(pl, vp)
+	}
+
+	var ef []*discordgo.MessageEmbedField
+	for _, vs := range versusStats {
+		var v string
+		for _, vp := range pl {
+			cv := vs.stat(vp.cur)
+			fv := p.Int(cv)
+			if vs.name == "Distance" {
+				fv = p.Distance(cv)
+			}
+			v += p.Sprintf("**%s**: %s", vp.name, fv)
+			if vp.old != nil && cv != vs.stat(vp.old) {
+				gv := cv - vs.stat(vp.old)
+				gf := p.Int(gv)
+				if vs.name == "Distance" {
+					gf = p.Distance(gv)
+				}
+				v += fmt.Sprintf(" (%s%s)", changeIcon(gv), gf)
+			}
+			v += "\n"
+		}
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s %s", vs.icon, p.Sprintf(vs.name)),
+			Value:  v,
+			Inline: true,
+		})
+	}
+
+	el := make(map[string]bool)
+	for _, vp := range pl {
+		for e := range vp.rep {
+			el[e] = true
+		}
+	}
+	var es []string
+	for e := range el {
+		if dbEmissaryToName(e) != "" {
+			es = append(es, e)
+		}
+	}
+	sort.Strings(es)
+	for _, e := range es {
+		var v string
+		for _, vp := range pl {
+			lv := "-"
+			if l, ok := vp.rep[e]; ok {
+				lv = p.Int(l)
+			}
+			v += p.Sprintf("**%s**: level %s", vp.name, lv) + "\n"
+		}
+		ef = append(ef, &discordgo.MessageEmbedField{
+			Name:   dbEmissaryToName(e),
+			Value:  v,
+			Inline: true,
+		})
+	}
+
+	e := []*discordgo.MessageEmbed{
+		{
+			Type:   discordgo.EmbedTypeRich,
+			Title:  p.Sprintf("%s vs. %s in Sea of Thieves", pl[0].name, pl[1].name),
+			Fields: ef,
+			Description: p.Sprintf("Current stats as of %s and %s. Gains: %s", p.Time(pl[0].cur.CreateTime),
+				p.Time(pl[1].cur.CreateTime), wl),
+		},
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e}); err != nil {
+		return fmt.Errorf("failed to edit /versus request: %w", err)
+	}
+	return nil
+}
+
+// versusPirate reads the latest stored stats, the stats at the start of the given window and the latest
+// reputation levels of the given user from the database
+func (b *Bot) versusPirate(u *model.User, n string, w Window) (versusPirate, error) {
+	vp := versusPirate{name: n, rep: make(map[string]int64)}
+	cus, err := b.Model.UserStats.GetByUserIDBeforeTime(u.ID, w.To)
+	if err != nil {
+		if errors.Is(err, model.ErrUserStatNotExistent) {
+			return vp, ErrVersusNoStats
+		}
+		return vp, fmt.Errorf("failed to read user stats from DB: %w", err)
+	}
+	vp.cur = cus
+	ous, err := b.Model.UserStats.GetByUserIDAtTime(u.ID, w.From)
+	if err != nil && !errors.Is(err, model.ErrUserStatNotExistent) {
+		return vp, fmt.Errorf("failed to read user stats from DB: %w", err)
+	}
+	if err == nil && ous.CreateTime.Before(cus.CreateTime) {
+		vp.old = ous
+	}
+	rl, err := b.Model.UserReputation.GetLatestByUserID(u.ID)
+	if err != nil {
+		return vp, fmt.Errorf("failed to read user reputation from DB: %w", err)
+	}
+	for _, ur := range rl {
+		vp.rep[ur.Emissary] = ur.Level
+	}
+	return vp, nil
+}
+
+// checkStatsPrivacy returns ErrStatsPrivate if the given user does not share their stats with other
+// guild members
+func (b *Bot) checkStatsPrivacy(u *model.User) error {
+	pr, err := b.Model.User.GetPrefBool(u, model.UserPrefStatsPrivate)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return fmt.Errorf("failed to read privacy preference from DB: %w", err)
+	}
+	if pr {
+		return ErrStatsPrivate
+	}
+	return nil
+}
+
+// versusCommandOptions returns the options of the /versus slash command
+func versusCommandOptions() []*discordgo.ApplicationCommandOption {
+	var pc []*discordgo.ApplicationCommandOptionChoice
+	for _, cp := range ComparePeriods {
+		if cp.Value == PeriodLastSession || cp.Value == PeriodThisSeason {
+			continue
+		}
+		pc = append(pc, &discordgo.ApplicationCommandOptionChoice{Name: cp.Name, Value: cp.Value})
+	}
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "member",
+			Description: "The registered guild member to compare with",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "period",
+			Description: "The period of the compared gains (default: last 24 hours)",
+			Required:    false,
+			Choices:     pc,
+		},
+	}
+}
@@ -6,96 +6,92 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/wneessen/arrgo/crypto"
 	"github.com/wneessen/arrgo/model"
 )
 
 // ScheduledEventCheckRATCookies performs scheuled checks if the provided RAT cookies are still valid
 func (b *Bot) ScheduledEventCheckRATCookies() error {
-	ll := b.Log.With().Str("context", "bot.ScheduledEventCheckRATCookies").Logger()
-	ul, err := b.Model.User.GetUsers()
-	if err != nil {
-		return fmt.Errorf("failed to retrieve user list from DB: %w", err)
+	// Cookies that expired since the last check are included, so their owners still get notified
+	t := b.clock.Now().Add(-b.Config.Timer.RCCheck)
+	_, err := b.RunUserJob("check RAT cookies", t, b.checkRATCookie)
+	return err
+}
+
+// checkRATCookie checks if the RAT cookie of the given user is about to expire and sends the reminder
+// of the most urgent due stage. If no reminder is due, the cookie is tested against the SoT API
+func (b *Bot) checkRATCookie(u *model.User) error {
+	if u.RATExpire == nil {
+		return ErrJobSkipped
 	}
+	sl := b.ratReminderStages(u)
+	tl := u.RATExpire.Sub(b.clock.Now())
 
-	for _, u := range ul {
-		ie := false
-		te, err := b.Model.User.GetPrefInt64Enc(u, model.UserPrefSoTAuthTokenExpiration)
-		if err != nil {
-			if !errors.Is(err, model.ErrUserPrefNotExistent) {
-				ll.Error().Msgf("failed to retrieve RAT cookie expiration from DB: %s", err)
-				continue
-			}
-			ll.Debug().Msgf("User has no RAT token configured... skipping")
-			continue
+	// Stages are in descending order, so the last stage that has been reached is the most urgent one.
+	// An expired cookie is always treated as stage 0
+	ds := time.Duration(-1)
+	for _, d := range sl {
+		if tl <= d {
+			ds = d
 		}
+	}
+	if tl <= 0 {
+		ds = 0
+	}
 
-		// Token is expired/will expire in 6h
-		ad, err := time.ParseDuration("-6h")
+	// In some cases the token might be expired on the server end... let's test with a HTTP request
+	if ds < 0 {
+		rq := &Requester{nil, b.Model.User, u}
+		hc, err := b.sotClient()
+		if err != nil {
+			return fmt.Errorf(ErrFailedHTTPClient, err)
+		}
+		c, err := rq.GetSoTRATCookie()
 		if err != nil {
 			return err
 		}
-		if time.Now().Unix() > time.Unix(te, 0).Add(ad).Unix() {
-			ie = true
+		r, err := hc.HTTPReq(APIURLSoTUserOverview, ReqMethodGet, nil)
+		if err != nil {
+			return err
 		}
-
-		// In some cases the token might be expired on the server end... let's test with a HTTP request
-		if !ie {
-			rq := &Requester{nil, b.Model.User, u}
-			hc, err := NewHTTPClient()
-			if err != nil {
-				ll.Error().Msgf(ErrFailedHTTPClient, err)
-				continue
-			}
-			c, err := rq.GetSoTRATCookie()
-			if err != nil {
-				ll.Error().Err(err)
-				continue
-			}
-			r, err := hc.HTTPReq(APIURLSoTUserOverview, ReqMethodGet, nil)
-			if err != nil {
-				ll.Error().Err(err)
-				continue
-			}
-			r.SetSOTRequest(c)
-			_, ho, err := hc.Fetch(r)
-			if err != nil {
-				ll.Error().Err(err)
-				continue
-			}
-			if ho.StatusCode == http.StatusUnauthorized {
-				ie = true
-			}
+		r.SetSOTRequest(c)
+		_, ho, err := hc.Fetch(r)
+		if err != nil {
+			return err
+		}
+		if ho.StatusCode == http.StatusUnauthorized {
+			return b.quarantineRATCookie(u)
 		}
+		return nil
+	}
 
-		if ie {
-			na, err := b.Model.User.GetPrefBool(u, model.UserPrefSoTAuthTokenNotified)
-			if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
-				ll.Error().Msgf("failed to retrieve RAT cookie already notified status from DB: %s", err)
-				continue
-			}
-			if !na {
-				st, err := b.Session.UserChannelCreate(u.UserID)
-				if err != nil {
-					ll.Error().Msgf("failed to create DM channel with user: %s", err)
-					continue
-				}
-				_, err = b.Session.ChannelMessageSend(st.ID, "Your SoT RAT cookie either will expire in "+
-					"6 hours or has already expired. Please use the `/setrat` command to set a new one.")
-				if err != nil {
-					ll.Error().Msgf("failed to send DM: %s", err)
-					continue
-				}
-				if err := b.Model.User.SetPref(u, model.UserPrefSoTAuthTokenNotified, true); err != nil {
-					ll.Error().Msgf("failed to set 'user notified' user pref in DB: %s", err)
-				}
-			}
+	// If the reminder state can't be read, the user is skipped rather than reminded again
+	na, err := b.Model.User.GetPrefBool(u, model.UserPrefRATReminderNotified(ds))
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return fmt.Errorf("failed to read RAT cookie reminder state from DB: %w", err)
+	}
+	if na {
+		return fmt.Errorf("reminder for stage %s already sent: %w", ds, ErrJobSkipped)
+	}
+	p := b.userPrinter(u)
+	m := p.Sprintf("Your SoT RAT cookie expires %s. Please use the `/setrat` command to set a "+
+		"new one.", discordTimestamp(u.RATExpire.Unix(), "R"))
+	if ds == 0 {
+		m = p.Sprintf("Your SoT RAT cookie has expired %s. Please use the `/setrat` command to "+
+			"set a new one.", discordTimestamp(u.RATExpire.Unix(), "R"))
+	}
+	if err := b.sendRATReminder(p, u, m, fmt.Sprintf("expiry-%d-%s", u.RATExpire.Unix(), ds)); err != nil {
+		return err
+	}
+
+	// Earlier stages that have been missed (e.g. because of downtime) are marked as sent as well, so
+	// that the user does not receive outdated reminders
+	for _, d := range append(sl, 0) {
+		if d < ds {
+			continue
 		}
-		rd, err := crypto.RandDuration(10, "s")
-		if err != nil {
-			rd = time.Second * 10
+		if err := b.Model.User.SetPref(u, model.UserPrefRATReminderNotified(d), true); err != nil {
+			return fmt.Errorf("failed to store RAT cookie reminder state in DB: %w", err)
 		}
-		time.Sleep(rd)
 	}
 	return nil
 }
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+func TestBot_checkRATCookie(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		name     string
+		expire   time.Duration
+		notified bool
+		message  string
+	}{
+		{"stage 6h is due", time.Hour * 5, false, "Your SoT RAT cookie expires <t:"},
+		{"cookie expired", -time.Hour, false, "Your SoT RAT cookie has expired <t:"},
+		{"stage 6h already sent", time.Hour * 5, true, ""},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, s, db := newTestBot(t, now)
+			re := now.Add(tc.expire)
+			u := &model.User{ID: 7, UserID: "200", RATExpire: &re}
+			if tc.notified {
+				db.pref(t, "user_prefs", model.UserPrefRATReminderNotified(time.Hour*6), true)
+			}
+
+			err := b.checkRATCookie(u)
+			dl := s.DirectMessages("200")
+			if tc.message == "" {
+				if !errors.Is(err, ErrJobSkipped) {
+					t.Errorf("checkRATCookie failed, expected error: %s, got: %v", ErrJobSkipped, err)
+				}
+				if len(dl) != 0 {
+					t.Errorf("checkRATCookie failed, expected no DM, got: %d", len(dl))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkRATCookie failed: %s", err)
+			}
+			if len(dl) != 1 {
+				t.Fatalf("checkRATCookie failed, expected 1 DM, got: %d", len(dl))
+			}
+			if len(dl[0].Embeds) != 1 {
+				t.Fatalf("checkRATCookie failed, expected 1 embed, got: %d", len(dl[0].Embeds))
+			}
+			if !strings.HasPrefix(dl[0].Embeds[0].Description, tc.message) {
+				t.Errorf("checkRATCookie failed, expected message to start with: %q, got: %q", tc.message,
+					dl[0].Embeds[0].Description)
+			}
+			if !db.executed("user_prefs") {
+				t.Error("checkRATCookie failed, expected the reminder state to be stored")
+			}
+		})
+	}
+}
+
+func TestBot_checkRATCookie_stateError(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	b, s, db := newTestBot(t, now)
+	re := now.Add(time.Hour * 5)
+	u := &model.User{ID: 7, UserID: "200", RATExpire: &re}
+	de := errors.New("connection reset")
+	db.onArgErr("FROM user_prefs", model.UserPrefRATReminderNotified(time.Hour*6), de)
+
+	err := b.checkRATCookie(u)
+	if !errors.Is(err, de) {
+		t.Errorf("checkRATCookie failed, expected error: %s, got: %v", de, err)
+	}
+	if dl := s.DirectMessages("200"); len(dl) != 0 {
+		t.Errorf("checkRATCookie failed, expected no DM, got: %d", len(dl))
+	}
+	if db.executed("user_prefs") {
+		t.Error("checkRATCookie failed, expected the reminder state not to be stored")
+	}
+}
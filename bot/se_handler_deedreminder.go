@@ -0,0 +1,35 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// ScheduledEventRemindSwiftDeeds reminds the guilds that enabled deed announcements of daily swift deeds
+// that end within the configured reminder period. Every deed is only reminded of once
+func (b *Bot) ScheduledEventRemindSwiftDeeds() error {
+	ll := b.Log.With().Str("context", "bot.ScheduledEventRemindSwiftDeeds").Logger()
+	n := b.clock.Now()
+	dl, err := b.Model.Deed.GetSwiftDeedsEndingBefore(n, n.Add(b.Config.Reminder.SwiftDeed))
+	if err != nil {
+		return fmt.Errorf("failed to read swift deeds from DB: %w", err)
+	}
+	for _, d := range dl {
+		b.announceToGuilds(model.GuildPrefAnnounceDeeds, model.GuildPrefAnnounceDeedsRole,
+			func(p *Display) []*discordgo.MessageEmbed {
+				e := deedEmbed(p, d)
+				if e == nil {
+					return nil
+				}
+				e.Title = p.Sprintf("Swift deed ends soon: %s", e.Title)
+				return []*discordgo.MessageEmbed{e}
+			})
+		if err := b.Model.Deed.SetReminded(d); err != nil {
+			ll.Error().Msgf("failed to mark deed %d as reminded in DB: %s", d.ID, err)
+		}
+	}
+	return nil
+}
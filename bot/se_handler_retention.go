@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// ScheduledEventRetention performs the scheduled downsampling of the user stats, reputation, ledger and
+// season progress history. Rows older than the configured raw retention are rolled up into hourly buckets, rows older
+// than the hourly retention are rolled up into daily buckets. It also purges the notification log
+func (b *Bot) ScheduledEventRetention() error {
+	ll := b.Log.With().Str("context", "bot.ScheduledEventRetention").Logger()
+	nl, err := b.Model.Notification.PurgeLog(b.clock.Now().Add(-NotificationLogRetention))
+	if err != nil {
+		return fmt.Errorf("failed to purge notification log: %w", err)
+	}
+	ll.Debug().Msgf("purged %d notification log entries", nl)
+
+	rd := b.Config.Retention.RawDays
+	hd := b.Config.Retention.HourlyDays
+	if rd <= 0 {
+		ll.Debug().Msg("retention is disabled. Skipping downsampling")
+		return nil
+	}
+	if hd < rd {
+		hd = rd
+	}
+
+	now := b.clock.Now()
+	rc := now.Add(time.Hour * -24 * time.Duration(rd))
+	hc := now.Add(time.Hour * -24 * time.Duration(hd))
+
+	type dsFunc func(f, t time.Time, g model.Granularity) (int64, error)
+	dl := []struct {
+		n string
+		f dsFunc
+	}{
+		{"user stats", b.Model.UserStats.Downsample},
+		{"user reputation", b.Model.UserReputation.Downsample},
+		{"user ledger", b.Model.UserLedger.Downsample},
+		{"user season progress", b.Model.UserSeason.Downsample},
+	}
+	for _, d := range dl {
+		nh, err := d.f(hc, rc, model.GranularityHour)
+		if err != nil {
+			return fmt.Errorf("failed to downsample %s to hourly buckets: %w", d.n, err)
+		}
+		nd, err := d.f(time.Time{}, hc, model.GranularityDay)
+		if err != nil {
+			return fmt.Errorf("failed to downsample %s to daily buckets: %w", d.n, err)
+		}
+		ll.Info().Msgf("downsampled %s history: removed %d raw rows and %d hourly rows", d.n, nh, nd)
+	}
+	return nil
+}
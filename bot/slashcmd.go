@@ -5,13 +5,15 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/language"
 
 	"github.com/wneessen/arrgo/crypto"
+	"github.com/wneessen/arrgo/locale"
 )
 
 // getSlashCommands returns a list of slash commands that will be registered for the bot
 func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
-	return []*discordgo.ApplicationCommand{
+	cl := []*discordgo.ApplicationCommand{
 		// time returns the current time back to the user
 		{
 			Name:        "time",
@@ -58,6 +60,59 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 					},
 					Type: discordgo.ApplicationCommandOptionSubCommandGroup,
 				},
+				{
+					Name:        "announce-achievements",
+					Description: "Enable/Disable announcing newly unlocked SoT achievements of the members",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "enable",
+							Description: "Announce newly unlocked Sea of Thieves achievements",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+						{
+							Name:        "disable",
+							Description: "Do not announce newly unlocked Sea of Thieves achievements",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+					Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				},
+				{
+					Name:        "announce-deeds",
+					Description: "Enable/Disable announcing new deeds and expiring swift deeds",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "enable",
+							Description: "Announce new deeds and remind of swift deeds shortly before they end",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options:     announceRoleOptions(),
+						},
+						{
+							Name:        "disable",
+							Description: "Do not announce new deeds",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+					Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				},
+				{
+					Name:        "announce-traderoutes",
+					Description: "Enable/Disable announcing new trade route rotations",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "enable",
+							Description: "Announce new and changed trade routes",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options:     announceRoleOptions(),
+						},
+						{
+							Name:        "disable",
+							Description: "Do not announce new trade routes",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+					Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				},
 				{
 					Name:        "announce-sot-summary",
 					Description: "Enable/Disable posting of SoT play summaries to the system/announce channel",
@@ -98,30 +153,222 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 			Description: "Regsiters your user with ArrGo so you can use certain user-specific features",
 		},
 
+		// language overrides the language of the bot's responses for the requesting user
+		{
+			Name:        "language",
+			Description: "Set the language of the bot's responses (default: the language of your Discord client)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "language",
+					Description: "The language of the bot's responses",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Automatic", Value: LanguageAuto},
+						{Name: "English", Value: language.English.String()},
+						{Name: "Deutsch", Value: language.German.String()},
+					},
+				},
+			},
+		},
+
+		// settings configures the display preferences of the requesting user
+		{
+			Name:        "settings",
+			Description: "Configure how distances, numbers and times are displayed to you",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "distance",
+					Description: "The unit of distances (default: nautical miles)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Nautical miles", Value: DistanceNauticalMiles},
+						{Name: "Kilometres", Value: DistanceKilometres},
+						{Name: "Miles", Value: DistanceMiles},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "numbers",
+					Description: "The digit grouping of numbers (default: the format of your language)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Automatic", Value: SettingAuto},
+						{Name: "1,234,567.8", Value: NumberGroupingComma},
+						{Name: "1.234.567,8", Value: NumberGroupingPeriod},
+						{Name: "1 234 567,8", Value: NumberGroupingSpace},
+						{Name: "1234567.8", Value: NumberGroupingNone},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "timezone",
+					Description: "IANA time zone, e.g. Europe/Berlin, or \"auto\" (default: your Discord client)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "clock",
+					Description: "The clock format of times (default: your Discord client)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Automatic", Value: SettingAuto},
+						{Name: "24-hour clock", Value: Clock24h},
+						{Name: "12-hour clock", Value: Clock12h},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "privacy",
+					Description: "Whether other guild members can compare with your stats (default: public)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Public", Value: SettingPublic},
+						{Name: "Private", Value: SettingPrivate},
+					},
+				},
+			},
+		},
+
+		// unregister removes the requesting user and all of its data from the bot
+		{
+			Name:        "unregister",
+			Description: "Removes your user and all data stored about you from ArrGo",
+		},
+
+		// mydata gives the requesting user access to the data the bot stores about them
+		{
+			Name:        "mydata",
+			Description: "Access the data ArrGo has stored about you",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "export",
+					Description: "Sends you a DM with all the data ArrGo has stored about you",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "format",
+							Description: "File format of the export (default: JSON)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "JSON", Value: ExportFormatJSON},
+								{Name: "CSV", Value: ExportFormatCSV},
+							},
+						},
+					},
+				},
+			},
+		},
+
 		// setrat stores the SoT authentication token in the Bot's database
 		{
 			Name:        "setrat",
-			Description: "Stores the Sea of Thieves authentication token in the Bot's database",
+			Description: "Verifies and stores your Sea of Thieves authentication cookie in the Bot's database",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "rat-cookie",
-					Description: "Full SoT authentication cookie string as provided by the SoT-RAT-Extractor",
+					Description: "SoT-RAT-Extractor string, rat= cookie value or full Cookie header",
 					Required:    true,
 				},
 			},
 		},
 
+		// reminders configures the RAT cookie expiry reminders of the requesting user
+		{
+			Name:        "reminders",
+			Description: "Configure when and where you get reminded about your expiring SoT authentication cookie",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "stages",
+					Description: "Comma-separated durations before the expiry, e.g. 24h,6h,1h",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "delivery",
+					Description: "How you want to be reminded",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Direct message", Value: ReminderDeliveryDM},
+						{Name: "Ping in a guild channel", Value: ReminderDeliveryChannel},
+					},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "Guild channel for the reminder ping (default: the current channel)",
+					Required:     false,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+			},
+		},
+
+		// notifications configures how the requesting user receives notifications of the bot
+		{
+			Name:        "notifications",
+			Description: "Configure how and where you receive notifications of the bot",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "show",
+					Description: "Show your current notification settings",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set",
+					Description: "Set how you want to receive a type of notification",
+					Options:     notifyRouteCommandOptions(false),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "quiet-hours",
+					Description: "Hold back notifications during the given hours",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "hours",
+							Description: "Quiet hours in the format HH:MM-HH:MM (e.g. 22:00-07:00) or \"off\"",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "timezone",
+							Description: "IANA time zone of the quiet hours, e.g. Europe/Berlin (default: UTC)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "guild",
+					Description: "Set the guild default for a type of notification (admin only)",
+					Options:     notifyRouteCommandOptions(true),
+				},
+			},
+		},
+
 		// achievement gets the users latest achievement from the SoT API
 		{
 			Name:        "achievement",
 			Description: "Returns your latest achievement in Sea of Thieves to you",
 		},
 
+		// achievements lists the stored achievements of the requesting user
+		{
+			Name:        "achievements",
+			Description: "Lists your unlocked Sea of Thieves achievements",
+			Options:     achievementsCommandOptions(),
+		},
+
 		// season gets the users season renown progress from the SoT API
 		{
 			Name:        "season",
 			Description: "Returns your renown progress in the current Sea of Thieves season to you",
+			Options:     seasonCommandOptions(),
 		},
 
 		// balance gets the users current gold/doubloon/ac balance from the SoT API
@@ -134,6 +381,14 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 		{
 			Name:        "traderoutes",
 			Description: "Returns the currently active trade routes in Sea of Thieves",
+			Options:     tradeRoutesCommandOptions(),
+		},
+
+		// sell looks up the outposts at which a commodity is sought after
+		{
+			Name:        "sell",
+			Description: "Shows at which outposts a commodity is sought after or in surplus",
+			Options:     sellCommandOptions(),
 		},
 
 		// overview get the users statistics overview from the SoT API
@@ -145,15 +400,42 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 		// historic compares the current Sea of Thives user stats with history data
 		{
 			Name:        "compare",
-			Description: "Compares the current Sea of Thieves users stats with historic data (in hours)",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "hours",
-					Description: "The backwards duration in hours that the historic data should be calculated from",
-					Required:    true,
-				},
-			},
+			Description: "Compares your current Sea of Thieves stats with historic data",
+			Options:     compareCommandOptions(),
+		},
+
+		// goal manages the personal goals of the requesting user
+		{
+			Name:        "goal",
+			Description: "Set personal Sea of Thieves goals and track your progress",
+			Options:     goalCommandOptions(),
+		},
+
+		// subscribe manages the personal topic subscriptions of the requesting user
+		{
+			Name:        "subscribe",
+			Description: "Subscribe to personal notifications about deeds, trade routes, your ledger and stats",
+			Options:     subscribeCommandOptions(),
+		},
+
+		// versus lines up the stats of the requesting user and another guild member
+		{
+			Name:        "versus",
+			Description: "Compares your Sea of Thieves stats with another guild member",
+			Options:     versusCommandOptions(),
+		},
+
+		// Compare SoT stats is the user context-menu command of /versus
+		{
+			Name: UserCmdVersus,
+			Type: discordgo.UserApplicationCommand,
+		},
+
+		// graph renders a chart of the stats history of the requesting user and other guild members
+		{
+			Name:        "graph",
+			Description: "Renders a chart of your Sea of Thieves stats history",
+			Options:     graphCommandOptions(),
 		},
 
 		// dailydeeds get the currently active daily deeds in Sea of Thieves
@@ -225,6 +507,174 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 			},
 		},
 	}
+	localizeSlashCommands(cl)
+	return cl
+}
+
+// localizeSlashCommands adds the Discord localizations of the names and descriptions to the given slash
+// commands and the descriptions and choices of their options
+func localizeSlashCommands(cl []*discordgo.ApplicationCommand) {
+	for _, c := range cl {
+		nl := make(map[discordgo.Locale]string)
+		dl := make(map[discordgo.Locale]string)
+		for t, l := range discordLocales {
+			if v, ok := locale.CommandName(t, c.Name); ok {
+				nl[l] = v
+			}
+			if v, ok := locale.Lookup(t, c.Description); ok {
+				dl[l] = v
+			}
+		}
+		if len(nl) > 0 {
+			c.NameLocalizations = &nl
+		}
+		if len(dl) > 0 {
+			c.DescriptionLocalizations = &dl
+		}
+		localizeSlashCommandOptions(c.Options)
+	}
+}
+
+// localizeSlashCommandOptions adds the Discord localizations of the descriptions and choice names to the
+// given slash command options
+func localizeSlashCommandOptions(ol []*discordgo.ApplicationCommandOption) {
+	for _, o := range ol {
+		for t, l := range discordLocales {
+			if v, ok := locale.Lookup(t, o.Description); ok {
+				if o.DescriptionLocalizations == nil {
+					o.DescriptionLocalizations = make(map[discordgo.Locale]string)
+				}
+				o.DescriptionLocalizations[l] = v
+			}
+			for _, c := range o.Choices {
+				if v, ok := locale.Lookup(t, c.Name); ok {
+					if c.NameLocalizations == nil {
+						c.NameLocalizations = make(map[discordgo.Locale]string)
+					}
+					c.NameLocalizations[l] = v
+				}
+			}
+		}
+		localizeSlashCommandOptions(o.Options)
+	}
+}
+
+// slashCommandChanged returns true if the given slash command differs from the registered slash command.
+// The options are compared recursively
+func slashCommandChanged(sc, rc *discordgo.ApplicationCommand) bool {
+	if sc.Description != rc.Description {
+		return true
+	}
+	if !sameLocalizations(localizationMap(sc.NameLocalizations), localizationMap(rc.NameLocalizations)) ||
+		!sameLocalizations(localizationMap(sc.DescriptionLocalizations),
+			localizationMap(rc.DescriptionLocalizations)) {
+		return true
+	}
+	return slashCommandOptionsChanged(sc.Options, rc.Options)
+}
+
+// slashCommandOptionsChanged returns true if the given options differ from the registered options in
+// their name, type, description, flags, limits, choices, localizations or sub-options
+func slashCommandOptionsChanged(sl, rl []*discordgo.ApplicationCommandOption) bool {
+	if len(sl) != len(rl) {
+		return true
+	}
+	for n, so := range sl {
+		ro := rl[n]
+		if so.Type != ro.Type || so.Name != ro.Name || so.Description != ro.Description ||
+			so.Required != ro.Required || so.Autocomplete != ro.Autocomplete {
+			return true
+		}
+		if so.MaxValue != ro.MaxValue || so.MaxLength != ro.MaxLength || !sameFloatPtr(so.MinValue, ro.MinValue) ||
+			!sameIntPtr(so.MinLength, ro.MinLength) {
+			return true
+		}
+		if !sameLocalizations(so.NameLocalizations, ro.NameLocalizations) ||
+			!sameLocalizations(so.DescriptionLocalizations, ro.DescriptionLocalizations) {
+			return true
+		}
+		if len(so.ChannelTypes) != len(ro.ChannelTypes) || len(so.Choices) != len(ro.Choices) {
+			return true
+		}
+		for k, ct := range so.ChannelTypes {
+			if ro.ChannelTypes[k] != ct {
+				return true
+			}
+		}
+		for k, sc := range so.Choices {
+			rc := ro.Choices[k]
+			if sc.Name != rc.Name || !sameChoiceValue(sc.Value, rc.Value) ||
+				!sameLocalizations(sc.NameLocalizations, rc.NameLocalizations) {
+				return true
+			}
+		}
+		if slashCommandOptionsChanged(so.Options, ro.Options) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameChoiceValue returns true if both choice values are equal. Numeric choice values of registered slash
+// commands are decoded as float64 from the API response, so numbers are compared by their value
+func sameChoiceValue(a, b interface{}) bool {
+	af, aok := choiceNumber(a)
+	bf, bok := choiceNumber(b)
+	if aok && bok {
+		return af == bf
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// choiceNumber returns the given choice value as float64 if it is numeric
+func choiceNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// sameFloatPtr returns true if both pointers are nil or point to the same value
+func sameFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameIntPtr returns true if both pointers are nil or point to the same value
+func sameIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// localizationMap returns the localization map of a slash command or nil if it is not set
+func localizationMap(m *map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
+
+// sameLocalizations returns true if both localization maps hold the same translations
+func sameLocalizations(a, b map[discordgo.Locale]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
 }
 
 // RegisterSlashCommands will fetch the list of available slash commands and register them with the Guild
@@ -232,13 +682,14 @@ func (b *Bot) getSlashCommands() []*discordgo.ApplicationCommand {
 func (b *Bot) RegisterSlashCommands() error {
 	ll := b.Log.With().Str("context", "bot.RegisterSlashCommands").Logger()
 
-	// We need a valid state and user
-	if b.Session.State == nil || b.Session.State.User == nil {
-		return fmt.Errorf("no valid session state or user. Required itents might be missing from discord token")
+	// We need a valid application ID
+	aid, err := b.applicationID()
+	if err != nil {
+		return err
 	}
 
 	// Get a list of currently registered slash commands
-	rcl, err := b.Session.ApplicationCommands(b.Session.State.User.ID, "")
+	rcl, err := b.Session.ApplicationCommands(aid, "")
 	if err != nil {
 		return fmt.Errorf("failed to fetch list registered slash commands: %w", err)
 	}
@@ -247,26 +698,19 @@ func (b *Bot) RegisterSlashCommands() error {
 		n := true
 		c := false
 		for _, rc := range rcl {
+			if sc.Name != rc.Name {
+				continue
+			}
 			sc.ApplicationID = rc.ApplicationID
 			sc.ID = rc.ID
-			if sc.Name == rc.Name && sc.Description == rc.Description {
-				ll.Debug().Msgf("slash command %s already registered. Skipping.", rc.Name)
-				n = false
-				c = false
-				break
-			}
-			if sc.Name == rc.Name && sc.Description != rc.Description {
-				ll.Debug().Msgf("slash command %s changed. Updating.", rc.Name)
-				n = false
-				c = true
-				break
-			}
-			if sc.Name == rc.Name && len(sc.Options) != len(rc.Options) {
+			n = false
+			c = slashCommandChanged(sc, rc)
+			if c {
 				ll.Debug().Msgf("slash command %s changed. Updating.", rc.Name)
-				n = false
-				c = true
 				break
 			}
+			ll.Debug().Msgf("slash command %s already registered. Skipping.", rc.Name)
+			break
 		}
 		if n || c {
 			go func(s *discordgo.ApplicationCommand, e bool) {
@@ -282,7 +726,7 @@ func (b *Bot) RegisterSlashCommands() error {
 				if e {
 					ll.Debug().Msgf("[%s] updating slash command...", s.Name)
 
-					_, err := b.Session.ApplicationCommandEdit(b.Session.State.User.ID, "", s.ID, s)
+					_, err := b.Session.ApplicationCommandEdit(aid, "", s.ID, s)
 					if err != nil {
 						ll.Error().Msgf("[%s] failed to update slash command: %s", s.Name, err)
 						return
@@ -291,7 +735,7 @@ func (b *Bot) RegisterSlashCommands() error {
 				}
 				if !e {
 					ll.Debug().Msgf("[%s] registering slash command...", s.Name)
-					_, err := b.Session.ApplicationCommandCreate(b.Session.State.User.ID, "", s)
+					_, err := b.Session.ApplicationCommandCreate(aid, "", s)
 					if err != nil {
 						ll.Error().Msgf("[%s] failed to register slash command: %s", s.Name, err)
 						return
@@ -306,28 +750,32 @@ func (b *Bot) RegisterSlashCommands() error {
 
 // RemoveSlashCommands will fetch the list of registered slash commands and remove them
 func (b *Bot) RemoveSlashCommands() error {
-	ll := b.Log.With().Str("context", "bot.RegisterSlashCommands").Logger()
+	ll := b.Log.With().Str("context", "bot.RemoveSlashCommands").Logger()
 
-	dg, err := discordgo.New("Bot " + b.Config.Discord.Token)
-	if err != nil {
-		return fmt.Errorf("failed to create discord session: %w", err)
+	if b.Session == nil {
+		dg, err := b.newDiscordSession()
+		if err != nil {
+			return err
+		}
+		b.Session = dg
 	}
-	b.Session = dg
-
-	// Define list of events we want to see
-	dg.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages |
-		discordgo.IntentsGuildVoiceStates | discordgo.IntentsDirectMessages |
-		discordgo.IntentsGuildPresences | discordgo.IntentsMessageContent |
-		discordgo.IntentsGuildIntegrations
 
 	// Open the websocket and begin listening.
-	err = b.Session.Open()
-	if err != nil {
+	if err := b.Session.Open(); err != nil {
 		return fmt.Errorf("failed to open websocket to listen: %w", err)
 	}
+	defer func() {
+		if err := b.Session.Close(); err != nil {
+			ll.Warn().Msgf("failed to close discord session: %s", err)
+		}
+	}()
 
 	// Get a list of currently registered slash commands
-	rcl, err := b.Session.ApplicationCommands(b.Session.State.User.ID, "")
+	aid, err := b.applicationID()
+	if err != nil {
+		return err
+	}
+	rcl, err := b.Session.ApplicationCommands(aid, "")
 	if err != nil {
 		return fmt.Errorf("failed to fetch list registered slash commands: %w", err)
 	}
@@ -341,19 +789,20 @@ func (b *Bot) RemoveSlashCommands() error {
 
 // SlashCommandHandler is the central handler method for all slash commands. It will look up
 // the name of the received SC-handler event in a map and when found execute the corresponding
-// method
-func (b *Bot) SlashCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	ll := b.Log.With().Str("context", "bot.SlashCommandHandler").
-		Str("command_type", i.Data.Type().String()).
-		Str("command_name", i.ApplicationCommandData().Name).Logger()
+// method. All responses are sent via the Bot's DiscordAPI
+func (b *Bot) SlashCommandHandler(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	s := b.Session
 
 	// We only process ApplicationCommands
-	if i.Data.Type().String() != "ApplicationCommand" {
+	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
+	ll := b.Log.With().Str("context", "bot.SlashCommandHandler").
+		Str("command_type", i.Type.String()).
+		Str("command_name", i.ApplicationCommandData().Name).Logger()
 
 	// Define list of slash command handler methods
-	sh := map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate) error{
+	sh := map[string]func(s DiscordAPI, i *discordgo.InteractionCreate) error{
 		"time":        b.SlashCmdTime,
 		"uptime":      b.SlashCmdUptime,
 		"version":     b.SlashCmdVersion,
@@ -363,24 +812,47 @@ func (b *Bot) SlashCommandHandler(s *discordgo.Session, i *discordgo.Interaction
 		"register":    b.SlashCmdRegister,
 		"setrat":      b.SlashCmdSetRAT,
 		"achievement": b.SlashCmdSoTAchievement,
-		"season":      b.SlashCmdSoTSeasonProgress,
+		"season":      b.SlashCmdSoTSeason,
 		"balance":     b.SlashCmdSoTBalance,
 		"traderoutes": b.SlashCmdSoTTradeRoutes,
 		"overview":    b.SlashCmdSoTOverview,
 		"compare":     b.SlashCmdSoTCompare,
+		"versus":      b.SlashCmdVersus,
+		"goal":        b.SlashCmdGoal,
+		"graph":       b.SlashCmdGraph,
 		"dailydeeds":  b.SlashCmdSoTDailyDeeds,
 		"ledger":      b.SlashCmdSoTLedger,
 		"allegiance":  b.SlashCmdSoTAllegiance,
 		"reputation":  b.SlashCmdSoTReputation,
+		"mydata":      b.SlashCmdMyData,
+		"unregister":  b.SlashCmdUnregister,
+		"reminders":   b.SlashCmdReminders,
+
+		"notifications": b.SlashCmdNotifications,
+		"achievements":  b.SlashCmdSoTAchievements,
+		"language":      b.SlashCmdLanguage,
+		"settings":      b.SlashCmdSettings,
+		"sell":          b.SlashCmdSoTSell,
+		"subscribe":     b.SlashCmdSubscribe,
+
+		UserCmdVersus: b.UserCmdCompareStats,
 	}
 
 	// Define list of slash commands that should use ephemeral messages
 	el := map[string]bool{
-		"register": true,
-		"setrat":   true,
-		"config":   true,
-		"override": true,
-		"version":  true,
+		"register":   true,
+		"setrat":     true,
+		"config":     true,
+		"override":   true,
+		"version":    true,
+		"mydata":     true,
+		"unregister": true,
+		"reminders":  true,
+
+		"notifications": true,
+		"language":      true,
+		"settings":      true,
+		"subscribe":     true,
 	}
 
 	// Check if provided command is available and process it
@@ -400,12 +872,14 @@ func (b *Bot) SlashCommandHandler(s *discordgo.Session, i *discordgo.Interaction
 		}
 		if err := h(s, i); err != nil {
 			ll.Error().Msgf("failed to process /%s command: %s", i.ApplicationCommandData().Name, err)
+			l := b.interactionLanguage(i.Interaction)
+			p := locale.NewPrinter(l)
 			e := []*discordgo.MessageEmbed{
 				{
 					Type: discordgo.EmbedTypeArticle,
-					Description: fmt.Sprintf("I am sorry, but I was not able to process your request: %s",
-						err),
-					Title: "Oh no! Something went wrong!",
+					Description: p.Sprintf("I am sorry, but I was not able to process your request: %s",
+						localizeError(l, err)),
+					Title: p.Sprintf("Oh no! Something went wrong!"),
 				},
 			}
 			_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &e})
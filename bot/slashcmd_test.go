@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestSlashCommandChanged(t *testing.T) {
+	tt := []struct {
+		name string
+		mod  func(c *discordgo.ApplicationCommand)
+		want bool
+	}{
+		{"unchanged", func(*discordgo.ApplicationCommand) {}, false},
+		{"numeric choice value decoded as float64", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[1].Choices[0].Value = float64(100000000)
+		}, false},
+		{"command description", func(c *discordgo.ApplicationCommand) {
+			c.Description = "Changed"
+		}, true},
+		{"command localization", func(c *discordgo.ApplicationCommand) {
+			(*c.DescriptionLocalizations)[discordgo.German] = "Geändert"
+		}, true},
+		{"sub-command description", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Description = "Changed"
+		}, true},
+		{"option type", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[0].Type = discordgo.ApplicationCommandOptionInteger
+		}, true},
+		{"option name", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[0].Name = "changed"
+		}, true},
+		{"option localization", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[0].DescriptionLocalizations = nil
+		}, true},
+		{"option autocomplete", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[0].Autocomplete = false
+		}, true},
+		{"option minimum", func(c *discordgo.ApplicationCommand) {
+			m := float64(2)
+			c.Options[0].Options[1].MinValue = &m
+		}, true},
+		{"option added", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options = append(c.Options[0].Options, &discordgo.ApplicationCommandOption{
+				Type: discordgo.ApplicationCommandOptionString, Name: "new", Description: "New",
+			})
+		}, true},
+		{"choice name", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[1].Choices[0].Name = "Changed"
+		}, true},
+		{"choice value", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[1].Choices[0].Value = float64(5)
+		}, true},
+		{"choice localization", func(c *discordgo.ApplicationCommand) {
+			c.Options[0].Options[1].Choices[0].NameLocalizations[discordgo.German] = "Geändert"
+		}, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rc := testSlashCommand()
+			tc.mod(rc)
+			if c := slashCommandChanged(testSlashCommand(), rc); c != tc.want {
+				t.Errorf("slashCommandChanged failed, expected: %t, got: %t", tc.want, c)
+			}
+		})
+	}
+}
+
+// testSlashCommand returns a slash command with a sub-command, localizations and choices
+func testSlashCommand() *discordgo.ApplicationCommand {
+	m := float64(1)
+	return &discordgo.ApplicationCommand{
+		Name:                     "test",
+		Description:              "Test command",
+		DescriptionLocalizations: &map[discordgo.Locale]string{discordgo.German: "Testbefehl"},
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "sub",
+				Description: "Sub-command",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "name",
+						Description:              "A name",
+						DescriptionLocalizations: map[discordgo.Locale]string{discordgo.German: "Ein Name"},
+						Autocomplete:             true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "amount",
+						Description: "An amount",
+						MinValue:    &m,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:              "Many",
+								NameLocalizations: map[discordgo.Locale]string{discordgo.German: "Viele"},
+								Value:             100000000,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SubscriptionFilterMaxLen is the maximum length of the filter expression of a subscription
+const SubscriptionFilterMaxLen = 200
+
+// subFilterOps are the comparison operators of a filter condition. Longer operators come first, so that
+// they are not mistaken for their shorter prefixes
+var subFilterOps = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// List of subscription filter specific errors
+var (
+	ErrSubFilterSyntax = errors.New("invalid filter. Use conditions like field=value or field>=number, " +
+		"separated by spaces")
+	ErrSubFilterField    = errors.New("unknown filter field. The fields of this topic are")
+	ErrSubFilterOperator = errors.New("only = and != can be used for text fields")
+	ErrSubFilterNumber   = errors.New("the value of a numeric field must be a number")
+	ErrSubFilterLength   = errors.New("the filter is too long")
+)
+
+// subFilterCond is a single condition of a SubscriptionFilter
+type subFilterCond struct {
+	field string
+	op    string
+	value string
+	num   float64
+}
+
+// SubscriptionFilter is a parsed filter expression of a subscription. All of its conditions have to
+// match for the filter to match
+type SubscriptionFilter []subFilterCond
+
+// ParseSubscriptionFilter parses the given filter expression for the given fields. The expression consists
+// of conditions like "field=value" or "field>=number", separated by spaces. Values that contain spaces
+// have to be enclosed in double quotes. The fields map holds the available field names and whether the
+// field is numeric
+func ParseSubscriptionFilter(e string, fields map[string]bool) (SubscriptionFilter, error) {
+	if len(e) > SubscriptionFilterMaxLen {
+		return nil, ErrSubFilterLength
+	}
+	var f SubscriptionFilter
+	for _, t := range subFilterTokens(e) {
+		oi := strings.IndexAny(t, "<>=!")
+		if oi <= 0 {
+			return nil, ErrSubFilterSyntax
+		}
+		c := subFilterCond{field: strings.ToLower(t[:oi])}
+		for _, op := range subFilterOps {
+			if strings.HasPrefix(t[oi:], op) {
+				c.op = op
+				break
+			}
+		}
+		if c.op == "" {
+			return nil, ErrSubFilterSyntax
+		}
+		c.value = strings.TrimSpace(strings.Trim(t[oi+len(c.op):], `"`))
+		if c.value == "" {
+			return nil, ErrSubFilterSyntax
+		}
+
+		num, ok := fields[c.field]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrSubFilterField, subFilterFieldList(fields))
+		}
+		if !num && c.op != "=" && c.op != "!=" {
+			return nil, ErrSubFilterOperator
+		}
+		if num {
+			n, err := strconv.ParseFloat(strings.NewReplacer(",", "", "_", "").Replace(c.value), 64)
+			if err != nil {
+				return nil, ErrSubFilterNumber
+			}
+			c.num = n
+		}
+		f = append(f, c)
+	}
+	return f, nil
+}
+
+// subFilterTokens splits the given filter expression at spaces that are not enclosed in double quotes
+func subFilterTokens(e string) []string {
+	var tl []string
+	var sb strings.Builder
+	q := false
+	for _, r := range e {
+		switch {
+		case r == '"':
+			q = !q
+			sb.WriteRune(r)
+		case (r == ' ' || r == '\t') && !q:
+			if sb.Len() > 0 {
+				tl = append(tl, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() > 0 {
+		tl = append(tl, sb.String())
+	}
+	return tl
+}
+
+// subFilterFieldList returns the sorted, comma-separated list of the given filter fields
+func subFilterFieldList(fields map[string]bool) string {
+	fl := make([]string, 0, len(fields))
+	for k := range fields {
+		fl = append(fl, k)
+	}
+	sort.Strings(fl)
+	return strings.Join(fl, ", ")
+}
+
+// Match returns true if all conditions of the filter match the given field values. Text fields match if
+// they contain the value of the condition, ignoring case, spaces and punctuation. Numeric fields have to
+// be given as float64
+func (f SubscriptionFilter) Match(vm map[string]interface{}) bool {
+	for _, c := range f {
+		if !c.match(vm[c.field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns true if the given field value matches the condition
+func (c subFilterCond) match(v interface{}) bool {
+	switch fv := v.(type) {
+	case float64:
+		switch c.op {
+		case ">=":
+			return fv >= c.num
+		case "<=":
+			return fv <= c.num
+		case ">":
+			return fv > c.num
+		case "<":
+			return fv < c.num
+		case "!=":
+			return fv != c.num
+		default:
+			return fv == c.num
+		}
+	case string:
+		s := fuzzyScore(c.value, fv)
+		m := s != fuzzyNoMatch && s <= 2
+		if c.op == "!=" {
+			return !m
+		}
+		return m
+	default:
+		return false
+	}
+}
+
+// String returns the normalized filter expression
+func (f SubscriptionFilter) String() string {
+	cl := make([]string, len(f))
+	for n, c := range f {
+		v := c.value
+		if strings.ContainsAny(v, " \t") {
+			v = `"` + v + `"`
+		}
+		cl[n] = c.field + c.op + v
+	}
+	return strings.Join(cl, " ")
+}
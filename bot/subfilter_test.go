@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testFilterFields are the filter fields used in the subscription filter tests
+var testFilterFields = map[string]bool{"name": false, "gold": true, "outpost": false}
+
+func TestParseSubscriptionFilter(t *testing.T) {
+	tt := []struct {
+		name string
+		expr string
+		want string
+		err  error
+	}{
+		{"empty filter", "", "", nil},
+		{"single condition", "gold>=1000", "gold>=1000", nil},
+		{"multiple conditions", "name=kraken  gold<5", "name=kraken gold<5", nil},
+		{"quoted value", `outpost="Sanctuary Outpost"`, `outpost="Sanctuary Outpost"`, nil},
+		{"field names are case-insensitive", "GOLD!=3", "gold!=3", nil},
+		{"digit grouping", "gold>1,000", "gold>1,000", nil},
+		{"missing operator", "gold", "", ErrSubFilterSyntax},
+		{"missing field", ">=5", "", ErrSubFilterSyntax},
+		{"missing value", "gold>=", "", ErrSubFilterSyntax},
+		{"reversed operator", "gold=>5", "", ErrSubFilterNumber},
+		{"unknown field", "silver>5", "", ErrSubFilterField},
+		{"comparison of text field", "name>kraken", "", ErrSubFilterOperator},
+		{"non-numeric value", "gold>=lots", "", ErrSubFilterNumber},
+		{"too long", "name=" + strings.Repeat("x", SubscriptionFilterMaxLen), "", ErrSubFilterLength},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseSubscriptionFilter(tc.expr, testFilterFields)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("ParseSubscriptionFilter(%q) failed, expected error: %s, got: %v", tc.expr,
+						tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSubscriptionFilter(%q) failed: %s", tc.expr, err)
+			}
+			if f.String() != tc.want {
+				t.Errorf("ParseSubscriptionFilter(%q) failed, expected: %q, got: %q", tc.expr, tc.want,
+					f.String())
+			}
+		})
+	}
+}
+
+func TestParseSubscriptionFilter_fieldList(t *testing.T) {
+	_, err := ParseSubscriptionFilter("silver>5", testFilterFields)
+	if err == nil || !strings.HasSuffix(err.Error(), ": gold, name, outpost") {
+		t.Errorf("ParseSubscriptionFilter failed, expected the sorted field list in the error, got: %v", err)
+	}
+}
+
+func TestSubscriptionFilter_Match(t *testing.T) {
+	vm := map[string]interface{}{"name": "Kraken Hunter", "gold": float64(1500), "outpost": "Sanctuary Outpost"}
+	tt := []struct {
+		name  string
+		expr  string
+		match bool
+	}{
+		{"empty filter", "", true},
+		{"greater or equal", "gold>=1500", true},
+		{"greater", "gold>1500", false},
+		{"less or equal", "gold<=1500", true},
+		{"less", "gold<1,000", false},
+		{"equal", "gold=1500", true},
+		{"not equal", "gold!=1500", false},
+		{"text contains", "name=kraken", true},
+		{"text with typo", "name=krakn", false},
+		{"text ignores punctuation", `outpost="sanctuary-outpost"`, true},
+		{"text not contained", "name=megalodon", false},
+		{"text not equal", "name!=megalodon", true},
+		{"all conditions have to match", "name=kraken gold<1000", false},
+		{"all conditions match", `name=kraken gold>1000 outpost=sanctuary`, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseSubscriptionFilter(tc.expr, testFilterFields)
+			if err != nil {
+				t.Fatalf("ParseSubscriptionFilter(%q) failed: %s", tc.expr, err)
+			}
+			if m := f.Match(vm); m != tc.match {
+				t.Errorf("Match(%q) failed, expected: %t, got: %t", tc.expr, tc.match, m)
+			}
+		})
+	}
+}
+
+func TestSubscriptionFilter_Match_missingField(t *testing.T) {
+	f, err := ParseSubscriptionFilter("gold>5", testFilterFields)
+	if err != nil {
+		t.Fatalf("ParseSubscriptionFilter failed: %s", err)
+	}
+	if f.Match(map[string]interface{}{"name": "Kraken Hunter"}) {
+		t.Error("Match failed, expected a missing field not to match")
+	}
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrWindowFormat is returned if a time window could not be parsed
+var ErrWindowFormat = errors.New("the time window could not be parsed. Please use a duration like 12h, " +
+	"7d or 2w3d, a date like 2024-05-01, a date range like 2024-05-01..2024-05-07 or one of the named " +
+	"periods today, yesterday, this-week, last-week, this-month, last-month, last-session or this-season")
+
+// ErrWindowTooLong is returned if a time window duration exceeds WindowMaxDuration
+var ErrWindowTooLong = errors.New("the time window must not be longer than 366 days")
+
+// WindowMaxDuration is the maximum duration of a time window that is given as duration
+const WindowMaxDuration = time.Hour * 24 * 366
+
+// DateLayout is the layout of absolute dates in time windows
+const DateLayout = "2006-01-02"
+
+// List of named periods of a time window
+const (
+	PeriodToday     = "today"
+	PeriodYesterday = "yesterday"
+	PeriodThisWeek  = "this-week"
+	PeriodLastWeek  = "last-week"
+	PeriodThisMonth = "this-month"
+	PeriodLastMonth = "last-month"
+)
+
+// windowDurationRE matches durations with week, day, hour and minute units, e.g. "2w3d" or "36h"
+var windowDurationRE = regexp.MustCompile(`^(?:\d{1,5}[wdhm])+$`)
+
+// windowUnits maps the units of a window duration to their time.Duration
+var windowUnits = map[byte]time.Duration{
+	'w': time.Hour * 24 * 7,
+	'd': time.Hour * 24,
+	'h': time.Hour,
+	'm': time.Minute,
+}
+
+// Window is a time window that ends at or before the time the window was parsed at
+type Window struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseWindow parses a time window that ends at the given time. The window is given as duration with
+// week, day, hour or minute units (a plain number is interpreted as hours), as absolute date or date range
+// or as named period. Dates and named periods are interpreted in the given location
+func ParseWindow(v string, now time.Time, lo *time.Location) (Window, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if lo == nil {
+		lo = time.UTC
+	}
+	n := now.In(lo)
+	td := time.Date(n.Year(), n.Month(), n.Day(), 0, 0, 0, 0, lo)
+	ws := td.AddDate(0, 0, -((int(td.Weekday()) + 6) % 7))
+	ms := time.Date(n.Year(), n.Month(), 1, 0, 0, 0, 0, lo)
+
+	switch v {
+	case PeriodToday:
+		return Window{From: td, To: now}, nil
+	case PeriodYesterday:
+		return Window{From: td.AddDate(0, 0, -1), To: td}, nil
+	case PeriodThisWeek:
+		return Window{From: ws, To: now}, nil
+	case PeriodLastWeek:
+		return Window{From: ws.AddDate(0, 0, -7), To: ws}, nil
+	case PeriodThisMonth:
+		return Window{From: ms, To: now}, nil
+	case PeriodLastMonth:
+		return Window{From: ms.AddDate(0, -1, 0), To: ms}, nil
+	}
+
+	if h, err := strconv.ParseUint(v, 10, 16); err == nil && h > 0 {
+		d := time.Duration(h) * time.Hour
+		if d > WindowMaxDuration {
+			return Window{}, ErrWindowTooLong
+		}
+		return Window{From: now.Add(-d), To: now}, nil
+	}
+	if windowDurationRE.MatchString(v) {
+		var d time.Duration
+		var nv int64
+		for _, c := range []byte(v) {
+			if c >= '0' && c <= '9' {
+				nv = nv*10 + int64(c-'0')
+				continue
+			}
+			// Every part and the total are checked against the maximum, so that the sum cannot overflow
+			if nv > int64(WindowMaxDuration/windowUnits[c]) {
+				return Window{}, ErrWindowTooLong
+			}
+			d += time.Duration(nv) * windowUnits[c]
+			if d > WindowMaxDuration {
+				return Window{}, ErrWindowTooLong
+			}
+			nv = 0
+		}
+		if d <= 0 {
+			return Window{}, ErrWindowFormat
+		}
+		return Window{From: now.Add(-d), To: now}, nil
+	}
+
+	fs, ts, ir := strings.Cut(v, "..")
+	f, err := time.ParseInLocation(DateLayout, strings.TrimSpace(fs), lo)
+	if err != nil {
+		return Window{}, ErrWindowFormat
+	}
+	w := Window{From: f, To: now}
+	if ir {
+		t, err := time.ParseInLocation(DateLayout, strings.TrimSpace(ts), lo)
+		if err != nil {
+			return Window{}, ErrWindowFormat
+		}
+		if t = t.AddDate(0, 0, 1); t.Before(now) {
+			w.To = t
+		}
+	}
+	if !w.From.Before(w.To) {
+		return Window{}, ErrWindowFormat
+	}
+	return w, nil
+}
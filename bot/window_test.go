@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	tt := []struct {
+		name string
+		v    string
+		from time.Time
+		to   time.Time
+		err  error
+	}{
+		{"plain hours", "12", now.Add(-time.Hour * 12), now, nil},
+		{"hours", "36h", now.Add(-time.Hour * 36), now, nil},
+		{"minutes", "90m", now.Add(-time.Minute * 90), now, nil},
+		{"weeks and days", "2w3d", now.AddDate(0, 0, -17), now, nil},
+		{"upper case with spaces", " 7D ", now.AddDate(0, 0, -7), now, nil},
+		{"today", PeriodToday, day(2024, 5, 15), now, nil},
+		{"yesterday", PeriodYesterday, day(2024, 5, 14), day(2024, 5, 15), nil},
+		{"this week", PeriodThisWeek, day(2024, 5, 13), now, nil},
+		{"last week", PeriodLastWeek, day(2024, 5, 6), day(2024, 5, 13), nil},
+		{"this month", PeriodThisMonth, day(2024, 5, 1), now, nil},
+		{"last month", PeriodLastMonth, day(2024, 4, 1), day(2024, 5, 1), nil},
+		{"date", "2024-05-01", day(2024, 5, 1), now, nil},
+		{"date range", "2024-05-01..2024-05-07", day(2024, 5, 1), day(2024, 5, 8), nil},
+		{"date range until today", "2024-05-01..2024-05-15", day(2024, 5, 1), now, nil},
+		{"maximum duration", "366d", now.AddDate(0, 0, -366), now, nil},
+		{"maximum plain hours", "8784", now.Add(-WindowMaxDuration), now, nil},
+		{"too many plain hours", "8785", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"too many days", "367d", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"too many weeks", "53w", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"sum too long", "52w3d", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"overflowing weeks", "99999w", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"negative overflow", "20000w", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"overflowing sum", "99999w99999w99999w", time.Time{}, time.Time{}, ErrWindowTooLong},
+		{"zero duration", "0d", time.Time{}, time.Time{}, ErrWindowFormat},
+		{"unknown unit", "3y", time.Time{}, time.Time{}, ErrWindowFormat},
+		{"future date", "2024-06-01", time.Time{}, time.Time{}, ErrWindowFormat},
+		{"reversed range", "2024-05-07..2024-05-01", time.Time{}, time.Time{}, ErrWindowFormat},
+		{"garbage", "soon", time.Time{}, time.Time{}, ErrWindowFormat},
+		{"empty", "", time.Time{}, time.Time{}, ErrWindowFormat},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := ParseWindow(tc.v, now, time.UTC)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("ParseWindow(%q) failed, expected error: %s, got: %v", tc.v, tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWindow(%q) failed: %s", tc.v, err)
+			}
+			if !w.From.Equal(tc.from) || !w.To.Equal(tc.to) {
+				t.Errorf("ParseWindow(%q) failed, expected: %s - %s, got: %s - %s", tc.v, tc.from, tc.to,
+					w.From, w.To)
+			}
+		})
+	}
+}
+
+func TestParseWindow_location(t *testing.T) {
+	lo := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2024, 5, 15, 23, 30, 0, 0, time.UTC)
+	w, err := ParseWindow(PeriodToday, now, lo)
+	if err != nil {
+		t.Fatalf("ParseWindow failed: %s", err)
+	}
+	if ex := time.Date(2024, 5, 16, 0, 0, 0, 0, lo); !w.From.Equal(ex) {
+		t.Errorf("ParseWindow failed, expected window to start at: %s, got: %s", ex, w.From)
+	}
+}
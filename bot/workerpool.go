@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// ErrJobSkipped should be returned by a UserJobFunc if the job was not executed for the given user.
+// Errors about a missing, expired or invalid RAT cookie are counted as skipped as well
+var ErrJobSkipped = errors.New("job skipped for user")
+
+// UserJobFunc is a job that is executed for a single user by the worker pool
+type UserJobFunc func(u *model.User) error
+
+// JobReport represents the result of a single run of a per-user job
+type JobReport struct {
+	Job       string
+	StartTime time.Time
+	EndTime   time.Time
+	Succeeded int
+	Skipped   int
+	Failed    int
+}
+
+// String satisfies the fmt.Stringer interface for the JobReport type
+func (r JobReport) String() string {
+	return fmt.Sprintf("%s finished in %s: %d succeeded, %d skipped, %d failed", r.Job,
+		r.EndTime.Sub(r.StartTime), r.Succeeded, r.Skipped, r.Failed)
+}
+
+// rateLimiter spaces out calls to wait by at least the given interval across all workers
+type rateLimiter struct {
+	c  Clock
+	iv time.Duration
+	mu sync.Mutex
+	nx time.Time
+}
+
+// wait blocks until the next call is allowed by the rateLimiter
+func (rl *rateLimiter) wait() {
+	rl.mu.Lock()
+	n := rl.c.Now()
+	if rl.nx.After(n) {
+		d := rl.nx.Sub(n)
+		rl.nx = rl.nx.Add(rl.iv)
+		rl.mu.Unlock()
+		rl.c.Sleep(d)
+		return
+	}
+	rl.nx = n.Add(rl.iv)
+	rl.mu.Unlock()
+}
+
+// RunUserJob executes the given job for all users that have a RAT cookie stored which expires after
+// the given time. The users are fetched page-wise from the DB and processed by a pool of workers under
+// a global rate limit. The resulting JobReport is logged and returned
+func (b *Bot) RunUserJob(n string, t time.Time, f UserJobFunc) (JobReport, error) {
+	ll := b.Log.With().Str("context", "bot.RunUserJob").Str("job", n).Logger()
+	jr := JobReport{Job: n, StartTime: b.clock.Now()}
+
+	ps := b.Config.Worker.PoolSize
+	if ps <= 0 {
+		ps = 1
+	}
+	pl := b.Config.Worker.PageSize
+	if pl <= 0 {
+		pl = 100
+	}
+	rl := &rateLimiter{c: b.clock, iv: b.Config.Worker.Interval}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	uc := make(chan *model.User)
+	for w := 0; w < ps; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range uc {
+				rl.wait()
+				err := f(u)
+				mu.Lock()
+				switch {
+				case err == nil:
+					jr.Succeeded++
+				case errors.Is(err, ErrJobSkipped), errors.Is(err, ErrRATCookieInvalid),
+					errors.Is(err, ErrRATCookieExpired), errors.Is(err, ErrUserHasNoRATCookie):
+					jr.Skipped++
+					ll.Debug().Msgf("skipped user %d: %s", u.ID, err)
+				default:
+					jr.Failed++
+					ll.Error().Msgf("failed to process user %d: %s", u.ID, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var a int64
+	var err error
+	for {
+		var ul []*model.User
+		ul, err = b.Model.User.GetUsersWithRATCookie(a, pl, t)
+		if err != nil {
+			err = fmt.Errorf("failed to retrieve user list from DB: %w", err)
+			break
+		}
+		for _, u := range ul {
+			uc <- u
+		}
+		if len(ul) < pl {
+			break
+		}
+		a = ul[len(ul)-1].ID
+	}
+	close(uc)
+	wg.Wait()
+
+	jr.EndTime = b.clock.Now()
+	ll.Info().Msg(jr.String())
+	return jr, err
+}
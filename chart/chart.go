@@ -0,0 +1,248 @@
+// Package chart renders simple line charts as PNG images without any external services
+package chart
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Default dimensions of a chart
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 400
+)
+
+// ErrNoData is returned if none of the series of a chart has any data points
+var ErrNoData = errors.New("no data points to draw")
+
+// List of chart colors. The background matches the dark theme of Discord
+var (
+	ColorBackground = color.RGBA{R: 0x2b, G: 0x2d, B: 0x31, A: 0xff}
+	ColorGrid       = color.RGBA{R: 0x3f, G: 0x41, B: 0x47, A: 0xff}
+	ColorText       = color.RGBA{R: 0xdb, G: 0xde, B: 0xe1, A: 0xff}
+	Palette         = []color.RGBA{
+		{R: 0xf1, G: 0xc4, B: 0x0f, A: 0xff},
+		{R: 0x34, G: 0x98, B: 0xdb, A: 0xff},
+		{R: 0x2e, G: 0xcc, B: 0x71, A: 0xff},
+		{R: 0xe7, G: 0x4c, B: 0x3c, A: 0xff},
+		{R: 0x9b, G: 0x59, B: 0xb6, A: 0xff},
+		{R: 0xe6, G: 0x7e, B: 0x22, A: 0xff},
+	}
+)
+
+// Point is a single data point of a Series
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a named list of data points, ordered by time
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// LineChart is a time-based line chart with one line per Series
+type LineChart struct {
+	Width   int
+	Height  int
+	From    time.Time
+	To      time.Time
+	Series  []Series
+	YFormat func(float64) string
+	XFormat func(time.Time) string
+}
+
+// plot area margins
+const (
+	marginLeft   = 80
+	marginRight  = 20
+	marginTop    = 36
+	marginBottom = 30
+	yTicks       = 5
+	xTicks       = 5
+)
+
+// Render draws the chart and writes it as PNG image to the given io.Writer
+func (c *LineChart) Render(w io.Writer) error {
+	if c.Width <= 0 {
+		c.Width = DefaultWidth
+	}
+	if c.Height <= 0 {
+		c.Height = DefaultHeight
+	}
+	if c.YFormat == nil {
+		c.YFormat = func(v float64) string { return formatFloat(v) }
+	}
+	if c.XFormat == nil {
+		c.XFormat = func(t time.Time) string { return t.Format("Jan 02") }
+	}
+
+	lo, hi, ok := c.valueRange()
+	if !ok {
+		return ErrNoData
+	}
+	st := niceStep((hi - lo) / (yTicks - 1))
+	lo = math.Floor(lo/st) * st
+	hi = math.Ceil(hi/st) * st
+	if hi-lo < st {
+		hi = lo + st
+	}
+	if !c.To.After(c.From) {
+		c.To = c.From.Add(time.Second)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: ColorBackground}, image.Point{}, draw.Src)
+	pa := image.Rect(marginLeft, marginTop, c.Width-marginRight, c.Height-marginBottom)
+
+	// Grid and axis labels
+	for v := lo; v <= hi+st/2; v += st {
+		y := pa.Max.Y - int(float64(pa.Dy())*(v-lo)/(hi-lo))
+		line(img, pa.Min.X, y, pa.Max.X, y, ColorGrid, 1)
+		l := c.YFormat(v)
+		text(img, pa.Min.X-8-textWidth(l), y+4, l, ColorText)
+	}
+	for n := 0; n < xTicks; n++ {
+		t := c.From.Add(time.Duration(float64(c.To.Sub(c.From)) * float64(n) / (xTicks - 1)))
+		x := pa.Min.X + int(float64(pa.Dx())*float64(n)/(xTicks-1))
+		line(img, x, pa.Min.Y, x, pa.Max.Y, ColorGrid, 1)
+		l := c.XFormat(t)
+		lx := x - textWidth(l)/2
+		if lx+textWidth(l) > c.Width {
+			lx = c.Width - textWidth(l) - 2
+		}
+		text(img, lx, pa.Max.Y+18, l, ColorText)
+	}
+
+	// Series and legend
+	lx := marginLeft
+	for n, s := range c.Series {
+		co := Palette[n%len(Palette)]
+		var px, py int
+		for pn, p := range s.Points {
+			x := pa.Min.X + int(float64(pa.Dx())*float64(p.Time.Sub(c.From))/float64(c.To.Sub(c.From)))
+			y := pa.Max.Y - int(float64(pa.Dy())*(p.Value-lo)/(hi-lo))
+			if pn > 0 {
+				line(img, px, py, x, y, co, 2)
+			}
+			if len(s.Points) == 1 {
+				draw.Draw(img, image.Rect(x-3, y-3, x+4, y+4), &image.Uniform{C: co}, image.Point{}, draw.Src)
+			}
+			px, py = x, y
+		}
+		draw.Draw(img, image.Rect(lx, 12, lx+12, 24), &image.Uniform{C: co}, image.Point{}, draw.Src)
+		text(img, lx+18, 22, s.Name, ColorText)
+		lx += 18 + textWidth(s.Name) + 24
+	}
+
+	return png.Encode(w, img)
+}
+
+// valueRange returns the minimum and maximum value of all data points
+func (c *LineChart) valueRange() (float64, float64, bool) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			lo = math.Min(lo, p.Value)
+			hi = math.Max(hi, p.Value)
+		}
+	}
+	if math.IsInf(lo, 0) {
+		return 0, 0, false
+	}
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	return lo, hi, true
+}
+
+// niceStep rounds the given step of the value axis to 1, 2 or 5 times a power of ten
+func niceStep(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	e := math.Pow(10, math.Floor(math.Log10(v)))
+	switch f := v / e; {
+	case f <= 1:
+		return e
+	case f <= 2:
+		return 2 * e
+	case f <= 5:
+		return 5 * e
+	default:
+		return 10 * e
+	}
+}
+
+// line draws a line of the given thickness between two points
+func line(img draw.Image, x0, y0, x1, y1 int, c color.Color, th int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		for ox := 0; ox < th; ox++ {
+			for oy := 0; oy < th; oy++ {
+				img.Set(x0+ox, y0+oy, c)
+			}
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// text draws the given string with its baseline at the given point
+func text(img draw.Image, x, y int, s string, c color.Color) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// textWidth returns the width of the given string in pixels
+func textWidth(s string) int {
+	return font.MeasureString(basicfont.Face7x13, s).Round()
+}
+
+// formatFloat is the default formatter for the values of the value axis
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// abs returns the absolute value of an integer
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
+	_ "time/tzdata" // the Docker image has no zoneinfo database
 
 	"github.com/rs/zerolog"
 
 	"github.com/wneessen/arrgo/bot"
 	"github.com/wneessen/arrgo/config"
+	"github.com/wneessen/arrgo/crypto"
 )
 
 // CLIFlags represents the struct that is used to handle CLI flags
@@ -20,6 +26,10 @@ type CLIFlags struct {
 	m bool   // Run in SQL migration mode
 	d bool   // Run in SQL downgrade mode
 	f bool   // First run
+	e string // Path to the gateway event recording file
+	p string // Path to a gateway event recording that should be replayed
+	s string // Path to the fake SoT API responses for the replay mode
+	b string // Name of the database used in replay mode
 }
 
 func main() {
@@ -35,6 +45,13 @@ func main() {
 	flag.BoolVar(&cf.d, "downgrade", false, "Execute SQL downgrade migrations before "+
 		"starting the bot")
 	flag.BoolVar(&cf.f, "firstrun", false, "Execute first-run tasks during startup")
+	flag.StringVar(&cf.e, "record", "", "Record incoming gateway events to the given JSONL file")
+	flag.StringVar(&cf.p, "replay", "", "Replay the given JSONL gateway event recording against a "+
+		"fake Discord session and exit")
+	flag.StringVar(&cf.s, "replay-sot", "", "JSON file with fake Sea of Thieves API responses for the "+
+		"replay mode")
+	flag.StringVar(&cf.b, "replay-db", "", "Name of the database that is used in replay mode. It has to "+
+		"differ from the database of the config file")
 	flag.Parse()
 
 	// Read/Parse config
@@ -69,8 +86,41 @@ func main() {
 	ll := l.With().Str("context", "main").Logger()
 	ll.Debug().Msg("Starting up...")
 
-	b, err := bot.New(l, &c)
+	// Replay a gateway event recording if requested
+	if cf.p != "" {
+		if err := replayEvents(&c, l, cf.p, cf.s, cf.b); err != nil {
+			ll.Error().Msgf("failed to replay gateway events: %s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	bo := []bot.Option{bot.WithLogger(l)}
+	if cf.e != "" {
+		rf, err := os.OpenFile(cf.e, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			ll.Error().Msgf("failed to open gateway event recording file: %s", err)
+			os.Exit(1)
+		}
+		defer func() {
+			_ = rf.Close()
+		}()
+		bo = append(bo, bot.WithEventRecorder(bot.NewEventRecorder(rf)))
+	}
+	b, err := bot.New(&c, bo...)
 	if err != nil {
+		// We require a global encryption key
+		if errors.Is(err, bot.ErrInvalidEncryptionKey) {
+			ll.Warn().Msgf("no/invalid encryption key in configuration file... generating key...")
+			cs, err := crypto.RandomStringSecure(config.CryptoKeyLen, true, false)
+			if err != nil {
+				ll.Error().Msgf("failed to generate encryption key: %s", err)
+				os.Exit(1)
+			}
+			ll.Info().Msg("encryption key generated... please add the following key to your config...")
+			ll.Info().Msgf(`enc_key = "%s"`, cs)
+			os.Exit(0)
+		}
 		ll.Error().Msgf("failed to initialize bot: %s", err)
 		os.Exit(1)
 	}
@@ -111,7 +161,10 @@ func main() {
 		ll.Warn().Msg("Please start the Bot using the -migrate flag to update the database")
 	}
 
-	if err := b.Run(); err != nil {
+	// Run the bot until we receive a termination signal
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGABRT)
+	defer cancel()
+	if err := b.Run(ctx); err != nil {
 		ll.Error().Msgf("failed to run bot: %s", err)
 	}
 }
@@ -0,0 +1,18 @@
+//go:build !replay
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/wneessen/arrgo/config"
+)
+
+// replayEvents is not available in release builds, so that the fake Discord session and Sea of Thieves
+// API are not linked into the binary. Build ArrGo with the "replay" build tag to enable the replay mode
+func replayEvents(_ *config.Config, _ zerolog.Logger, _, _, _ string) error {
+	return fmt.Errorf("the replay mode is not available in this build. Please build ArrGo with " +
+		"the \"replay\" build tag")
+}
@@ -0,0 +1,70 @@
+//go:build replay
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/wneessen/arrgo/bot"
+	"github.com/wneessen/arrgo/config"
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/replay"
+)
+
+// replayEvents replays a gateway event recording against a fake Discord session and reports
+// what the bot would have sent. The replay runs against the given database instead of the database
+// of the config file, so that it does not change the production data
+func replayEvents(c *config.Config, l zerolog.Logger, p, sp, dn string) error {
+	ll := l.With().Str("context", "main.replayEvents").Logger()
+	if dn == "" || dn == c.DB.Database {
+		return fmt.Errorf("the replay mode requires a dedicated database. Please provide it via -replay-db")
+	}
+	rc := *c
+	rc.DB.Database = dn
+	db, err := (&bot.Bot{}).OpenDB(&rc)
+	if err != nil {
+		return fmt.Errorf("failed to open replay database: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	r, err := replay.New(&rc, model.New(db, &rc), bot.WithLogger(l))
+	if err != nil {
+		return err
+	}
+	if sp != "" {
+		if err := r.SoT.LoadFile(sp); err != nil {
+			return err
+		}
+	}
+	rf, err := os.Open(p)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer func() {
+		_ = rf.Close()
+	}()
+	n, err := r.Replay(rf)
+	if err != nil {
+		return err
+	}
+	r.Drain(time.Hour)
+
+	ll.Info().Msgf("replayed %d gateway events", n)
+	for _, m := range r.Session.Messages() {
+		ll.Info().Str("channel_id", m.ChannelID).Str("user_id", m.UserID).Bool("dm", m.IsDM()).
+			Interface("embeds", m.Embeds).Msgf("message sent: %s", m.Content)
+	}
+	for _, e := range r.Session.InteractionEdits() {
+		ll.Info().Str("interaction_id", e.Interaction.ID).Interface("embeds", e.Embeds).
+			Msgf("interaction response edited: %s", e.Content)
+	}
+	for _, u := range r.SoT.Requests() {
+		ll.Info().Msgf("SoT API requested: %s", u)
+	}
+	return nil
+}
@@ -49,9 +49,27 @@ type Config struct {
 		TRUpdate time.Duration `fig:"traderoutes_update" default:"12h"`
 		USUpdate time.Duration `fig:"userstats_update" default:"6h"`
 		URUpdate time.Duration `fig:"userrep_update" default:"24h"`
-		RCCheck  time.Duration `fig:"ratcookie_check" default:"6h"`
+		RCCheck  time.Duration `fig:"ratcookie_check" default:"30m"`
 		DDUpdate time.Duration `fig:"dailydeed_update" default:"24h"`
 		ULUpdate time.Duration `fig:"userledger_update" default:"6h"`
+		UAUpdate time.Duration `fig:"userachievement_update" default:"12h"`
+		SPUpdate time.Duration `fig:"seasonprogress_update" default:"6h"`
+		DRCheck  time.Duration `fig:"deedreminder_check" default:"5m"`
+		RTRun    time.Duration `fig:"retention_run" default:"24h"`
+		NFFlush  time.Duration `fig:"notification_flush" default:"1m"`
+	}
+	Reminder struct {
+		Stages    string        `fig:"stages" default:"24h,6h,1h"`
+		SwiftDeed time.Duration `fig:"swift_deed" default:"1h"`
+	}
+	Worker struct {
+		PoolSize int           `fig:"pool_size" default:"4"`
+		Interval time.Duration `fig:"req_interval" default:"500ms"`
+		PageSize int           `fig:"page_size" default:"100"`
+	}
+	Retention struct {
+		RawDays    int `fig:"raw_days"`
+		HourlyDays int `fig:"hourly_days"`
 	}
 	confPath string
 	confFile string
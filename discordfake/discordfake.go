@@ -0,0 +1,426 @@
+// Package discordfake provides a recording fake of the Discord API used by ArrGo. It does not
+// talk to Discord at all, but captures everything the bot would have sent, so that it can be
+// inspected afterwards
+package discordfake
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultBotUserID is the user/application ID of the bot user of a new fake Session
+const DefaultBotUserID = "100000000000000000"
+
+// List of fake Session errors
+var (
+	// ErrUnknownUser is returned if a user is requested that has not been added to the fake Session
+	ErrUnknownUser = errors.New("unknown discord user")
+
+	// ErrUnknownMember is returned if a guild member is requested that has not been added to the fake Session
+	ErrUnknownMember = errors.New("unknown guild member")
+)
+
+// Session is a recording fake of the discordgo.Session
+type Session struct {
+	BotUser *discordgo.User
+
+	mu        sync.Mutex
+	cid       int64
+	commands  map[string]*discordgo.ApplicationCommand
+	dms       map[string]string
+	edits     []InteractionEdit
+	handlers  []interface{}
+	members   map[string]*discordgo.Member
+	messages  []Message
+	open      bool
+	perms     map[string]int64
+	responses []InteractionResponse
+	users     map[string]*discordgo.User
+}
+
+// Message represents a message that was sent to a channel or as DM via the fake Session
+type Message struct {
+	ChannelID string
+	UserID    string
+	Content   string
+	Embeds    []*discordgo.MessageEmbed
+	Files     []File
+}
+
+// File represents a file attachment of a Message. The content of the attachment is read when the
+// Message is sent
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// InteractionResponse represents the initial response to an interaction
+type InteractionResponse struct {
+	Interaction *discordgo.Interaction
+	Response    *discordgo.InteractionResponse
+}
+
+// InteractionEdit represents an edit of a (deferred) interaction response
+type InteractionEdit struct {
+	Interaction *discordgo.Interaction
+	Content     string
+	Embeds      []*discordgo.MessageEmbed
+	Components  []discordgo.MessageComponent
+	Files       []File
+}
+
+// New returns a new fake Session
+func New() *Session {
+	bu := &discordgo.User{ID: DefaultBotUserID, Username: "ArrGo", Bot: true}
+	return &Session{
+		BotUser:  bu,
+		commands: make(map[string]*discordgo.ApplicationCommand),
+		dms:      make(map[string]string),
+		members:  make(map[string]*discordgo.Member),
+		perms:    make(map[string]int64),
+		users:    map[string]*discordgo.User{bu.ID: bu},
+	}
+}
+
+// IsDM returns true if the Message was sent as direct message
+func (m Message) IsDM() bool {
+	return m.UserID != ""
+}
+
+// AddUser adds a user to the fake Session, so it can be looked up via User()
+func (s *Session) AddUser(u *discordgo.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[u.ID] = u
+}
+
+// AddMember adds a guild member to the fake Session, so it can be looked up via GuildMember()
+func (s *Session) AddMember(m *discordgo.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members[m.GuildID+"/"+m.User.ID] = m
+	s.users[m.User.ID] = m.User
+}
+
+// SetChannelPermissions sets the permissions of a user in a channel, as returned by UserChannelPermissions()
+func (s *Session) SetChannelPermissions(uid, cid string, p int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.perms[cid+"/"+uid] = p
+}
+
+// AddHandler records the handler. Events are not dispatched by the fake Session
+func (s *Session) AddHandler(h interface{}) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, h)
+	return func() {}
+}
+
+// AddHandlerOnce records the handler. Events are not dispatched by the fake Session
+func (s *Session) AddHandlerOnce(h interface{}) func() {
+	return s.AddHandler(h)
+}
+
+// Handlers returns the number of handlers that have been added to the fake Session
+func (s *Session) Handlers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.handlers)
+}
+
+// Open marks the fake Session as open
+func (s *Session) Open() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.open = true
+	return nil
+}
+
+// Close marks the fake Session as closed
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.open = false
+	return nil
+}
+
+// IsOpen returns true if the fake Session has been opened and not closed since
+func (s *Session) IsOpen() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open
+}
+
+// User returns a previously added user. "@me" returns the BotUser
+func (s *Session) User(uid string, _ ...discordgo.RequestOption) (*discordgo.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if uid == "@me" {
+		return s.BotUser, nil
+	}
+	u, ok := s.users[uid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownUser, uid)
+	}
+	return u, nil
+}
+
+// GuildMember returns a guild member that has been added to the fake Session
+func (s *Session) GuildMember(gid, uid string, _ ...discordgo.RequestOption) (*discordgo.Member, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.members[gid+"/"+uid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnknownMember, gid, uid)
+	}
+	return m, nil
+}
+
+// UserChannelPermissions returns the permissions of the user in the channel that have been set via
+// SetChannelPermissions(). Users without permissions set have no permissions in the channel
+func (s *Session) UserChannelPermissions(uid, cid string, _ ...discordgo.RequestOption) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.perms[cid+"/"+uid], nil
+}
+
+// GuildMembersSearch returns at most l of the added members of the given guild whose username or nickname
+// starts with the given query (case-insensitive), ordered by user ID
+func (s *Session) GuildMembersSearch(gid, q string, l int, _ ...discordgo.RequestOption) ([]*discordgo.Member,
+	error,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q = strings.ToLower(q)
+	var ml []*discordgo.Member
+	for _, m := range s.members {
+		if m.GuildID != gid {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(m.User.Username), q) || strings.HasPrefix(strings.ToLower(m.Nick), q) {
+			ml = append(ml, m)
+		}
+	}
+	sort.Slice(ml, func(i, j int) bool { return ml[i].User.ID < ml[j].User.ID })
+	if l > 0 && len(ml) > l {
+		ml = ml[:l]
+	}
+	return ml, nil
+}
+
+// UserChannelCreate returns a DM channel for the given user. Messages sent to this channel are
+// recorded as DMs
+func (s *Session) UserChannelCreate(rid string, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cid := fmt.Sprintf("dm-%s", rid)
+	s.dms[cid] = rid
+	return &discordgo.Channel{ID: cid, Type: discordgo.ChannelTypeDM}, nil
+}
+
+// ChannelMessageSend records a text message sent to a channel
+func (s *Session) ChannelMessageSend(cid string, c string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.record(cid, c, nil), nil
+}
+
+// ChannelMessageSendEmbed records an embed sent to a channel
+func (s *Session) ChannelMessageSendEmbed(cid string, e *discordgo.MessageEmbed,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	return s.record(cid, "", []*discordgo.MessageEmbed{e}), nil
+}
+
+// ChannelMessageSendComplex records a message with optional embeds and file attachments sent to a channel
+func (s *Session) ChannelMessageSendComplex(cid string, d *discordgo.MessageSend,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	var fl []File
+	for _, f := range d.Files {
+		fd, err := io.ReadAll(f.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %w", f.Name, err)
+		}
+		fl = append(fl, File{Name: f.Name, ContentType: f.ContentType, Data: fd})
+	}
+	el := d.Embeds
+	if d.Embed != nil {
+		el = append(el, d.Embed)
+	}
+	return s.record(cid, d.Content, el, fl...), nil
+}
+
+// ApplicationCommands returns the registered application commands
+func (s *Session) ApplicationCommands(_, _ string, _ ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand,
+	error,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cl := make([]*discordgo.ApplicationCommand, 0, len(s.commands))
+	for _, c := range s.commands {
+		cl = append(cl, c)
+	}
+	return cl, nil
+}
+
+// ApplicationCommandCreate registers an application command
+func (s *Session) ApplicationCommandCreate(aid string, _ string, c *discordgo.ApplicationCommand,
+	_ ...discordgo.RequestOption,
+) (*discordgo.ApplicationCommand, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cid++
+	c.ID = fmt.Sprintf("%d", s.cid)
+	c.ApplicationID = aid
+	s.commands[c.ID] = c
+	return c, nil
+}
+
+// ApplicationCommandEdit updates a registered application command
+func (s *Session) ApplicationCommandEdit(aid, _, cid string, c *discordgo.ApplicationCommand,
+	_ ...discordgo.RequestOption,
+) (*discordgo.ApplicationCommand, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.ID = cid
+	c.ApplicationID = aid
+	s.commands[cid] = c
+	return c, nil
+}
+
+// ApplicationCommandDelete removes a registered application command
+func (s *Session) ApplicationCommandDelete(_, _, cid string, _ ...discordgo.RequestOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.commands, cid)
+	return nil
+}
+
+// InteractionRespond records the initial response to an interaction
+func (s *Session) InteractionRespond(i *discordgo.Interaction, r *discordgo.InteractionResponse,
+	_ ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, InteractionResponse{Interaction: i, Response: r})
+	return nil
+}
+
+// InteractionResponseEdit records the edit of an interaction response
+func (s *Session) InteractionResponseEdit(i *discordgo.Interaction, e *discordgo.WebhookEdit,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ie := InteractionEdit{Interaction: i}
+	if e.Content != nil {
+		ie.Content = *e.Content
+	}
+	if e.Embeds != nil {
+		ie.Embeds = *e.Embeds
+	}
+	if e.Components != nil {
+		ie.Components = *e.Components
+	}
+	for _, f := range e.Files {
+		fd, err := io.ReadAll(f.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %w", f.Name, err)
+		}
+		ie.Files = append(ie.Files, File{Name: f.Name, ContentType: f.ContentType, Data: fd})
+	}
+	s.edits = append(s.edits, ie)
+	return &discordgo.Message{ChannelID: i.ChannelID, Content: ie.Content, Embeds: ie.Embeds}, nil
+}
+
+// Messages returns all messages that have been sent via the fake Session
+func (s *Session) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ml := make([]Message, len(s.messages))
+	copy(ml, s.messages)
+	return ml
+}
+
+// ChannelMessages returns all messages that have been sent to the given channel
+func (s *Session) ChannelMessages(cid string) []Message {
+	var ml []Message
+	for _, m := range s.Messages() {
+		if m.ChannelID == cid {
+			ml = append(ml, m)
+		}
+	}
+	return ml
+}
+
+// DirectMessages returns all DMs that have been sent to the given user
+func (s *Session) DirectMessages(uid string) []Message {
+	var ml []Message
+	for _, m := range s.Messages() {
+		if m.IsDM() && m.UserID == uid {
+			ml = append(ml, m)
+		}
+	}
+	return ml
+}
+
+// SentEmbeds returns all embeds that have been sent to channels or as DM
+func (s *Session) SentEmbeds() []*discordgo.MessageEmbed {
+	var el []*discordgo.MessageEmbed
+	for _, m := range s.Messages() {
+		el = append(el, m.Embeds...)
+	}
+	return el
+}
+
+// InteractionResponses returns all recorded initial interaction responses
+func (s *Session) InteractionResponses() []InteractionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rl := make([]InteractionResponse, len(s.responses))
+	copy(rl, s.responses)
+	return rl
+}
+
+// InteractionEdits returns all recorded interaction response edits
+func (s *Session) InteractionEdits() []InteractionEdit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el := make([]InteractionEdit, len(s.edits))
+	copy(el, s.edits)
+	return el
+}
+
+// LastInteractionEdit returns the most recent interaction response edit and false if there is none
+func (s *Session) LastInteractionEdit() (InteractionEdit, bool) {
+	el := s.InteractionEdits()
+	if len(el) == 0 {
+		return InteractionEdit{}, false
+	}
+	return el[len(el)-1], true
+}
+
+// Reset removes all recorded messages and interaction responses
+func (s *Session) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+	s.responses = nil
+	s.edits = nil
+}
+
+// record stores a sent message
+func (s *Session) record(cid, c string, el []*discordgo.MessageEmbed, fl ...File) *discordgo.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := Message{ChannelID: cid, UserID: s.dms[cid], Content: c, Embeds: el, Files: fl}
+	s.messages = append(s.messages, m)
+	return &discordgo.Message{ChannelID: cid, Content: c, Embeds: el}
+}
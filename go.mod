@@ -1,6 +1,6 @@
 module github.com/wneessen/arrgo
 
-go 1.18
+go 1.22.0
 
 require (
 	github.com/bwmarrin/discordgo v0.27.1
@@ -8,6 +8,7 @@ require (
 	github.com/kkyr/fig v0.4.0
 	github.com/lib/pq v1.10.9
 	github.com/rs/zerolog v1.33.0
+	golang.org/x/image v0.18.0
 	golang.org/x/text v0.22.0
 )
 
@@ -0,0 +1,647 @@
+package locale
+
+// deCommands holds the German slash command names
+var deCommands = map[string]string{
+	"time":          "zeit",
+	"uptime":        "laufzeit",
+	"config":        "konfiguration",
+	"override":      "überschreiben",
+	"register":      "registrieren",
+	"unregister":    "abmelden",
+	"mydata":        "meinedaten",
+	"language":      "sprache",
+	"settings":      "einstellungen",
+	"graph":         "diagramm",
+	"reminders":     "erinnerungen",
+	"notifications": "benachrichtigungen",
+	"achievement":   "erfolg",
+	"achievements":  "erfolge",
+	"season":        "saison",
+	"balance":       "kontostand",
+	"traderoutes":   "handelsrouten",
+	"overview":      "übersicht",
+	"compare":       "vergleichen",
+	"versus":        "duell",
+	"goal":          "ziel",
+	"sell":          "verkaufen",
+	"subscribe":     "abonnieren",
+
+	"Compare SoT stats": "SoT-Statistiken vergleichen",
+	"dailydeeds":        "tagesaufgaben",
+	"ledger":            "rangliste",
+	"reputation":        "ruf",
+	"allegiance":        "gesinnung",
+}
+
+// de holds the German translations
+var de = map[string]string{
+	// Errors
+	"I am sorry, but I was not able to process your request: %s": "Es tut mir leid, aber ich konnte deine " +
+		"Anfrage nicht bearbeiten: %s",
+	"Oh no! Something went wrong!": "Oh nein! Da ist etwas schiefgelaufen!",
+	"your user is not registered with the bot. Please use the **/register** command to activate the full " +
+		"feature set first": "dein Benutzer ist nicht beim Bot registriert. Bitte nutze zuerst den Befehl " +
+		"**/registrieren**, um alle Funktionen freizuschalten",
+	"user is not registered": "dein Benutzer ist nicht registriert. Bitte nutze zuerst den Befehl **/registrieren**",
+	"you have not provided a Sea of Thieves authentication token. Please store your cookie with the " +
+		"**/setrat** command first": "du hast noch kein Sea of Thieves Authentifizierungstoken hinterlegt. " +
+		"Bitte speichere dein Cookie zuerst mit dem Befehl **/setrat**",
+	"your Sea of Thieves authentication token is expired. Please use the **/setrat** command to update " +
+		"your token": "dein Sea of Thieves Authentifizierungstoken ist abgelaufen. Bitte aktualisiere es mit " +
+		"dem Befehl **/setrat**",
+	"your Sea of Thieves authentication token has been rejected by the Sea of Thieves API. Please use the " +
+		"**/setrat** command to update your token": "dein Sea of Thieves Authentifizierungstoken wurde von " +
+		"der Sea of Thieves API abgelehnt. Bitte aktualisiere es mit dem Befehl **/setrat**",
+	"the provided Sea of Thieves authentication cookie could not be parsed. Please provide the string of " +
+		"the SoT-RAT-Extractor, the rat= cookie value or the full Cookie header": "das angegebene Sea of " +
+		"Thieves Authentifizierungscookie konnte nicht gelesen werden. Bitte gib den Text des " +
+		"SoT-RAT-Extractor, den Wert des rat= Cookies oder den vollständigen Cookie-Header an",
+	"the provided Sea of Thieves authentication cookie has been rejected by the Sea of Thieves API. Please " +
+		"log in to the Sea of Thieves website again and provide a new cookie": "das angegebene Sea of " +
+		"Thieves Authentifizierungscookie wurde von der Sea of Thieves API abgelehnt. Bitte melde dich " +
+		"erneut auf der Sea of Thieves Webseite an und gib ein neues Cookie an",
+	"failed to fetch Sea of Thieves content, due to being unauthorized": "die Sea of Thieves Inhalte " +
+		"konnten nicht abgerufen werden, da der Zugriff verweigert wurde",
+	"this command is only accessible for admin-user": "dieser Befehl steht nur Administratoren zur " +
+		"Verfügung",
+	"the guild notification settings are only accessible for admin-users": "die Benachrichtigungs" +
+		"einstellungen des Servers stehen nur Administratoren zur Verfügung",
+	"direct messages can only be configured per user": "Direktnachrichten können nur pro Benutzer " +
+		"eingestellt werden",
+	"channel and role notifications can only be configured on a guild": "Kanal- und Rollen" +
+		"benachrichtigungen können nur auf einem Server eingestellt werden",
+	"role mentions can only be configured by guild admins with /notifications guild": "Rollenerwähnungen " +
+		"können nur von Server-Administratoren mit /notifications guild eingestellt werden",
+	"you are not allowed to send messages to this channel": "du darfst in diesem Kanal keine Nachrichten " +
+		"senden",
+	"please provide the role that should be mentioned": "bitte gib die Rolle an, die erwähnt werden soll",
+	"reminders can only be delivered to a guild channel. Please use this command on a guild": "Erinnerungen " +
+		"können nur in einem Serverkanal zugestellt werden. Bitte nutze diesen Befehl auf einem Server",
+	"reminder stages need to be a comma-separated list of up to 5 durations between 1m and 336h, e.g. " +
+		"\"24h,6h,1h\"": "die Erinnerungszeitpunkte müssen eine kommagetrennte Liste von bis zu 5 " +
+		"Zeitspannen zwischen 1m und 336h sein, z. B. \"24h,6h,1h\"",
+	"quiet hours need to be given as HH:MM-HH:MM, e.g. 22:00-07:00": "Ruhezeiten müssen im Format " +
+		"HH:MM-HH:MM angegeben werden, z. B. 22:00-07:00",
+	"no trade routes found in database":          "keine Handelsrouten in der Datenbank gefunden",
+	"this trade route rotation is not known yet": "dieser Zeitraum der Handelsrouten ist noch nicht bekannt",
+	"there is no trade route for an outpost with this name": "es gibt keine Handelsroute für einen " +
+		"Außenposten mit diesem Namen",
+	"this commodity is not traded on any of the current trade routes": "diese Ware wird auf keiner der " +
+		"aktuellen Handelsrouten gehandelt",
+	"no deeds found for today in database":  "keine Tagesaufgaben für heute in der Datenbank gefunden",
+	"no SoT achievements found":             "keine Sea of Thieves Erfolge gefunden",
+	"no SoT season progress found":          "kein Sea of Thieves Saisonfortschritt gefunden",
+	"provided User pointer must not be nil": "der Benutzer konnte nicht ermittelt werden",
+
+	// General
+	"It's time, Matey!":              "Es ist Zeit, Maat!",
+	"The current bot time is: %s":    "Die aktuelle Zeit des Bots ist: %s",
+	"Forrest Gump would be proud...": "Forrest Gump wäre stolz...",
+	"I started running: %s and haven't stopped since... which means I've been running for %s now!": "Ich " +
+		"bin %s losgelaufen und habe seitdem nicht angehalten... ich laufe also schon seit %s!",
+	"Oh look! It's me!": "Oh schau! Das bin ja ich!",
+	"I am ArrBot (Version v%s)! Your Sea of Thieves themed discord bot. Nice to meet you!": "Ich bin " +
+		"ArrBot (Version v%s)! Dein Discord-Bot im Stil von Sea of Thieves. Schön, dich kennenzulernen!",
+	"Ahoy, Mateys!": "Ahoi, Maate!",
+	"I am ArrGo the Discord Pirate Lord! I just joined this nice vessel to have an an eye on you " +
+		"scallywags!": "Ich bin ArrGo, der Piratenlord von Discord! Ich bin gerade an Bord dieses feinen " +
+		"Schiffs gekommen, um ein Auge auf euch Halunken zu haben!",
+	"Avast ye!":                                "Aufgepasst!",
+	"Captain Flameheart yells at you:":         "Captain Flameheart brüllt dich an:",
+	"Language updated":                         "Sprache aktualisiert",
+	"I will respond to you in %s from now on.": "Ich antworte dir ab jetzt auf %s.",
+
+	// Display settings
+	"%s nmi":                "%s sm",
+	"%s km":                 "%s km",
+	"%s mi":                 "%s mi",
+	"Your display settings": "Deine Anzeigeeinstellungen",
+	"Distance":              "Entfernung",
+	"Numbers":               "Zahlen",
+	"Time zone":             "Zeitzone",
+	"Clock":                 "Uhrzeit",
+	"Current time":          "Aktuelle Zeit",
+	"Stats privacy":         "Sichtbarkeit der Statistiken",
+	"Public":                "Öffentlich",
+	"Private":               "Privat",
+	"Discord client":        "Discord-Client",
+	"This is how your stats and times will look like from now on.": "So sehen deine Statistiken und " +
+		"Zeiten ab jetzt aus.",
+
+	// Configuration
+	"Bot configuration updated": "Bot-Konfiguration aktualisiert",
+	"The bot will not spam the server with Captain Flameheart quotes": "Der Bot wird den Server nicht mit " +
+		"Zitaten von Captain Flameheart zuspammen",
+	"The bot will spam the server with Captain Flameheart quotes": "Der Bot wird den Server mit Zitaten " +
+		"von Captain Flameheart zuspammen",
+	"The annoucment channel for this server has been set to: <#%s>": "Der Ankündigungskanal dieses " +
+		"Servers wurde gesetzt auf: <#%s>",
+	"The bot will not announce a user's summary after they played SoT": "Der Bot wird keine Zusammenfassung " +
+		"ankündigen, nachdem ein Benutzer SoT gespielt hat",
+	"The bot will announce a user's summary after they played SoT": "Der Bot wird eine Zusammenfassung " +
+		"ankündigen, nachdem ein Benutzer SoT gespielt hat",
+	"The bot will not announce newly unlocked SoT achievements of the members": "Der Bot wird keine neu " +
+		"freigeschalteten SoT-Erfolge der Mitglieder ankündigen",
+	"The bot will announce newly unlocked SoT achievements of the members": "Der Bot wird neu " +
+		"freigeschaltete SoT-Erfolge der Mitglieder ankündigen",
+	"The bot will not announce new deeds": "Der Bot wird keine neuen Taten ankündigen",
+	"The bot will announce new deeds and remind of swift deeds shortly before they end": "Der Bot wird " +
+		"neue Taten ankündigen und kurz vor dem Ende von Blitz-Taten an sie erinnern",
+	"The bot will not announce new trade routes":         "Der Bot wird keine neuen Handelsrouten ankündigen",
+	"The bot will announce new and changed trade routes": "Der Bot wird neue und geänderte Handelsrouten ankündigen",
+	"The announcements will mention <@&%s>":              "Die Ankündigungen erwähnen <@&%s>",
+
+	// Registration and user data
+	"Welcome back!": "Willkommen zurück!",
+	"You are already registered with ArrGo. Thanks for double checking...": "Du bist bereits bei ArrGo " +
+		"registriert. Danke, dass du nochmal nachgesehen hast...",
+	"Welcome!": "Willkommen!",
+	"You have successfully registered your account and are now able to use the full feature set": "Du " +
+		"hast dein Konto erfolgreich registriert und kannst jetzt alle Funktionen nutzen",
+	"Not registered": "Nicht registriert",
+	"You are not registered with ArrGo. There is no data stored about you.": "Du bist nicht bei ArrGo " +
+		"registriert. Es sind keine Daten über dich gespeichert.",
+	"Here is all the data that ArrGo has stored about you. Secrets like your Sea of Thieves authentication " +
+		"cookie are redacted.": "Hier sind alle Daten, die ArrGo über dich gespeichert hat. Geheimnisse wie " +
+		"dein Sea of Thieves Authentifizierungscookie sind unkenntlich gemacht.",
+	"Data export sent": "Datenexport gesendet",
+	"I've sent you a DM with all the data that ArrGo has stored about you.": "Ich habe dir eine " +
+		"Direktnachricht mit allen Daten geschickt, die ArrGo über dich gespeichert hat.",
+	"Are you sure?": "Bist du sicher?",
+	"This will remove your user, your preferences, your Sea of Thieves authentication cookie and your " +
+		"complete stats, reputation and ledger history from ArrGo. This cannot be undone. If you want to " +
+		"keep a copy of your data, use **/mydata export** first.": "Dadurch werden dein Benutzer, deine " +
+		"Einstellungen, dein Sea of Thieves Authentifizierungscookie und dein kompletter Statistik-, Ruf- " +
+		"und Ranglistenverlauf aus ArrGo entfernt. Das kann nicht rückgängig gemacht werden. Wenn du eine " +
+		"Kopie deiner Daten behalten möchtest, nutze zuerst **/meinedaten export**.",
+	"Delete my data":           "Meine Daten löschen",
+	"Cancel":                   "Abbrechen",
+	"Unregistration cancelled": "Abmeldung abgebrochen",
+	"Nothing has been removed. Glad you're staying aboard!": "Es wurde nichts entfernt. Schön, dass du an " +
+		"Bord bleibst!",
+	"Farewell!": "Lebewohl!",
+	"Your user and all data stored about you have been removed from ArrGo. You can **/register** again at " +
+		"any time.": "Dein Benutzer und alle über dich gespeicherten Daten wurden aus ArrGo entfernt. Du " +
+		"kannst dich jederzeit wieder mit **/registrieren** anmelden.",
+
+	// RAT cookie
+	"Please register your user first!": "Bitte registriere zuerst deinen Benutzer!",
+	"To use the Sea of Thieves bot features, please first use the **/register** command to register your " +
+		"user with the bot": "Um die Sea of Thieves Funktionen des Bots zu nutzen, registriere deinen " +
+		"Benutzer bitte zuerst mit dem Befehl **/registrieren**",
+	"Thank you for storing/updating your Sea of Thieves authentication cookie. It expires %s (%s).": "Danke, " +
+		"dass du dein Sea of Thieves Authentifizierungscookie gespeichert hast. Es läuft %s ab (%s).",
+	"Ahoy, **%s**! %s": "Ahoi, **%s**! %s",
+	"Sea of Thieves authentication cookie stored/updated": "Sea of Thieves Authentifizierungscookie " +
+		"gespeichert",
+	"Sea of Thieves authentication cookie": "Sea of Thieves Authentifizierungscookie",
+	"How to get a new cookie":              "So bekommst du ein neues Cookie",
+	"Your SoT RAT cookie expires %s. Please use the `/setrat` command to set a new one.": "Dein SoT " +
+		"RAT-Cookie läuft %s ab. Bitte nutze den Befehl `/setrat`, um ein neues zu hinterlegen.",
+	"Your SoT RAT cookie has expired %s. Please use the `/setrat` command to set a new one.": "Dein SoT " +
+		"RAT-Cookie ist %s abgelaufen. Bitte nutze den Befehl `/setrat`, um ein neues zu hinterlegen.",
+	"Your SoT RAT cookie has been rejected by the Sea of Thieves API. I won't use it anymore. Please use " +
+		"the `/setrat` command to set a new one.": "Dein SoT RAT-Cookie wurde von der Sea of Thieves API " +
+		"abgelehnt. Ich werde es nicht mehr verwenden. Bitte nutze den Befehl `/setrat`, um ein neues zu " +
+		"hinterlegen.",
+
+	// Reminders and notifications
+	"Your RAT cookie reminder settings": "Deine Erinnerungseinstellungen für das RAT-Cookie",
+	"Remind me before expiry":           "Erinnere mich vor Ablauf",
+	"Delivery":                          "Zustellung",
+	"Your notification settings":        "Deine Benachrichtigungseinstellungen",
+	"Quiet hours":                       "Ruhezeiten",
+	"off":                               "aus",
+	"ping in <#%s>":                     "Erwähnung in <#%s>",
+	"<@&%s> mention in <#%s>":           "Erwähnung von <@&%s> in <#%s>",
+	"muted":                             "stummgeschaltet",
+	"direct message":                    "Direktnachricht",
+	"Cookie expiry":                     "Cookie-Ablauf",
+	"Voyage summary":                    "Reisezusammenfassung",
+	"Milestone":                         "Meilenstein",
+	"Subscription match":                "Abo-Treffer",
+
+	// Stats
+	"%s Gold":                 "%s Gold",
+	"%s Doubloons":            "%s Dublonen",
+	"%s Ancient Coins":        "%s Uralte Münzen",
+	"%s Kraken":               "%s Kraken",
+	"%s Megalodon":            "%s Megalodon",
+	"%s Chests":               "%s Truhen",
+	"%s Other Ships":          "%s Andere Schiffe",
+	"%s Vomitted":             "%s Übergeben",
+	"%s Distance":             "%s Strecke",
+	"%s Duration":             "%s Dauer",
+	"%s **%s** Gold":          "%s **%s** Gold",
+	"%s **%s** Doubloons":     "%s **%s** Dublonen",
+	"%s **%s** Ancient Coins": "%s **%s** Uralte Münzen",
+	"**%s** defeated":         "**%s** besiegt",
+	"**%s** encounter(s)":     "**%s** Begegnung(en)",
+	"**%s** handed in":        "**%s** abgegeben",
+	"**%s** sunk":             "**%s** versenkt",
+	"**%s** times":            "**%s** Mal",
+	"**%s** sailed":           "**%s** gesegelt",
+	"**%s** played":           "**%s** gespielt",
+	"**Current Title:** %s":   "**Aktueller Titel:** %s",
+	"Your current user statistics overview in Sea of Thieves:": "Deine aktuelle Statistikübersicht in " +
+		"Sea of Thieves:",
+	"Your current balance in Sea of Thieves:": "Dein aktueller Kontostand in Sea of Thieves:",
+	"Your Sea of Thieves stats changes: %s":   "Deine Änderungen der Sea of Thieves Statistiken: %s",
+	"None of your Sea of Thieves stats changed: %s": "Keine deiner Sea of Thieves Statistiken hat sich " +
+		"verändert: %s",
+	"Compared the snapshots of %s and %s": "Verglichen wurden die Stände von %s und %s",
+	"Last %d hours":                       "Letzte %d Stunden",
+	"Last %d days":                        "Letzte %d Tage",
+	"Today":                               "Heute",
+	"Yesterday":                           "Gestern",
+	"This week":                           "Diese Woche",
+	"Last week":                           "Letzte Woche",
+	"This month":                          "Dieser Monat",
+	"Last month":                          "Letzter Monat",
+	"Last session":                        "Letzte Sitzung",
+	"This season":                         "Diese Saison",
+	"please provide only one of the options hours, days, period or window": "bitte gib nur eine der " +
+		"Optionen hours, days, period oder window an",
+	"there are not enough stored stats snapshots within the chosen time window to compare": "im gewählten " +
+		"Zeitraum sind nicht genügend gespeicherte Statistikstände für einen Vergleich vorhanden",
+	"there is no recorded Sea of Thieves play session yet. Sessions are recorded if you share your game " +
+		"activity with Discord": "es wurde noch keine Sea of Thieves Spielsitzung aufgezeichnet. Sitzungen " +
+		"werden aufgezeichnet, wenn du deine Spielaktivität mit Discord teilst",
+	"there is no stored Sea of Thieves season progress yet. The progress is stored if you have set a " +
+		"valid RAT cookie": "es ist noch kein Sea of Thieves Saisonfortschritt gespeichert. Der Fortschritt " +
+		"wird gespeichert, wenn du ein gültiges RAT-Cookie gesetzt hast",
+	"the time window must not be longer than 366 days": "das Zeitfenster darf nicht länger als 366 Tage sein",
+	"the time window could not be parsed. Please use a duration like 12h, 7d or 2w3d, a date like " +
+		"2024-05-01, a date range like 2024-05-01..2024-05-07 or one of the named periods today, yesterday, " +
+		"this-week, last-week, this-month, last-month, last-session or this-season": "der Zeitraum konnte " +
+		"nicht verarbeitet werden. Bitte nutze eine Dauer wie 12h, 7d oder 2w3d, ein Datum wie 2024-05-01, " +
+		"einen Datumsbereich wie 2024-05-01..2024-05-07 oder einen der benannten Zeiträume today, yesterday, " +
+		"this-week, last-week, this-month, last-month, last-session oder this-season",
+	"%s vs. %s in Sea of Thieves":              "%s gegen %s in Sea of Thieves",
+	"Current stats as of %s and %s. Gains: %s": "Aktuelle Statistiken vom %s und %s. Zuwachs: %s",
+	"**%s**: %s":       "**%s**: %s",
+	"**%s**: level %s": "**%s**: Stufe %s",
+	"Kraken":           "Kraken",
+	"Megalodon":        "Megalodon",
+	"Chests":           "Truhen",
+	"Other Ships":      "Andere Schiffe",
+	"Vomitted":         "Übergeben",
+	"please choose another registered guild member to compare with": "bitte wähle ein anderes " +
+		"registriertes Servermitglied zum Vergleichen",
+	"there are no stored Sea of Thieves stats for one of the pirates yet": "für einen der Piraten sind " +
+		"noch keine Sea of Thieves Statistiken gespeichert",
+	"this pirate keeps their Sea of Thieves stats private": "dieser Pirat hält seine Sea of Thieves " +
+		"Statistiken privat",
+	"Your Sea of Thieves achievements":             "Deine Sea of Thieves Erfolge",
+	"Your Sea of Thieves achievements matching %q": "Deine Sea of Thieves Erfolge passend zu %q",
+	"No achievements found.":                       "Keine Erfolge gefunden.",
+	"**%s** (%s)\n%s\n":                            "**%s** (%s)\n%s\n",
+	"%s achievements":                              "%s Erfolge",
+	"%s unlocked a new Sea of Thieves achievement: %s": "%s hat einen neuen Sea of Thieves Erfolg " +
+		"freigeschaltet: %s",
+	"New goal added":            "Neues Ziel hinzugefügt",
+	"Your Sea of Thieves goals": "Deine Sea of Thieves Ziele",
+	"You have not set any goals yet. Use **/goal add** to set one.": "Du hast noch keine Ziele gesetzt. " +
+		"Nutze **/ziel add**, um eines zu setzen.",
+	"Goal removed":                       "Ziel entfernt",
+	"Your goal #%d has been removed.":    "Dein Ziel #%d wurde entfernt.",
+	"`%s%s` %s%% (%s / %s)":              "`%s%s` %s%% (%s / %s)",
+	"Completed: %s":                      "Erreicht: %s",
+	"Projected completion: %s":           "Voraussichtlich erreicht: %s",
+	"No progress within the last 7 days": "Kein Fortschritt in den letzten 7 Tagen",
+	"#%d %s":                             "#%d %s",
+	"%s level %s":                        "%s Stufe %s",
+	"%s: %s":                             "%s: %s",
+	"Goal reached!":                      "Ziel erreicht!",
+	"Congratulations! You reached your goal **%s**, which you have set on %s.": "Glückwunsch! Du hast " +
+		"dein Ziel **%s** erreicht, das du am %s gesetzt hast.",
+	"this list has expired. Please run the command again": "diese Liste ist abgelaufen. Bitte führe den " +
+		"Befehl erneut aus",
+	"only the pirate who requested this list can browse it": "nur der Pirat, der diese Liste angefragt " +
+		"hat, kann darin blättern",
+	"this renown tier does not exist in the current season": "dieser Ansehensrang existiert in der " +
+		"aktuellen Saison nicht",
+	"this metric can not be used for goals":            "diese Statistik kann nicht für Ziele genutzt werden",
+	"please choose the faction of the reputation goal": "bitte wähle die Fraktion des Rufziels",
+	"you have reached the maximum number of open goals. Please remove a goal first": "du hast die " +
+		"maximale Anzahl offener Ziele erreicht. Bitte entferne zuerst ein Ziel",
+	"you have already reached this goal": "du hast dieses Ziel bereits erreicht",
+	"there are no stored stats for this goal yet. Please try again later": "für dieses Ziel sind noch " +
+		"keine Statistiken gespeichert. Bitte versuche es später erneut",
+	"there is no goal with this ID. Use **/goal list** to see the IDs of your goals": "es gibt kein Ziel " +
+		"mit dieser ID. Nutze **/ziel list**, um die IDs deiner Ziele zu sehen",
+	"Subscription added": "Abo hinzugefügt",
+	"You will receive a notification whenever an update matches your subscription.": "Du erhältst eine " +
+		"Benachrichtigung, sobald eine Aktualisierung zu deinem Abo passt.",
+	"Your subscriptions": "Deine Abos",
+	"You have not subscribed to any topics yet. Use **/subscribe add** to subscribe to one.": "Du hast noch " +
+		"keine Themen abonniert. Nutze **/abonnieren add**, um eines zu abonnieren.",
+	"Subscription removed":                      "Abo entfernt",
+	"Your subscription #%d has been removed.":   "Dein Abo #%d wurde entfernt.",
+	"Matching subscriptions":                    "Passende Abos",
+	"#%d %s: %s":                                "#%d %s: %s",
+	"Everything":                                "Alles",
+	"`%s`\nSubscribed: %s":                      "`%s`\nAbonniert: %s",
+	"Trade route: %s":                           "Handelsroute: %s",
+	"%s ledger update":                          "%s Ranglisten-Aktualisierung",
+	"**Band:** %s\n**Rank:** %s\n**Score:** %s": "**Stufe:** %s\n**Platz:** %s\n**Punkte:** %s",
+	"Your Sea of Thieves stats":                 "Deine Sea of Thieves Statistiken",
+	"New deeds":                                 "Neue Taten",
+	"Emissary ledger":                           "Abgesandten-Rangliste",
+	"Stats":                                     "Statistiken",
+	"unknown subscription topic":                "unbekanntes Abo-Thema",
+	"please provide a filter for this topic, e.g. gold>=1000000": "bitte gib einen Filter für dieses Thema " +
+		"an, z.B. gold>=1000000",
+	"you have reached the maximum number of subscriptions. Please remove one first": "du hast die maximale " +
+		"Anzahl an Abos erreicht. Bitte entferne zuerst eines",
+	"there is no subscription with this ID. Use **/subscribe list** to see the IDs of your subscriptions": "es " +
+		"gibt kein Abo mit dieser ID. Nutze **/abonnieren list**, um die IDs deiner Abos zu sehen",
+	"invalid filter. Use conditions like field=value or field>=number, separated by spaces": "ungültiger " +
+		"Filter. Nutze durch Leerzeichen getrennte Bedingungen wie feld=wert oder feld>=zahl",
+	"unknown filter field. The fields of this topic are": "unbekanntes Filterfeld. Die Felder dieses Themas " +
+		"sind",
+	"only = and != can be used for text fields":     "für Textfelder können nur = und != genutzt werden",
+	"the value of a numeric field must be a number": "der Wert eines numerischen Felds muss eine Zahl sein",
+	"the filter is too long":                        "der Filter ist zu lang",
+	"Sea of Thieves voyage summary for @%s":         "Sea of Thieves Reisezusammenfassung für @%s",
+
+	// Allegiance, ledger, reputation
+	"Ships Sunk":              "Versenkte Schiffe",
+	"Highest Streak":          "Längste Serie",
+	"Highest Hourglass Value": "Höchster Stundenglaswert",
+	"%s **%s** Total":         "%s **%s** insgesamt",
+	"%s **%s** Ships":         "%s **%s** Schiffe",
+	"Your current allegiance values for the **%s**:": "Deine aktuellen Gesinnungswerte für die **%s**:",
+	"Faction/Company":                       "Fraktion/Handelsgesellschaft",
+	"Current Title":                         "Aktueller Titel",
+	"Emissary value":                        "Abgesandtenwert",
+	"Ledger position":                       "Ranglistenplatz",
+	"Next level in":                         "Nächste Stufe in",
+	"%s **%s** points":                      "%s **%s** Punkten",
+	"Your global ledger in Sea of Thieves:": "Deine globale Rangliste in Sea of Thieves:",
+	"Motto":                                 "Motto",
+	"Rank":                                  "Rang",
+	"Level":                                 "Stufe",
+	"XP in current level":                   "EP in der aktuellen Stufe",
+	"Your user reputation with **%s**":      "Dein Ruf bei **%s**",
+
+	// Season, achievements, deeds and trade routes
+	"Current title":                         "Aktueller Titel",
+	"Renown Level":                          "Ansehensstufe",
+	"Renown Tier":                           "Ansehensrang",
+	"Challenges":                            "Herausforderungen",
+	"☑️ %d/%d completed":                    "☑️ %d/%d abgeschlossen",
+	"Your progress in Sea of Thieves %s":    "Dein Fortschritt in Sea of Thieves %s",
+	"Levels this week":                      "Stufen diese Woche",
+	"Sea of Thieves %s rewards":             "Sea of Thieves %s Belohnungen",
+	"There are no rewards in tier %d (%s).": "Es gibt keine Belohnungen im Rang %d (%s).",
+	"Tier %d (%s), level %d":                "Rang %d (%s), Stufe %d",
+	"Page %d of %d":                         "Seite %d von %d",
+	"Previous":                              "Zurück",
+	"Next":                                  "Weiter",
+	"**%s**: %s of %s":                      "**%s**: %s von %s",
+	"Still locked":                          "Noch gesperrt",
+	"✅ Owned":                               "✅ Im Besitz",
+	"🔒 Locked":                              "🔒 Gesperrt",
+	"🔓 Unlocked":                            "🔓 Freigeschaltet",
+	"%s+%s levels":                          "%s+%s Stufen",
+	"Next tier":                             "Nächster Rang",
+	"Final tier reached":                    "Letzter Rang erreicht",
+	"Level up!":                             "Stufenaufstieg!",
+	"Tier up!":                              "Rangaufstieg!",
+	"You reached renown level %d in Sea of Thieves %s.": "Du hast die Ansehensstufe %d in Sea of Thieves " +
+		"%s erreicht.",
+	"You reached renown tier %d (%s) in Sea of Thieves %s.": "Du hast den Ansehensrang %d (%s) in Sea of " +
+		"Thieves %s erreicht.",
+	"Base":                                       "Basis",
+	"Legendary":                                  "Legendär",
+	"Season Pass":                                "Saisonpass",
+	"Your latest reward in the %q tier":          "Deine neueste Belohnung in der Stufe %q",
+	"A nice stack of Gold!":                      "Ein schöner Haufen Gold!",
+	"A nice stack of Doubloons!":                 "Ein schöner Haufen Dublonen!",
+	"A nice stack of Ancient Coins!":             "Ein schöner Haufen Uralter Münzen!",
+	"Your latest Sea of Thieves achievement: %s": "Dein neuester Sea of Thieves Erfolg: %s",
+	"Standard Deed":                              "Standard-Tat",
+	"Standard Daily Deed":                        "Tägliche Standard-Tat",
+	"Daily Swift Deed":                           "Tägliche Blitz-Tat",
+	"Small renown":                               "Wenig Ansehen",
+	"Medium renown":                              "Mittleres Ansehen",
+	"Doubloons":                                  "Dublonen",
+	"%s\n\n**Valid from:** %s\n**Valid thru:** %s\n**Reward:** %s %s\n**Renown gain:** %s": "%s\n\n" +
+		"**Gültig ab:** %s\n**Gültig bis:** %s\n**Belohnung:** %s %s\n**Ansehensgewinn:** %s",
+	"Trade Routes":             "Handelsrouten",
+	"valid from %s thru %s":    "gültig von %s bis %s",
+	"Previous Trade Routes":    "Vorherige Handelsrouten",
+	"Upcoming Trade Routes":    "Kommende Handelsrouten",
+	"Where to sell %s":         "Wo du %s verkaufen kannst",
+	"%s Sought after at":       "%s Gefragt bei",
+	"%s In surplus at":         "%s Im Überschuss bei",
+	"No outpost":               "Kein Außenposten",
+	"Source: %s":               "Quelle: %s",
+	"New deed: %s":             "Neue Tat: %s",
+	"Swift deed ends soon: %s": "Blitz-Tat endet bald: %s",
+	"New trade routes":         "Neue Handelsrouten",
+	"Updated trade routes":     "Aktualisierte Handelsrouten",
+
+	// Graphs
+	"there is no stored history for the chosen metric and period yet": "für die gewählte Statistik und " +
+		"den gewählten Zeitraum ist noch kein Verlauf gespeichert",
+	"please choose the faction of the reputation or ledger that should be charted": "bitte wähle die " +
+		"Fraktion des Rufs oder der Rangliste, die dargestellt werden soll",
+	"please pick the guild members from the suggestions of the member options": "bitte wähle die " +
+		"Servermitglieder aus den Vorschlägen der Mitglieder-Optionen",
+	"Kraken defeated":       "Besiegte Kraken",
+	"Megalodon encounters":  "Megalodon-Begegnungen",
+	"Chests handed in":      "Abgegebene Truhen",
+	"Ships sunk":            "Versenkte Schiffe",
+	"Times vomitted":        "Übergeben",
+	"Distance sailed":       "Gesegelte Strecke",
+	"Reputation level":      "Rufstufe",
+	"Emissary ledger value": "Abgesandtenwert",
+	"Gold":                  "Gold",
+	"Ancient Coins":         "Uralte Münzen",
+	"Last 7 days":           "Letzte 7 Tage",
+	"Last 24 hours":         "Letzte 24 Stunden",
+	"Last 30 days":          "Letzte 30 Tage",
+	"Last 90 days":          "Letzte 90 Tage",
+	"Last year":             "Letztes Jahr",
+
+	// Slash command descriptions
+	"Renders a chart of your Sea of Thieves stats history": "Zeichnet ein Diagramm deines Sea of Thieves " +
+		"Statistikverlaufs",
+	"The metric that should be charted": "Die Statistik, die dargestellt werden soll",
+	"The period that should be charted (default: last 7 days)": "Der Zeitraum, der dargestellt werden " +
+		"soll (Standard: letzte 7 Tage)",
+	"The faction of the reputation or ledger metric":   "Die Fraktion der Ruf- oder Ranglistenstatistik",
+	"A registered guild member to compare with":        "Ein registriertes Servermitglied zum Vergleichen",
+	"Let's you know how late it currently is":          "Sagt dir, wie spät es gerade ist",
+	"Let's you know how long the bot has been running": "Sagt dir, wie lange der Bot schon läuft",
+	"Tells you some information about the bot":         "Erzählt dir ein paar Dinge über den Bot",
+	"Returns a random quote from Captain Flameheart":   "Gibt ein zufälliges Zitat von Captain Flameheart aus",
+	"Configure certain aspects of your ArrBot instance (admin-only)": "Konfiguriere bestimmte Aspekte " +
+		"deiner ArrBot-Instanz (nur Administratoren)",
+	"Enable/Disable the random Captain Flameheart quote spam": "Aktiviere/Deaktiviere den zufälligen " +
+		"Spam mit Zitaten von Captain Flameheart",
+	"Enable Captain Flameheart":  "Captain Flameheart aktivieren",
+	"Disable Captain Flameheart": "Captain Flameheart deaktivieren",
+	"Enable/Disable posting of SoT play summaries to the system/announce channel": "Aktiviere/Deaktiviere " +
+		"das Posten von SoT-Spielzusammenfassungen im System-/Ankündigungskanal",
+	"Announce Sea of Thieves play summaries":        "Sea of Thieves Spielzusammenfassungen ankündigen",
+	"Do not announce Sea of Thieves play summaries": "Keine Sea of Thieves Spielzusammenfassungen ankündigen",
+	"Enable/Disable announcing newly unlocked SoT achievements of the members": "Aktiviere/Deaktiviere " +
+		"die Ankündigung neu freigeschalteter SoT-Erfolge der Mitglieder",
+	"Announce newly unlocked Sea of Thieves achievements": "Neu freigeschaltete Sea of Thieves Erfolge " +
+		"ankündigen",
+	"Do not announce newly unlocked Sea of Thieves achievements": "Keine neu freigeschalteten Sea of " +
+		"Thieves Erfolge ankündigen",
+	"Enable/Disable announcing new deeds and expiring swift deeds": "Aktiviere/Deaktiviere die Ankündigung " +
+		"neuer Taten und ablaufender Blitz-Taten",
+	"Announce new deeds and remind of swift deeds shortly before they end": "Neue Taten ankündigen und " +
+		"kurz vor dem Ende von Blitz-Taten erinnern",
+	"Do not announce new deeds": "Keine neuen Taten ankündigen",
+	"Enable/Disable announcing new trade route rotations": "Aktiviere/Deaktiviere die Ankündigung neuer " +
+		"Handelsrouten-Rotationen",
+	"Announce new and changed trade routes":           "Neue und geänderte Handelsrouten ankündigen",
+	"Do not announce new trade routes":                "Keine neuen Handelsrouten ankündigen",
+	"The role to mention in the announcements":        "Die Rolle, die in den Ankündigungen erwähnt wird",
+	"Lists your unlocked Sea of Thieves achievements": "Listet deine freigeschalteten Sea of Thieves Erfolge",
+	"Only list achievements whose name or description contain this text": "Nur Erfolge auflisten, deren " +
+		"Name oder Beschreibung diesen Text enthält",
+	"The page of the list (default: 1)": "Die Seite der Liste (Standard: 1)",
+	"Override some default settings of your ArrBot instance (admin-only)": "Überschreibe einige " +
+		"Standardeinstellungen deiner ArrBot-Instanz (nur Administratoren)",
+	"Override the default system channel for bot related announcements": "Überschreibe den " +
+		"Standard-Systemkanal für Ankündigungen des Bots",
+	"Regsiters your user with ArrGo so you can use certain user-specific features": "Registriert deinen " +
+		"Benutzer bei ArrGo, damit du benutzerspezifische Funktionen nutzen kannst",
+	"Set the language of the bot's responses (default: the language of your Discord client)": "Lege die " +
+		"Sprache der Antworten des Bots fest (Standard: die Sprache deines Discord-Clients)",
+	"The language of the bot's responses": "Die Sprache der Antworten des Bots",
+	"Automatic":                           "Automatisch",
+	"Configure how distances, numbers and times are displayed to you": "Lege fest, wie dir Entfernungen, " +
+		"Zahlen und Zeiten angezeigt werden",
+	"The unit of distances (default: nautical miles)": "Die Einheit von Entfernungen (Standard: Seemeilen)",
+	"Nautical miles": "Seemeilen",
+	"Kilometres":     "Kilometer",
+	"Miles":          "Meilen",
+	"The digit grouping of numbers (default: the format of your language)": "Die Zifferngruppierung von " +
+		"Zahlen (Standard: das Format deiner Sprache)",
+	"IANA time zone, e.g. Europe/Berlin, or \"auto\" (default: your Discord client)": "IANA-Zeitzone, z. B. " +
+		"Europe/Berlin, oder \"auto\" (Standard: dein Discord-Client)",
+	"The clock format of times (default: your Discord client)": "Das Uhrzeitformat (Standard: dein " +
+		"Discord-Client)",
+	"24-hour clock": "24-Stunden-Format",
+	"12-hour clock": "12-Stunden-Format",
+	"Whether other guild members can compare with your stats (default: public)": "Ob andere " +
+		"Servermitglieder sich mit deinen Statistiken vergleichen können (Standard: öffentlich)",
+	"Removes your user and all data stored about you from ArrGo": "Entfernt deinen Benutzer und alle über " +
+		"dich gespeicherten Daten aus ArrGo",
+	"Access the data ArrGo has stored about you": "Greife auf die Daten zu, die ArrGo über dich " +
+		"gespeichert hat",
+	"Sends you a DM with all the data ArrGo has stored about you": "Schickt dir eine Direktnachricht mit " +
+		"allen Daten, die ArrGo über dich gespeichert hat",
+	"File format of the export (default: JSON)": "Dateiformat des Exports (Standard: JSON)",
+	"Verifies and stores your Sea of Thieves authentication cookie in the Bot's database": "Prüft dein " +
+		"Sea of Thieves Authentifizierungscookie und speichert es in der Datenbank des Bots",
+	"SoT-RAT-Extractor string, rat= cookie value or full Cookie header": "Text des SoT-RAT-Extractor, " +
+		"Wert des rat= Cookies oder vollständiger Cookie-Header",
+	"Configure when and where you get reminded about your expiring SoT authentication cookie": "Lege " +
+		"fest, wann und wo du an dein ablaufendes SoT Authentifizierungscookie erinnert wirst",
+	"Comma-separated durations before the expiry, e.g. 24h,6h,1h": "Kommagetrennte Zeitspannen vor dem " +
+		"Ablauf, z. B. 24h,6h,1h",
+	"How you want to be reminded": "Wie du erinnert werden möchtest",
+	"Direct message":              "Direktnachricht",
+	"Ping in a guild channel":     "Erwähnung in einem Serverkanal",
+	"Guild channel for the reminder ping (default: the current channel)": "Serverkanal für die " +
+		"Erinnerung (Standard: der aktuelle Kanal)",
+	"Configure how and where you receive notifications of the bot": "Lege fest, wie und wo du " +
+		"Benachrichtigungen des Bots erhältst",
+	"Show your current notification settings": "Zeigt deine aktuellen Benachrichtigungseinstellungen",
+	"Set how you want to receive a type of notification": "Lege fest, wie du eine Art von " +
+		"Benachrichtigung erhalten möchtest",
+	"Hold back notifications during the given hours": "Halte Benachrichtigungen während der angegebenen " +
+		"Zeiten zurück",
+	"Quiet hours in the format HH:MM-HH:MM (e.g. 22:00-07:00) or \"off\"": "Ruhezeiten im Format " +
+		"HH:MM-HH:MM (z. B. 22:00-07:00) oder \"off\"",
+	"IANA time zone of the quiet hours, e.g. Europe/Berlin (default: UTC)": "IANA-Zeitzone der " +
+		"Ruhezeiten, z. B. Europe/Berlin (Standard: UTC)",
+	"Set the guild default for a type of notification (admin only)": "Lege die Servervorgabe für eine " +
+		"Art von Benachrichtigung fest (nur Administratoren)",
+	"The type of notification":          "Die Art der Benachrichtigung",
+	"How the notification is delivered": "Wie die Benachrichtigung zugestellt wird",
+	"Default":                           "Standard",
+	"Guild channel":                     "Serverkanal",
+	"Role mention in a guild channel":   "Rollenerwähnung in einem Serverkanal",
+	"Muted":                             "Stummgeschaltet",
+	"Guild channel for channel/role delivery (default: the current channel)": "Serverkanal für die " +
+		"Zustellung per Kanal/Rolle (Standard: der aktuelle Kanal)",
+	"Role that is mentioned for role delivery": "Rolle, die bei der Zustellung per Rolle erwähnt wird",
+	"Returns your latest achievement in Sea of Thieves to you": "Zeigt dir deinen neuesten Erfolg in " +
+		"Sea of Thieves",
+	"Returns your renown progress in the current Sea of Thieves season to you": "Zeigt dir deinen " +
+		"Ansehensfortschritt in der aktuellen Sea of Thieves Saison",
+	"Shows your renown progress in the current season": "Zeigt deinen Ansehensfortschritt in der " +
+		"aktuellen Saison",
+	"Browse the rewards of the current season": "Blättere durch die Belohnungen der aktuellen Saison",
+	"The renown tier to browse (default: your current tier)": "Der anzuzeigende Ansehensrang " +
+		"(Standard: dein aktueller Rang)",
+	"Returns your current Sea of Thieves gold/doubloon/ancient coins balance": "Zeigt dir deinen " +
+		"aktuellen Sea of Thieves Kontostand an Gold, Dublonen und Uralten Münzen",
+	"Returns the currently active trade routes in Sea of Thieves": "Zeigt die aktuell aktiven " +
+		"Handelsrouten in Sea of Thieves",
+	"Only show the trade route of this outpost":           "Zeige nur die Handelsroute dieses Außenpostens",
+	"The rotation of the trade routes (default: current)": "Der Zeitraum der Handelsrouten (Standard: aktuell)",
+	"Current rotation":  "Aktueller Zeitraum",
+	"Previous rotation": "Vorheriger Zeitraum",
+	"Upcoming rotation": "Kommender Zeitraum",
+	"Shows at which outposts a commodity is sought after or in surplus": "Zeigt, bei welchen Außenposten " +
+		"eine Ware gefragt oder im Überschuss ist",
+	"The commodity you want to sell": "Die Ware, die du verkaufen möchtest",
+	"Returns an overview of some general stats of your Sea of Thieves pirate": "Zeigt eine Übersicht " +
+		"einiger allgemeiner Statistiken deines Sea of Thieves Piraten",
+	"Compares your current Sea of Thieves stats with historic data": "Vergleicht deine aktuellen Sea of " +
+		"Thieves Statistiken mit früheren Daten",
+	"Set personal Sea of Thieves goals and track your progress": "Setze persönliche Sea of Thieves " +
+		"Ziele und verfolge deinen Fortschritt",
+	"Set a new personal goal":         "Setze ein neues persönliches Ziel",
+	"The metric of the goal":          "Die Statistik des Ziels",
+	"Show the progress of your goals": "Zeigt den Fortschritt deiner Ziele",
+	"Remove one of your goals":        "Entfernt eines deiner Ziele",
+	"The value to reach (distances in your distance unit)": "Der zu erreichende Wert (Entfernungen in " +
+		"deiner Entfernungseinheit)",
+	"The faction of a reputation level goal":    "Die Fraktion eines Rufstufen-Ziels",
+	"The ID of the goal as shown by /goal list": "Die ID des Ziels, wie von /ziel list angezeigt",
+	"Subscribe to personal notifications about deeds, trade routes, your ledger and stats": "Abonniere " +
+		"persönliche Benachrichtigungen zu Taten, Handelsrouten, deiner Rangliste und Statistiken",
+	"Subscribe to a topic":      "Ein Thema abonnieren",
+	"The topic to subscribe to": "Das zu abonnierende Thema",
+	"Only notify if all conditions match, e.g. type=swift reward=doubloons": "Nur benachrichtigen, wenn " +
+		"alle Bedingungen passen, z.B. type=swift reward=doubloons",
+	"Show your subscriptions":          "Zeigt deine Abos",
+	"Remove one of your subscriptions": "Entfernt eines deiner Abos",
+	"The ID of the subscription as shown by /subscribe list": "Die ID des Abos, wie von /abonnieren list " +
+		"angezeigt",
+	"Compares your Sea of Thieves stats with another guild member": "Vergleicht deine Sea of Thieves " +
+		"Statistiken mit einem anderen Servermitglied",
+	"The registered guild member to compare with": "Das registrierte Servermitglied zum Vergleichen",
+	"The period of the compared gains (default: last 24 hours)": "Der Zeitraum des verglichenen Zuwachses " +
+		"(Standard: letzte 24 Stunden)",
+	"Compare with the stats of the given number of hours ago": "Mit den Statistiken von vor der " +
+		"angegebenen Anzahl Stunden vergleichen",
+	"Compare with the stats of the given number of days ago": "Mit den Statistiken von vor der " +
+		"angegebenen Anzahl Tage vergleichen",
+	"Compare the stats of a named period": "Die Statistiken eines benannten Zeitraums vergleichen",
+	"Compare a custom time window like 36h, 2w3d, 2024-05-01 or 2024-05-01..2024-05-07": "Einen eigenen " +
+		"Zeitraum wie 36h, 2w3d, 2024-05-01 oder 2024-05-01..2024-05-07 vergleichen",
+	"Returns the currently active Sea of Thieves daily deeds": "Zeigt die aktuell aktiven Sea of Thieves " +
+		"Tagesaufgaben",
+	"Returns your current leaderboard position in the different emissary ledgers": "Zeigt deinen " +
+		"aktuellen Platz in den Ranglisten der verschiedenen Abgesandten",
+	"Name of the emissary faction": "Name der Abgesandten-Fraktion",
+	"Returns your current reputation value in the different emissary/allegiance faction": "Zeigt deinen " +
+		"aktuellen Ruf bei den verschiedenen Abgesandten-/Gesinnungsfraktionen",
+	"Name of the emissary/allegiance faction": "Name der Abgesandten-/Gesinnungsfraktion",
+	"Returns your current allegiance values in the different allegiance factions": "Zeigt deine aktuellen " +
+		"Gesinnungswerte bei den verschiedenen Gesinnungsfraktionen",
+	"Name of the allegiance faction": "Name der Gesinnungsfraktion",
+}
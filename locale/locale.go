@@ -0,0 +1,92 @@
+// Package locale provides the message catalog and the language matching for the localized responses
+// of ArrGo. Messages are keyed by their English format string, so English needs no dictionary and
+// messages without a translation fall back to English
+package locale
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Default is the language that is used if none of the requested languages is supported
+var Default = language.English
+
+// Supported is the list of languages that ArrGo provides responses in
+var Supported = []language.Tag{language.English, language.German}
+
+// dictionaries holds the translations of the messages per language
+var dictionaries = map[language.Tag]map[string]string{
+	language.German: de,
+}
+
+// commandNames holds the translations of the slash command names per language
+var commandNames = map[language.Tag]map[string]string{
+	language.German: deCommands,
+}
+
+var (
+	cat     = newCatalog()
+	matcher = language.NewMatcher(Supported)
+)
+
+// newCatalog builds the message catalog from the dictionaries
+func newCatalog() catalog.Catalog {
+	cb := catalog.NewBuilder(catalog.Fallback(Default))
+	for t, d := range dictionaries {
+		for k, v := range d {
+			if err := cb.SetString(t, k, v); err != nil {
+				panic(fmt.Sprintf("failed to add message %q to the %s catalog: %s", k, t, err))
+			}
+		}
+	}
+	return cb
+}
+
+// Match returns the first supported language of the given locales (e.g. "de" or "en-US"). Empty,
+// invalid and unsupported locales are skipped. If none of the locales is supported, Default is returned
+func Match(ll ...string) language.Tag {
+	for _, l := range ll {
+		if l == "" {
+			continue
+		}
+		t, err := language.Parse(l)
+		if err != nil {
+			continue
+		}
+		_, i, c := matcher.Match(t)
+		if c == language.No {
+			continue
+		}
+		return Supported[i]
+	}
+	return Default
+}
+
+// NewPrinter returns a message.Printer for the given language that uses the ArrGo message catalog
+func NewPrinter(t language.Tag) *message.Printer {
+	return message.NewPrinter(t, message.Catalog(cat))
+}
+
+// Lookup returns the translation of the given message without applying any formatting
+func Lookup(t language.Tag, m string) (string, bool) {
+	v, ok := dictionaries[t][m]
+	return v, ok
+}
+
+// Translate returns the translation of the given message without applying any formatting. If no
+// translation is available, the message is returned unchanged
+func Translate(t language.Tag, m string) string {
+	if v, ok := Lookup(t, m); ok {
+		return v
+	}
+	return m
+}
+
+// CommandName returns the translation of the given slash command name
+func CommandName(t language.Tag, n string) (string, bool) {
+	v, ok := commandNames[t][n]
+	return v, ok
+}
@@ -133,3 +133,51 @@ func (m DeedModel) Insert(d *Deed) error {
 	}
 	return nil
 }
+
+// GetSwiftDeedsEndingBefore retrieves the daily swift deeds that are valid at the given time n, end
+// before the given time t and have not been reminded of yet from the database
+func (m DeedModel) GetSwiftDeedsEndingBefore(n, t time.Time) ([]*Deed, error) {
+	q := `SELECT d.id, d.deed_type, d.description, d.valid_from, d.valid_thru, d.reward_type, d.reward_amount,
+       d.reward_icon, d.image_url, d.ctime
+            FROM deeds d
+           WHERE d.deed_type = $1
+             AND d.reminded IS NULL
+             AND d.valid_from <= $2
+             AND d.valid_thru > $2
+             AND d.valid_thru <= $3
+           ORDER BY d.valid_thru`
+
+	var dl []*Deed
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, DeedTypeDailySwift, n, t)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var d Deed
+		err := rows.Scan(&d.ID, &d.DeedType, &d.Description, &d.ValidFrom, &d.ValidThru, &d.RewardType,
+			&d.RewardAmount, &d.RewardIcon, &d.ImageURL, &d.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		dl = append(dl, &d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// SetReminded marks the given Deed as reminded of in the database
+func (m DeedModel) SetReminded(d *Deed) error {
+	q := `UPDATE deeds SET reminded = NOW() WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, d.ID)
+	return err
+}
@@ -61,10 +61,28 @@ func (m GuildModel) GetGuilds() ([]*Guild, error) {
 	q := `SELECT g.guild_id
             FROM guilds g`
 
-	var gl []*Guild
 	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
 	defer cancel()
-	rows, err := m.DB.QueryContext(ctx, q)
+	return m.queryGuilds(ctx, q)
+}
+
+// GetGuildsWithPref returns a list of all guilds that have the given (unencrypted) preference set in
+// the database, regardless of its value
+func (m GuildModel) GetGuildsWithPref(k GuildPrefKey) ([]*Guild, error) {
+	q := `SELECT g.guild_id
+            FROM guilds g
+            JOIN guild_prefs p ON p.guild_id = g.id
+           WHERE p.pref_key = $1 AND p.is_enc = false`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	return m.queryGuilds(ctx, q, k)
+}
+
+// queryGuilds runs the given query for guild IDs and returns the list of corresponding guilds
+func (m GuildModel) queryGuilds(ctx context.Context, q string, a ...interface{}) ([]*Guild, error) {
+	var gl []*Guild
+	rows, err := m.DB.QueryContext(ctx, q, a...)
 	if err != nil {
 		return nil, err
 	}
@@ -24,8 +24,43 @@ const (
 
 	// GuildPrefAnnounceSoTSummary is set, when the guild allows the announcing of SoT play summaries
 	GuildPrefAnnounceSoTSummary GuildPrefKey = "announce_sot_play_summary"
+
+	// GuildPrefAnnounceAchievements is set, when the guild allows the announcing of newly unlocked
+	// SoT achievements of its members
+	GuildPrefAnnounceAchievements GuildPrefKey = "announce_achievements"
+
+	// GuildPrefAnnounceDeeds is set, when the guild wants new SoT deeds and swift deed expiry reminders
+	// to be announced
+	GuildPrefAnnounceDeeds GuildPrefKey = "announce_deeds"
+
+	// GuildPrefAnnounceDeedsRole is the role that is mentioned in deed announcements
+	GuildPrefAnnounceDeedsRole GuildPrefKey = "announce_deeds_role"
+
+	// GuildPrefAnnounceTradeRoutes is set, when the guild wants new trade route rotations to be announced
+	GuildPrefAnnounceTradeRoutes GuildPrefKey = "announce_traderoutes"
+
+	// GuildPrefAnnounceTradeRoutesRole is the role that is mentioned in trade route announcements
+	GuildPrefAnnounceTradeRoutesRole GuildPrefKey = "announce_traderoutes_role"
+
+	// GuildPrefLocale is the preferred locale of the guild as reported by Discord
+	GuildPrefLocale GuildPrefKey = "locale"
 )
 
+// GuildPrefNotifyDelivery returns the GuildPrefKey of the delivery method for the given notification type
+func GuildPrefNotifyDelivery(t string) GuildPrefKey {
+	return GuildPrefKey("notify_" + t + "_delivery")
+}
+
+// GuildPrefNotifyChannel returns the GuildPrefKey of the delivery channel for the given notification type
+func GuildPrefNotifyChannel(t string) GuildPrefKey {
+	return GuildPrefKey("notify_" + t + "_channel")
+}
+
+// GuildPrefNotifyRole returns the GuildPrefKey of the role to mention for the given notification type
+func GuildPrefNotifyRole(t string) GuildPrefKey {
+	return GuildPrefKey("notify_" + t + "_role")
+}
+
 // GetPrefString fetches a client-specific setting from the database as string type
 func (m GuildModel) GetPrefString(g *Guild, k GuildPrefKey) (string, error) {
 	return getGuildPref[string](m, g, k)
@@ -109,6 +144,20 @@ func (m GuildModel) SetPref(g *Guild, k GuildPrefKey, v interface{}) error {
 	return nil
 }
 
+// DeletePref removes a guild-specific setting from the database
+func (m GuildModel) DeletePref(g *Guild, k GuildPrefKey) error {
+	if g == nil {
+		return ErrGuildNil
+	}
+	q := `DELETE FROM guild_prefs WHERE guild_id = $1 AND pref_key = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, g.ID, k)
+	return err
+}
+
 // SetPrefEnc stores an encrypted guild-specific setting in the database
 func (m GuildModel) SetPrefEnc(g *Guild, k GuildPrefKey, v interface{}) error {
 	var sv bytes.Buffer
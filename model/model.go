@@ -11,6 +11,9 @@ import (
 // SQLTimeout is the default timeout for SQL queries
 const SQLTimeout = time.Second * 1
 
+// SQLExportTimeout is the timeout for SQL queries that return the full history of a user
+const SQLExportTimeout = time.Second * 10
+
 // List of model specific errors
 var (
 	// ErrGuildNotExistent should be used in case a requested guild was not found in the database
@@ -45,28 +48,46 @@ var (
 	// ErrUserNil should be returned if the check for the *User returns nil
 	ErrUserNil = errors.New("user pointer must not be nil:w")
 
+	// ErrGuildNil should be returned if the check for the *Guild returns nil
+	ErrGuildNil = errors.New("guild pointer must not be nil")
+
+	// ErrUserLedgerNotExistent should be used in case a requested user ledger was not found in the database
+	ErrUserLedgerNotExistent = errors.New("requested user ledger not existent in database")
+
 	// ErrUserRepNotExistent should be used in case a requested user reputation was not found in the database
 	ErrUserRepNotExistent = errors.New("requested user reputation not existent in database")
 )
 
 // Model is a collection of all available models
 type Model struct {
-	Deed           *DeedModel
-	Guild          *GuildModel
-	TradeRoute     *TradeRouteModel
-	User           *UserModel
-	UserReputation *UserReputationModel
-	UserStats      *UserStatModel
+	Deed             *DeedModel
+	Guild            *GuildModel
+	Notification     *NotificationModel
+	TradeRoute       *TradeRouteModel
+	User             *UserModel
+	UserAchievement  *UserAchievementModel
+	UserGoal         *UserGoalModel
+	UserLedger       *UserLedgerModel
+	UserReputation   *UserReputationModel
+	UserSeason       *UserSeasonModel
+	UserStats        *UserStatModel
+	UserSubscription *UserSubscriptionModel
 }
 
 // New returns the collection of all available models
 func New(db *sql.DB, c *config.Config) Model {
 	return Model{
-		Deed:           &DeedModel{DB: db},
-		Guild:          &GuildModel{DB: db, Config: c},
-		TradeRoute:     &TradeRouteModel{DB: db},
-		User:           &UserModel{DB: db, Config: c},
-		UserReputation: &UserReputationModel{DB: db},
-		UserStats:      &UserStatModel{DB: db},
+		Deed:             &DeedModel{DB: db},
+		Guild:            &GuildModel{DB: db, Config: c},
+		Notification:     &NotificationModel{DB: db},
+		TradeRoute:       &TradeRouteModel{DB: db},
+		User:             &UserModel{DB: db, Config: c},
+		UserAchievement:  &UserAchievementModel{DB: db},
+		UserGoal:         &UserGoalModel{DB: db},
+		UserLedger:       &UserLedgerModel{DB: db},
+		UserReputation:   &UserReputationModel{DB: db},
+		UserSeason:       &UserSeasonModel{DB: db},
+		UserStats:        &UserStatModel{DB: db},
+		UserSubscription: &UserSubscriptionModel{DB: db},
 	}
 }
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// NotificationModel wraps the connection pool.
+type NotificationModel struct {
+	DB *sql.DB
+}
+
+// QueuedNotification represents a notification in the database that is held back until NotBefore
+type QueuedNotification struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"userId"`
+	Type       string    `json:"type"`
+	Payload    []byte    `json:"payload"`
+	NotBefore  time.Time `json:"notBefore"`
+	Attempts   int       `json:"attempts"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+// Log records that a notification of the given type and dedup key has been sent to the user. It returns
+// false if the notification has already been recorded before
+func (m NotificationModel) Log(u *User, t, k string) (bool, error) {
+	if u == nil {
+		return false, ErrUserNil
+	}
+	q := `INSERT INTO notification_log (user_id, ntype, dedup_key)
+               VALUES ($1, $2, $3)
+          ON CONFLICT DO NOTHING`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	r, err := m.DB.ExecContext(ctx, q, u.ID, t, k)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Unlog removes a notification record, so that the notification can be sent again
+func (m NotificationModel) Unlog(u *User, t, k string) error {
+	if u == nil {
+		return ErrUserNil
+	}
+	q := `DELETE FROM notification_log WHERE user_id = $1 AND ntype = $2 AND dedup_key = $3`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, u.ID, t, k)
+	return err
+}
+
+// PurgeLog removes all notification records older than the given time and returns the number of
+// removed records
+func (m NotificationModel) PurgeLog(t time.Time) (int64, error) {
+	q := `DELETE FROM notification_log WHERE ctime < $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLMaintenanceTimeout)
+	defer cancel()
+
+	r, err := m.DB.ExecContext(ctx, q, t)
+	if err != nil {
+		return 0, err
+	}
+	return r.RowsAffected()
+}
+
+// Enqueue adds a notification to the queue
+func (m NotificationModel) Enqueue(qn *QueuedNotification) error {
+	q := `INSERT INTO notification_queue (user_id, ntype, payload, not_before)
+               VALUES ($1, $2, $3, $4)
+            RETURNING id, ctime`
+	v := []interface{}{qn.UserID, qn.Type, qn.Payload, qn.NotBefore}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, v...)
+	return row.Scan(&qn.ID, &qn.CreateTime)
+}
+
+// GetDue returns at most l queued notifications that are due at the given time
+func (m NotificationModel) GetDue(t time.Time, l int) ([]*QueuedNotification, error) {
+	q := `SELECT id, user_id, ntype, payload, not_before, attempts, ctime
+            FROM notification_queue n
+           WHERE n.not_before <= $1
+           ORDER BY n.not_before, n.id
+           LIMIT $2`
+
+	var nl []*QueuedNotification
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, t, l)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var qn QueuedNotification
+		if err := rows.Scan(&qn.ID, &qn.UserID, &qn.Type, &qn.Payload, &qn.NotBefore, &qn.Attempts,
+			&qn.CreateTime); err != nil {
+			return nil, err
+		}
+		nl = append(nl, &qn)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return nl, nil
+}
+
+// Dequeue removes a notification from the queue
+func (m NotificationModel) Dequeue(qn *QueuedNotification) error {
+	q := `DELETE FROM notification_queue WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, qn.ID)
+	return err
+}
+
+// Retry increments the number of failed delivery attempts of a queued notification
+func (m NotificationModel) Retry(qn *QueuedNotification) error {
+	q := `UPDATE notification_queue SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, qn.ID)
+	return row.Scan(&qn.Attempts)
+}
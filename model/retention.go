@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Granularity represents the time resolution of a stored user stats or user reputation row
+type Granularity string
+
+// List of possible Granularity values
+const (
+	// GranularityRaw is a row as it was stored by the bot
+	GranularityRaw Granularity = "raw"
+
+	// GranularityHour is a row that represents a full hour after downsampling
+	GranularityHour Granularity = "hour"
+
+	// GranularityDay is a row that represents a full day after downsampling
+	GranularityDay Granularity = "day"
+)
+
+// SQLMaintenanceTimeout is the timeout for long-running maintenance queries like downsampling
+const SQLMaintenanceTimeout = time.Minute * 5
+
+// downsample rolls up all rows of the given table with a ctime between f and t into buckets of
+// the given Granularity. Since all stored values are snapshots, a bucket is represented by the
+// first row within the bucket, all other rows of the bucket are removed. The pc columns define
+// the partition (i. e. per user). It returns the number of removed rows
+func downsample(db *sql.DB, tb, pc string, f, t time.Time, g Granularity) (int64, error) {
+	var tu string
+	switch g {
+	case GranularityHour:
+		tu = "hour"
+	case GranularityDay:
+		tu = "day"
+	default:
+		return 0, fmt.Errorf("unsupported downsampling granularity: %s", g)
+	}
+
+	ks := fmt.Sprintf(`SELECT DISTINCT ON (%[2]s, date_trunc('%[3]s', r.ctime)) r.id
+            FROM %[1]s r
+           WHERE r.ctime >= $1 AND r.ctime < $2
+           ORDER BY %[2]s, date_trunc('%[3]s', r.ctime), r.ctime, r.id`, tb, pc, tu)
+	dq := fmt.Sprintf(`DELETE FROM %[1]s d
+           WHERE d.ctime >= $1 AND d.ctime < $2
+             AND d.id NOT IN (%[2]s)`, tb, ks)
+	uq := fmt.Sprintf(`UPDATE %[1]s u
+             SET granularity = $3
+           WHERE u.ctime >= $1 AND u.ctime < $2
+             AND u.granularity <> $3`, tb)
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLMaintenanceTimeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, dq, f, t)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove downsampled rows from %s: %w", tb, err)
+	}
+	if _, err := tx.ExecContext(ctx, uq, f, t, g); err != nil {
+		return 0, fmt.Errorf("failed to update granularity of rows in %s: %w", tb, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
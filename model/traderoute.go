@@ -3,7 +3,6 @@ package model
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"time"
 )
 
@@ -12,68 +11,65 @@ type TradeRouteModel struct {
 	DB *sql.DB
 }
 
-// TradeRoute represents the trade route information in the database
+// TradeRoute represents the trade route information of an outpost within a rotation in the database
 type TradeRoute struct {
 	ID          int64     `json:"id"`
 	Outpost     string    `json:"outpost"`
 	SoughtAfter string    `json:"soughtAfter"`
 	Surplus     string    `json:"surplus"`
+	ValidFrom   time.Time `json:"validFrom"`
 	ValidThru   time.Time `json:"validThru"`
 	Version     int       `json:"-"`
 	CreateTime  time.Time `json:"createTime"`
 	ModTime     time.Time `json:"modTime"`
 }
 
-// GetByOutpost retrieves the TradeRoute details from the database based on the given Outpost name
-func (m TradeRouteModel) GetByOutpost(o string) (*TradeRoute, error) {
-	q := `SELECT t.id, t.outpost, t.sought_after, t.surplus, t.validthru,
-       		     t.version, t.ctime, t.mtime
-            FROM trade_routes t
-           WHERE t.outpost = $1`
-
-	var t TradeRoute
-	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
-	defer cancel()
+// TradeRouteRotation represents the validity window of a set of trade routes in the database
+type TradeRouteRotation struct {
+	ValidFrom time.Time `json:"validFrom"`
+	ValidThru time.Time `json:"validThru"`
+}
 
-	row := m.DB.QueryRowContext(ctx, q, o)
-	err := row.Scan(&t.ID, &t.Outpost, &t.SoughtAfter, &t.Surplus, &t.ValidThru,
-		&t.Version, &t.CreateTime, &t.ModTime)
-	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return &t, ErrTradeRouteNotExistent
-		default:
-			return &t, err
-		}
-	}
-	return &t, nil
+// GetTradeRoutes returns the trade routes of the latest rotation that started at or before the given
+// time from the database
+func (m TradeRouteModel) GetTradeRoutes(t time.Time) ([]*TradeRoute, error) {
+	q := `SELECT t.id, t.outpost, t.sought_after, t.surplus, t.validfrom, t.validthru,
+                 t.version, t.ctime, t.mtime
+            FROM trade_routes t
+           WHERE t.validfrom = (SELECT MAX(r.validfrom) FROM trade_routes r WHERE r.validfrom <= $1)
+           ORDER BY t.outpost`
+	return m.queryTradeRoutes(q, t)
 }
 
-// GetTradeRoutes returns a list of all trade routes registered in the database
-func (m TradeRouteModel) GetTradeRoutes() ([]*TradeRoute, error) {
-	q := `SELECT t.outpost
-            FROM trade_routes t`
+// GetByRotation returns the trade routes of the rotation that starts at the given time from the database
+func (m TradeRouteModel) GetByRotation(vf time.Time) ([]*TradeRoute, error) {
+	q := `SELECT t.id, t.outpost, t.sought_after, t.surplus, t.validfrom, t.validthru,
+                 t.version, t.ctime, t.mtime
+            FROM trade_routes t
+           WHERE t.validfrom = $1
+           ORDER BY t.outpost`
+	return m.queryTradeRoutes(q, vf)
+}
 
+// queryTradeRoutes runs the given trade route query and returns the resulting list of trade routes
+func (m TradeRouteModel) queryTradeRoutes(q string, a ...interface{}) ([]*TradeRoute, error) {
 	var tl []*TradeRoute
 	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
 	defer cancel()
-	rows, err := m.DB.QueryContext(ctx, q)
+	rows, err := m.DB.QueryContext(ctx, q, a...)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
 	for rows.Next() {
-		var o string
-		err := rows.Scan(&o)
+		var t TradeRoute
+		err := rows.Scan(&t.ID, &t.Outpost, &t.SoughtAfter, &t.Surplus, &t.ValidFrom, &t.ValidThru,
+			&t.Version, &t.CreateTime, &t.ModTime)
 		if err != nil {
 			return nil, err
 		}
-		t, err := m.GetByOutpost(o)
-		if err != nil {
-			return nil, err
-		}
-		tl = append(tl, t)
+		tl = append(tl, &t)
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
@@ -81,56 +77,65 @@ func (m TradeRouteModel) GetTradeRoutes() ([]*TradeRoute, error) {
 	return tl, nil
 }
 
-// Insert adds a new TradeRoute into the database
-func (m TradeRouteModel) Insert(t *TradeRoute) error {
-	q := `INSERT INTO trade_routes (outpost, sought_after, surplus, validthru)
-               VALUES ($1, $2, $3, $4)
-            RETURNING id, ctime, mtime, version`
-	v := []interface{}{t.Outpost, t.SoughtAfter, t.Surplus, t.ValidThru}
+// GetRotations returns the validity windows of all stored trade route rotations, oldest first
+func (m TradeRouteModel) GetRotations() ([]TradeRouteRotation, error) {
+	q := `SELECT t.validfrom, MAX(t.validthru)
+            FROM trade_routes t
+           GROUP BY t.validfrom
+           ORDER BY t.validfrom`
 
+	var rl []TradeRouteRotation
 	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
 	defer cancel()
-
-	row := m.DB.QueryRowContext(ctx, q, v...)
-	err := row.Scan(&t.ID, &t.CreateTime, &t.ModTime, &t.Version)
+	rows, err := m.DB.QueryContext(ctx, q)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
-}
+	defer func() { _ = rows.Close() }()
 
-// Update takes a given TradeRoute and updates the variable values in the database
-func (m TradeRouteModel) Update(t *TradeRoute) error {
-	q := `UPDATE trade_routes
-             SET outpost = $1, sought_after = $2, surplus = $3, validthru = $4, 
-                 mtime = NOW(), version = version + 1
-           WHERE id = $5 AND version = $6
-       RETURNING version`
-
-	v := []interface{}{
-		t.Outpost, t.SoughtAfter, t.Surplus, t.ValidThru,
-		t.ID, t.Version,
+	for rows.Next() {
+		var r TradeRouteRotation
+		if err := rows.Scan(&r.ValidFrom, &r.ValidThru); err != nil {
+			return nil, err
+		}
+		rl = append(rl, r)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// Upsert adds the given TradeRoute into the database. If the route of the outpost within the same
+// rotation is already stored, it is updated instead. It returns true if the route was added or changed
+func (m TradeRouteModel) Upsert(t *TradeRoute) (bool, error) {
+	q := `INSERT INTO trade_routes AS t (outpost, sought_after, surplus, validfrom, validthru)
+               VALUES ($1, $2, $3, $4, $5)
+          ON CONFLICT (outpost, validfrom) DO UPDATE
+                  SET sought_after = EXCLUDED.sought_after, surplus = EXCLUDED.surplus,
+                      validthru = EXCLUDED.validthru, mtime = NOW(), version = t.version + 1
+                WHERE (t.sought_after, t.surplus, t.validthru) IS DISTINCT FROM
+                      (EXCLUDED.sought_after, EXCLUDED.surplus, EXCLUDED.validthru)
+            RETURNING id, ctime, mtime, version`
+	v := []interface{}{t.Outpost, t.SoughtAfter, t.Surplus, t.ValidFrom, t.ValidThru}
 
 	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
 	defer cancel()
 
 	row := m.DB.QueryRowContext(ctx, q, v...)
-	err := row.Scan(&t.Version)
-	if err != nil {
-		switch {
-		case err == sql.ErrNoRows:
-			return ErrEditConflict
-		default:
-			return err
-		}
+	err := row.Scan(&t.ID, &t.CreateTime, &t.ModTime, &t.Version)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
 	}
-	return nil
+	return true, nil
 }
 
 // ValidThru retrieves the maximum TradeRoute valid thru date form the database
 func (m TradeRouteModel) ValidThru() (time.Time, error) {
-	q := `SELECT MAX(t.validthru)
+	q := `SELECT COALESCE(MAX(t.validthru), 'epoch')
             FROM trade_routes t`
 
 	var v time.Time
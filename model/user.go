@@ -19,17 +19,18 @@ type UserModel struct {
 
 // User represents the user information in the database
 type User struct {
-	ID            int64     `json:"id"`
-	UserID        string    `json:"userId"`
-	EncryptionKey []byte    `json:"-"`
-	Version       int       `json:"-"`
-	CreateTime    time.Time `json:"createTime"`
-	ModTime       time.Time `json:"modTime"`
+	ID            int64      `json:"id"`
+	UserID        string     `json:"userId"`
+	EncryptionKey []byte     `json:"-"`
+	RATExpire     *time.Time `json:"ratExpire,omitempty"`
+	Version       int        `json:"-"`
+	CreateTime    time.Time  `json:"createTime"`
+	ModTime       time.Time  `json:"modTime"`
 }
 
 // GetByUserID retrieves the User details from the database based on the given User ID
 func (m UserModel) GetByUserID(i string) (*User, error) {
-	q := `SELECT u.id, u.user_id, u.enc_key, u.version, u.ctime, u.mtime
+	q := `SELECT u.id, u.user_id, u.enc_key, u.rat_expire, u.version, u.ctime, u.mtime
             FROM users u
            WHERE u.user_id = $1`
 
@@ -38,7 +39,30 @@ func (m UserModel) GetByUserID(i string) (*User, error) {
 	defer cancel()
 
 	row := m.DB.QueryRowContext(ctx, q, i)
-	err := row.Scan(&u.ID, &u.UserID, &u.EncryptionKey, &u.Version, &u.CreateTime, &u.ModTime)
+	err := row.Scan(&u.ID, &u.UserID, &u.EncryptionKey, &u.RATExpire, &u.Version, &u.CreateTime, &u.ModTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return &u, ErrUserNotExistent
+		default:
+			return &u, err
+		}
+	}
+	return &u, nil
+}
+
+// GetByID retrieves the User details from the database based on the given database ID
+func (m UserModel) GetByID(i int64) (*User, error) {
+	q := `SELECT u.id, u.user_id, u.enc_key, u.rat_expire, u.version, u.ctime, u.mtime
+            FROM users u
+           WHERE u.id = $1`
+
+	var u User
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, i)
+	err := row.Scan(&u.ID, &u.UserID, &u.EncryptionKey, &u.RATExpire, &u.Version, &u.CreateTime, &u.ModTime)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -52,29 +76,101 @@ func (m UserModel) GetByUserID(i string) (*User, error) {
 
 // GetUsers returns a list of all users registered in the database
 func (m UserModel) GetUsers() ([]*User, error) {
-	q := `SELECT u.user_id
-            FROM users u`
+	q := `SELECT u.id, u.user_id, u.enc_key, u.rat_expire, u.version, u.ctime, u.mtime
+            FROM users u
+           ORDER BY u.id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	return m.queryUsers(ctx, q)
+}
+
+// GetUsersWithRATCookie returns a page of at most l users that have a RAT cookie stored, which expires
+// after the given time and has not been marked as invalid. The list is ordered by ID and starts after the
+// user with the ID a, so that the next page can be requested with the ID of the last user of the previous page
+func (m UserModel) GetUsersWithRATCookie(a int64, l int, t time.Time) ([]*User, error) {
+	q := `SELECT u.id, u.user_id, u.enc_key, u.rat_expire, u.version, u.ctime, u.mtime
+            FROM users u
+           WHERE u.rat_expire > $1
+             AND u.id > $2
+             AND NOT EXISTS (SELECT 1 FROM user_prefs p WHERE p.user_id = u.id AND p.pref_key = $4)
+           ORDER BY u.id
+           LIMIT $3`
 
-	var ul []*User
 	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
 	defer cancel()
-	rows, err := m.DB.QueryContext(ctx, q)
+	return m.queryUsers(ctx, q, t, a, l, UserPrefSoTAuthTokenInvalid)
+}
+
+// SetRATExpire stores the expiration time of the users RAT cookie. A zero time removes the expiration
+func (m UserModel) SetRATExpire(u *User, t time.Time) error {
+	if u == nil {
+		return ErrUserNil
+	}
+	q := `UPDATE users SET rat_expire = $2, mtime = NOW() WHERE id = $1`
+	var te *time.Time
+	if !t.IsZero() {
+		te = &t
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	if _, err := m.DB.ExecContext(ctx, q, u.ID, te); err != nil {
+		return err
+	}
+	u.RATExpire = te
+	return nil
+}
+
+// BackfillRATExpire copies the encrypted RAT cookie expiration user preference into the rat_expire column
+// for all users that have not been migrated yet. It returns the number of updated users
+func (m UserModel) BackfillRATExpire() (int64, error) {
+	q := `SELECT u.id, u.user_id, u.enc_key, u.rat_expire, u.version, u.ctime, u.mtime
+            FROM users u
+           WHERE u.rat_expire IS NULL
+             AND EXISTS (SELECT 1 FROM user_prefs p WHERE p.user_id = u.id AND p.pref_key = $1)
+           ORDER BY u.id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	ul, err := m.queryUsers(ctx, q, UserPrefSoTAuthTokenExpiration)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, u := range ul {
+		te, err := m.GetPrefInt64Enc(u, UserPrefSoTAuthTokenExpiration)
+		if err != nil {
+			return n, fmt.Errorf("failed to read RAT cookie expiration of user %d: %w", u.ID, err)
+		}
+		if err := m.SetRATExpire(u, time.Unix(te, 0)); err != nil {
+			return n, fmt.Errorf("failed to store RAT cookie expiration of user %d: %w", u.ID, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// queryUsers executes the given query and returns the list of resulting users. The query needs to
+// select all columns of the users table in the order of the User struct
+func (m UserModel) queryUsers(ctx context.Context, q string, a ...interface{}) ([]*User, error) {
+	var ul []*User
+	rows, err := m.DB.QueryContext(ctx, q, a...)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
 	for rows.Next() {
-		var id string
-		err := rows.Scan(&id)
-		if err != nil {
-			return nil, err
-		}
-		g, err := m.GetByUserID(id)
+		var u User
+		err := rows.Scan(&u.ID, &u.UserID, &u.EncryptionKey, &u.RATExpire, &u.Version, &u.CreateTime,
+			&u.ModTime)
 		if err != nil {
 			return nil, err
 		}
-		ul = append(ul, g)
+		ul = append(ul, &u)
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// likeEscaper escapes the wildcards and the escape character of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// UserAchievementModel wraps the connection pool.
+type UserAchievementModel struct {
+	DB *sql.DB
+}
+
+// UserAchievement represents an unlocked Sea of Thieves achievement of a user in the database. The
+// CreateTime is the time the achievement was first seen by the bot
+type UserAchievement struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"userId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MediaURL    string    `json:"mediaUrl"`
+	Sort        int       `json:"sort"`
+	CreateTime  time.Time `json:"createTime"`
+}
+
+// Count returns the number of stored achievements of the given User ID
+func (m UserAchievementModel) Count(i int64) (int, error) {
+	q := `SELECT COUNT(a.id) FROM user_achievements a WHERE a.user_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	var n int
+	err := m.DB.QueryRowContext(ctx, q, i).Scan(&n)
+	return n, err
+}
+
+// GetAllByUserID retrieves all achievements of the given User ID from the database
+func (m UserAchievementModel) GetAllByUserID(i int64) ([]*UserAchievement, error) {
+	q := `SELECT id, user_id, name, description, media_url, sort, ctime
+            FROM user_achievements a
+           WHERE a.user_id = $1
+           ORDER BY ctime DESC, sort`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	return m.queryAchievements(ctx, q, i)
+}
+
+// Search retrieves all achievements of the given User ID whose name or description contain the given
+// search term. Wildcards in the search term are matched literally
+func (m UserAchievementModel) Search(i int64, s string) ([]*UserAchievement, error) {
+	q := `SELECT id, user_id, name, description, media_url, sort, ctime
+            FROM user_achievements a
+           WHERE a.user_id = $1
+             AND (a.name ILIKE '%' || $2 || '%' ESCAPE '\'
+                  OR a.description ILIKE '%' || $2 || '%' ESCAPE '\')
+           ORDER BY ctime DESC, sort`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	return m.queryAchievements(ctx, q, i, likeEscaper.Replace(s))
+}
+
+// queryAchievements runs the given achievement query and returns the resulting list of achievements
+func (m UserAchievementModel) queryAchievements(ctx context.Context, q string, a ...interface{},
+) ([]*UserAchievement, error) {
+	var al []*UserAchievement
+	rows, err := m.DB.QueryContext(ctx, q, a...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ua UserAchievement
+		if err := rows.Scan(&ua.ID, &ua.UserID, &ua.Name, &ua.Description, &ua.MediaURL, &ua.Sort,
+			&ua.CreateTime); err != nil {
+			return nil, err
+		}
+		al = append(al, &ua)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return al, nil
+}
+
+// Insert adds a new UserAchievement into the database. It returns false if the user's achievement was
+// already stored before
+func (m UserAchievementModel) Insert(ua *UserAchievement) (bool, error) {
+	q := `INSERT INTO user_achievements (user_id, name, description, media_url, sort)
+               VALUES ($1, $2, $3, $4, $5)
+          ON CONFLICT DO NOTHING
+            RETURNING id, ctime`
+	v := []interface{}{ua.UserID, ua.Name, ua.Description, ua.MediaURL, ua.Sort}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, q, v...).Scan(&ua.ID, &ua.CreateTime)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrUserGoalNotExistent should be used in case a requested user goal was not found in the database
+var ErrUserGoalNotExistent = errors.New("requested user goal not existent in database")
+
+// UserGoalModel wraps the connection pool.
+type UserGoalModel struct {
+	DB *sql.DB
+}
+
+// UserGoal represents a personal goal of a user in the database
+type UserGoal struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"userId"`
+	Metric       string     `json:"metric"`
+	Emissary     string     `json:"emissary,omitempty"`
+	Target       int64      `json:"target"`
+	StartValue   int64      `json:"startValue"`
+	Value        int64      `json:"value"`
+	CompleteTime *time.Time `json:"completeTime,omitempty"`
+	CreateTime   time.Time  `json:"createTime"`
+	ModifyTime   time.Time  `json:"modifyTime"`
+}
+
+// GetByUserID retrieves all goals of the given User ID from the database
+func (m UserGoalModel) GetByUserID(i int64) ([]*UserGoal, error) {
+	q := `SELECT id, user_id, metric, emissary, target, start_val, cur_val, completed, ctime, mtime
+            FROM user_goals g
+           WHERE g.user_id = $1
+           ORDER BY id`
+	return m.queryGoals(q, i)
+}
+
+// GetOpenByUserID retrieves the goals of the given User ID that are not completed yet from the database
+func (m UserGoalModel) GetOpenByUserID(i int64) ([]*UserGoal, error) {
+	q := `SELECT id, user_id, metric, emissary, target, start_val, cur_val, completed, ctime, mtime
+            FROM user_goals g
+           WHERE g.user_id = $1
+             AND g.completed IS NULL
+           ORDER BY id`
+	return m.queryGoals(q, i)
+}
+
+// queryGoals runs the given goal query and returns the resulting list of goals
+func (m UserGoalModel) queryGoals(q string, a ...interface{}) ([]*UserGoal, error) {
+	var gl []*UserGoal
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, a...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var g UserGoal
+		err := rows.Scan(&g.ID, &g.UserID, &g.Metric, &g.Emissary, &g.Target, &g.StartValue, &g.Value,
+			&g.CompleteTime, &g.CreateTime, &g.ModifyTime)
+		if err != nil {
+			return nil, err
+		}
+		gl = append(gl, &g)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return gl, nil
+}
+
+// Insert adds a new UserGoal into the database
+func (m UserGoalModel) Insert(g *UserGoal) error {
+	q := `INSERT INTO user_goals (user_id, metric, emissary, target, start_val, cur_val)
+               VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id, ctime, mtime`
+	v := []interface{}{g.UserID, g.Metric, g.Emissary, g.Target, g.StartValue, g.Value}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, v...)
+	return row.Scan(&g.ID, &g.CreateTime, &g.ModifyTime)
+}
+
+// Update stores the current value and completion time of the given UserGoal in the database
+func (m UserGoalModel) Update(g *UserGoal) error {
+	q := `UPDATE user_goals SET cur_val = $1, completed = $2, mtime = NOW()
+           WHERE id = $3
+       RETURNING mtime`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, g.Value, g.CompleteTime, g.ID)
+	if err := row.Scan(&g.ModifyTime); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserGoalNotExistent
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes the goal with the given ID of the given User ID from the database
+func (m UserGoalModel) Delete(i, gi int64) error {
+	q := `DELETE FROM user_goals g WHERE g.user_id = $1 AND g.id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	r, err := m.DB.ExecContext(ctx, q, i, gi)
+	if err != nil {
+		return err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return ErrUserGoalNotExistent
+	}
+	return nil
+}
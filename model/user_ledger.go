@@ -0,0 +1,139 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// UserLedgerModel wraps the connection pool.
+type UserLedgerModel struct {
+	DB *sql.DB
+}
+
+// UserLedger represents the emissary ledger position of a user in the database
+type UserLedger struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"userId"`
+	Emissary   string    `json:"emissary"`
+	Band       int64     `json:"band"`
+	Rank       int64     `json:"rank"`
+	Score      int64     `json:"score"`
+	NextRank   int64     `json:"nextRank"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+// GetAllByUserID retrieves the full ledger history of all emissaries from the database based on the
+// given User ID
+func (m UserLedgerModel) GetAllByUserID(i int64) ([]*UserLedger, error) {
+	q := `SELECT id, user_id, emissary, COALESCE(band, 0), rank, COALESCE(score, 0), COALESCE(next_rank, 0), ctime
+            FROM user_ledger l
+           WHERE l.user_id = $1
+           ORDER BY id`
+
+	var ll []*UserLedger
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ul UserLedger
+		err := rows.Scan(&ul.ID, &ul.UserID, &ul.Emissary, &ul.Band, &ul.Rank, &ul.Score, &ul.NextRank,
+			&ul.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		ll = append(ll, &ul)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ll, nil
+}
+
+// GetByUserID retrieves the latest ledger position of the given emissary from the database based on the
+// given User ID
+func (m UserLedgerModel) GetByUserID(i int64, e string) (*UserLedger, error) {
+	q := `SELECT id, user_id, emissary, COALESCE(band, 0), rank, COALESCE(score, 0), COALESCE(next_rank, 0), ctime
+            FROM user_ledger l
+           WHERE l.user_id = $1
+             AND l.emissary = $2
+           ORDER BY id DESC
+           LIMIT 1`
+
+	var ul UserLedger
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, i, e)
+	err := row.Scan(&ul.ID, &ul.UserID, &ul.Emissary, &ul.Band, &ul.Rank, &ul.Score, &ul.NextRank, &ul.CreateTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return &ul, ErrUserLedgerNotExistent
+		default:
+			return &ul, err
+		}
+	}
+	return &ul, nil
+}
+
+// GetRangeByUserID retrieves the ledger history of the given emissary between f and t from the database
+// based on the given User ID
+func (m UserLedgerModel) GetRangeByUserID(i int64, e string, f, t time.Time) ([]*UserLedger, error) {
+	q := `SELECT id, user_id, emissary, COALESCE(band, 0), rank, COALESCE(score, 0), COALESCE(next_rank, 0), ctime
+            FROM user_ledger l
+           WHERE l.user_id = $1
+             AND l.emissary = $2
+             AND l.ctime >= $3
+             AND l.ctime <= $4
+           ORDER BY ctime`
+
+	var ll []*UserLedger
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i, e, f, t)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ul UserLedger
+		err := rows.Scan(&ul.ID, &ul.UserID, &ul.Emissary, &ul.Band, &ul.Rank, &ul.Score, &ul.NextRank,
+			&ul.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		ll = append(ll, &ul)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ll, nil
+}
+
+// Insert adds a new ledger position of a user into the database
+func (m UserLedgerModel) Insert(ul *UserLedger) error {
+	q := `INSERT INTO user_ledger (user_id, emissary, band, rank, score, next_rank)
+               VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id, ctime`
+	v := []interface{}{ul.UserID, ul.Emissary, ul.Band, ul.Rank, ul.Score, ul.NextRank}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, v...)
+	return row.Scan(&ul.ID, &ul.CreateTime)
+}
+
+// Downsample rolls up the user ledger with a ctime between f and t into buckets of the given
+// Granularity and returns the number of removed rows
+func (m UserLedgerModel) Downsample(f, t time.Time, g Granularity) (int64, error) {
+	return downsample(m.DB, "user_ledger", "r.user_id, r.emissary", f, t, g)
+}
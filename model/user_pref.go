@@ -7,6 +7,7 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/wneessen/arrgo/crypto"
 )
@@ -20,10 +21,59 @@ const (
 	UserPrefSoTAuthToken           UserPrefKey = "rat_token"
 	UserPrefSoTAuthTokenExpiration UserPrefKey = "rat_token_expire"
 	UserPrefSoTAuthTokenNotified   UserPrefKey = "rat_expiry_notified"
+	UserPrefSoTAuthTokenInvalid    UserPrefKey = "rat_invalid"
+	UserPrefRATReminderStages      UserPrefKey = "rat_reminder_stages"
+	UserPrefNotifyQuietHours       UserPrefKey = "notify_quiet_hours"
+	UserPrefNotifyQuietTZ          UserPrefKey = "notify_quiet_tz"
+	UserPrefLanguage               UserPrefKey = "language"
+	UserPrefLocale                 UserPrefKey = "locale"
+	UserPrefDistanceUnit           UserPrefKey = "distance_unit"
+	UserPrefNumberGrouping         UserPrefKey = "number_grouping"
+	UserPrefTimezone               UserPrefKey = "timezone"
+	UserPrefClock                  UserPrefKey = "clock"
 	UserPrefPlaysSoT               UserPrefKey = "plays_sot"
 	UserPrefPlaysSoTStartTime      UserPrefKey = "plays_sot_start"
+	UserPrefPlaysSoTEndTime        UserPrefKey = "plays_sot_end"
+	UserPrefStatsPrivate           UserPrefKey = "stats_private"
 )
 
+// UserPrefRATReminderNotifiedPrefix is the common prefix of the per-stage RAT cookie reminder state keys
+const UserPrefRATReminderNotifiedPrefix = "rat_reminder_notified_"
+
+// UserPrefRATReminderNotified returns the UserPrefKey that tracks if the RAT cookie reminder for the
+// given stage (duration before expiry) has been sent
+func UserPrefRATReminderNotified(d time.Duration) UserPrefKey {
+	return UserPrefKey(UserPrefRATReminderNotifiedPrefix + d.String())
+}
+
+// UserPrefNotifyDelivery returns the UserPrefKey of the delivery method for the given notification type
+func UserPrefNotifyDelivery(t string) UserPrefKey {
+	return UserPrefKey("notify_" + t + "_delivery")
+}
+
+// UserPrefNotifyChannel returns the UserPrefKey of the delivery channel for the given notification type
+func UserPrefNotifyChannel(t string) UserPrefKey {
+	return UserPrefKey("notify_" + t + "_channel")
+}
+
+// UserPrefNotifyRole returns the UserPrefKey of the role to mention for the given notification type
+func UserPrefNotifyRole(t string) UserPrefKey {
+	return UserPrefKey("notify_" + t + "_role")
+}
+
+// UserPref represents a single user preference in the database. The Value of encrypted preferences is
+// never decrypted and always set to RedactedPrefValue
+type UserPref struct {
+	Key        UserPrefKey `json:"key"`
+	Value      interface{} `json:"value"`
+	Encrypted  bool        `json:"encrypted"`
+	CreateTime time.Time   `json:"createTime"`
+	ModTime    time.Time   `json:"modTime"`
+}
+
+// RedactedPrefValue is the value that is returned for encrypted user preferences by GetPrefs
+const RedactedPrefValue = "[redacted]"
+
 // GetPrefString fetches a client-specific setting from the database as string type
 func (m UserModel) GetPrefString(u *User, k UserPrefKey) (string, error) {
 	return getUserPref[string](m, u, k)
@@ -164,6 +214,90 @@ func (m UserModel) SetPrefEnc(u *User, k UserPrefKey, v interface{}) error {
 	return nil
 }
 
+// DeletePref removes a user-specific setting from the database
+func (m UserModel) DeletePref(u *User, k UserPrefKey) error {
+	if u == nil {
+		return ErrUserNil
+	}
+	q := `DELETE FROM user_prefs WHERE user_id = $1 AND pref_key = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, u.ID, k)
+	return err
+}
+
+// DeletePrefsWithPrefix removes all user-specific settings with a key starting with the given prefix
+// from the database
+func (m UserModel) DeletePrefsWithPrefix(u *User, p string) error {
+	if u == nil {
+		return ErrUserNil
+	}
+	q := `DELETE FROM user_prefs WHERE user_id = $1 AND POSITION($2 IN pref_key) = 1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, q, u.ID, p)
+	return err
+}
+
+// GetPrefs returns all preferences of a user. Encrypted preferences are redacted
+func (m UserModel) GetPrefs(u *User) ([]*UserPref, error) {
+	if u == nil {
+		return nil, ErrUserNil
+	}
+	q := `SELECT pref_key, pref_val, is_enc, ctime, mtime
+            FROM user_prefs u
+           WHERE u.user_id = $1
+           ORDER BY pref_key`
+
+	var pl []*UserPref
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var p UserPref
+		var bv []byte
+		if err := rows.Scan(&p.Key, &bv, &p.Encrypted, &p.CreateTime, &p.ModTime); err != nil {
+			return nil, err
+		}
+		p.Value = RedactedPrefValue
+		if !p.Encrypted {
+			p.Value = decodePrefVal(bv)
+		}
+		pl = append(pl, &p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return pl, nil
+}
+
+// decodePrefVal decodes a gob-encoded preference value without knowing its type. Values that are
+// neither string, bool nor a number are returned as raw bytes
+func decodePrefVal(bv []byte) interface{} {
+	var sv string
+	if err := gob.NewDecoder(bytes.NewReader(bv)).Decode(&sv); err == nil {
+		return sv
+	}
+	var bo bool
+	if err := gob.NewDecoder(bytes.NewReader(bv)).Decode(&bo); err == nil {
+		return bo
+	}
+	var iv int64
+	if err := gob.NewDecoder(bytes.NewReader(bv)).Decode(&iv); err == nil {
+		return iv
+	}
+	return bv
+}
+
 // getUserPref is a generic interface to fetch user-specific settings from the database
 // for different types
 func getUserPref[V string | bool | int | int64](m UserModel, u *User, k UserPrefKey) (V, error) {
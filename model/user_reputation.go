@@ -14,28 +14,29 @@ type UserReputationModel struct {
 
 // UserReputation represents the user reputation in the database
 type UserReputation struct {
-	ID                  int64     `json:"id"`
-	UserID              int64     `json:"userId"`
-	Emissary            string    `json:"emissary"`
-	Motto               string    `json:"motto"`
-	Rank                string    `json:"rank"`
-	Level               int64     `json:"lvl"`
-	Experience          int64     `json:"experience"`
-	NextLevel           int64     `json:"nextLevel"`
-	ExperienceNextLevel int64     `json:"experienceNextLevel"`
-	TitlesTotal         int64     `json:"titlesTotal"`
-	TitlesUnlocked      int64     `json:"titlesUnlocked"`
-	EmblemsTotal        int64     `json:"EmblemsTotal"`
-	EmblemsUnlocked     int64     `json:"EmblemsUnlocked"`
-	ItemsTotal          int64     `json:"ItemsTotal"`
-	ItemsUnlocked       int64     `json:"ItemsUnlocked"`
-	CreateTime          time.Time `json:"createTime"`
+	ID                  int64       `json:"id"`
+	UserID              int64       `json:"userId"`
+	Emissary            string      `json:"emissary"`
+	Motto               string      `json:"motto"`
+	Rank                string      `json:"rank"`
+	Level               int64       `json:"lvl"`
+	Experience          int64       `json:"experience"`
+	NextLevel           int64       `json:"nextLevel"`
+	ExperienceNextLevel int64       `json:"experienceNextLevel"`
+	TitlesTotal         int64       `json:"titlesTotal"`
+	TitlesUnlocked      int64       `json:"titlesUnlocked"`
+	EmblemsTotal        int64       `json:"EmblemsTotal"`
+	EmblemsUnlocked     int64       `json:"EmblemsUnlocked"`
+	ItemsTotal          int64       `json:"ItemsTotal"`
+	ItemsUnlocked       int64       `json:"ItemsUnlocked"`
+	Granularity         Granularity `json:"granularity"`
+	CreateTime          time.Time   `json:"createTime"`
 }
 
 // GetByUserID retrieves the User details from the database based on the given User ID
 func (m UserReputationModel) GetByUserID(i int64, e string) (*UserReputation, error) {
 	q := `SELECT id, user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, titlestotal, titlesunlocked, 
-       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, ctime
+       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, granularity, ctime
             FROM user_reputation r
            WHERE r.user_id = $1
              AND LOWER(r.emissary) = LOWER($2)
@@ -49,7 +50,7 @@ func (m UserReputationModel) GetByUserID(i int64, e string) (*UserReputation, er
 	row := m.DB.QueryRowContext(ctx, q, i, e)
 	err := row.Scan(&ur.ID, &ur.UserID, &ur.Emissary, &ur.Motto, &ur.Rank, &ur.Level, &ur.Experience,
 		&ur.NextLevel, &ur.ExperienceNextLevel, &ur.TitlesTotal, &ur.TitlesUnlocked, &ur.EmblemsTotal,
-		&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.CreateTime)
+		&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.Granularity, &ur.CreateTime)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -65,7 +66,7 @@ func (m UserReputationModel) GetByUserID(i int64, e string) (*UserReputation, er
 // point of time
 func (m UserReputationModel) GetByUserIDAtTime(i int64, e string, t time.Time) (*UserReputation, error) {
 	q := `SELECT id, user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, titlestotal, titlesunlocked, 
-       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, ctime
+       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, granularity, ctime
             FROM user_reputation r
            WHERE r.user_id = $1
              AND r.ctime >= $3
@@ -80,7 +81,7 @@ func (m UserReputationModel) GetByUserIDAtTime(i int64, e string, t time.Time) (
 	row := m.DB.QueryRowContext(ctx, q, i, e, t)
 	err := row.Scan(&ur.ID, &ur.UserID, &ur.Emissary, &ur.Motto, &ur.Rank, &ur.Level, &ur.Experience,
 		&ur.NextLevel, &ur.ExperienceNextLevel, &ur.TitlesTotal, &ur.TitlesUnlocked, &ur.EmblemsTotal,
-		&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.CreateTime)
+		&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.Granularity, &ur.CreateTime)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -92,12 +93,117 @@ func (m UserReputationModel) GetByUserIDAtTime(i int64, e string, t time.Time) (
 	return &ur, nil
 }
 
+// GetAllByUserID retrieves the full reputation history of all emissaries from the database based on the
+// given User ID
+func (m UserReputationModel) GetAllByUserID(i int64) ([]*UserReputation, error) {
+	q := `SELECT id, user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, titlestotal, titlesunlocked, 
+       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, granularity, ctime
+            FROM user_reputation r
+           WHERE r.user_id = $1
+           ORDER BY id`
+
+	var rl []*UserReputation
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ur UserReputation
+		err := rows.Scan(&ur.ID, &ur.UserID, &ur.Emissary, &ur.Motto, &ur.Rank, &ur.Level, &ur.Experience,
+			&ur.NextLevel, &ur.ExperienceNextLevel, &ur.TitlesTotal, &ur.TitlesUnlocked, &ur.EmblemsTotal,
+			&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.Granularity, &ur.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		rl = append(rl, &ur)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// GetRangeByUserID retrieves the reputation history of the given emissary between f and t from the
+// database based on the given User ID
+func (m UserReputationModel) GetRangeByUserID(i int64, e string, f, t time.Time) ([]*UserReputation, error) {
+	q := `SELECT id, user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, titlestotal, titlesunlocked, 
+       emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, granularity, ctime
+            FROM user_reputation r
+           WHERE r.user_id = $1
+             AND LOWER(r.emissary) = LOWER($2)
+             AND r.ctime >= $3
+             AND r.ctime <= $4
+           ORDER BY ctime`
+
+	var rl []*UserReputation
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i, e, f, t)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ur UserReputation
+		err := rows.Scan(&ur.ID, &ur.UserID, &ur.Emissary, &ur.Motto, &ur.Rank, &ur.Level, &ur.Experience,
+			&ur.NextLevel, &ur.ExperienceNextLevel, &ur.TitlesTotal, &ur.TitlesUnlocked, &ur.EmblemsTotal,
+			&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.Granularity, &ur.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		rl = append(rl, &ur)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// GetLatestByUserID retrieves the latest reputation of each emissary from the database based on the
+// given User ID
+func (m UserReputationModel) GetLatestByUserID(i int64) ([]*UserReputation, error) {
+	q := `SELECT DISTINCT ON (r.emissary) id, user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, 
+       titlestotal, titlesunlocked, emblemstotal, emblemsunlocked, itemstotal, itemsunlocked, granularity, ctime
+            FROM user_reputation r
+           WHERE r.user_id = $1
+           ORDER BY r.emissary, r.id DESC`
+
+	var rl []*UserReputation
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ur UserReputation
+		err := rows.Scan(&ur.ID, &ur.UserID, &ur.Emissary, &ur.Motto, &ur.Rank, &ur.Level, &ur.Experience,
+			&ur.NextLevel, &ur.ExperienceNextLevel, &ur.TitlesTotal, &ur.TitlesUnlocked, &ur.EmblemsTotal,
+			&ur.EmblemsUnlocked, &ur.ItemsTotal, &ur.ItemsUnlocked, &ur.Granularity, &ur.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		rl = append(rl, &ur)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
 // Insert adds a new User into the database
 func (m UserReputationModel) Insert(ur *UserReputation) error {
 	q := `INSERT INTO user_reputation (user_id, emissary, motto, rank, lvl, xp, next_lvl, xp_next_lvl, titlestotal, 
                              titlesunlocked, emblemstotal, emblemsunlocked, itemstotal, itemsunlocked)
                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-            RETURNING id, ctime`
+            RETURNING id, granularity, ctime`
 	v := []interface{}{
 		ur.UserID, ur.Emissary, ur.Motto, ur.Rank, ur.Level, ur.Experience, ur.NextLevel,
 		ur.ExperienceNextLevel, ur.TitlesTotal, ur.TitlesUnlocked, ur.EmblemsTotal, ur.EmblemsUnlocked,
@@ -108,9 +214,15 @@ func (m UserReputationModel) Insert(ur *UserReputation) error {
 	defer cancel()
 
 	row := m.DB.QueryRowContext(ctx, q, v...)
-	err := row.Scan(&ur.ID, &ur.CreateTime)
+	err := row.Scan(&ur.ID, &ur.Granularity, &ur.CreateTime)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// Downsample rolls up the user reputation with a ctime between f and t into buckets of the given
+// Granularity and returns the number of removed rows
+func (m UserReputationModel) Downsample(f, t time.Time, g Granularity) (int64, error) {
+	return downsample(m.DB, "user_reputation", "r.user_id, r.emissary", f, t, g)
+}
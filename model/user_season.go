@@ -0,0 +1,159 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUserSeasonNotExistent should be used in case a requested user season progress was not found in
+// the database
+var ErrUserSeasonNotExistent = errors.New("requested user season progress not existent in database")
+
+// UserSeasonModel wraps the connection pool.
+type UserSeasonModel struct {
+	DB *sql.DB
+}
+
+// UserSeasonProgress represents the progress of a user in a Sea of Thieves season in the database. For
+// archived seasons, the ArchiveTime is set
+type UserSeasonProgress struct {
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"userId"`
+	Season          string     `json:"season"`
+	Tier            int        `json:"tier"`
+	LevelProgress   float64    `json:"levelProgress"`
+	ChallengesDone  int        `json:"challengesDone"`
+	ChallengesTotal int        `json:"challengesTotal"`
+	CreateTime      time.Time  `json:"createTime"`
+	ArchiveTime     *time.Time `json:"archiveTime,omitempty"`
+}
+
+// GetLatestByUserID retrieves the latest stored season progress of the given User ID from the database
+func (m UserSeasonModel) GetLatestByUserID(i int64) (*UserSeasonProgress, error) {
+	q := `SELECT id, user_id, season, tier, level_progress, challenges_done, challenges_total, ctime
+            FROM user_season_progress s
+           WHERE s.user_id = $1
+           ORDER BY id DESC
+           LIMIT 1`
+	return m.queryProgress(q, i)
+}
+
+// GetByUserIDAtTime retrieves the first stored progress of the given User ID in the given season at or
+// after the given time from the database
+func (m UserSeasonModel) GetByUserIDAtTime(i int64, s string, t time.Time) (*UserSeasonProgress, error) {
+	q := `SELECT id, user_id, season, tier, level_progress, challenges_done, challenges_total, ctime
+            FROM user_season_progress s
+           WHERE s.user_id = $1
+             AND s.season = $2
+             AND s.ctime >= $3
+           ORDER BY ctime
+           LIMIT 1`
+	return m.queryProgress(q, i, s, t)
+}
+
+// queryProgress runs the given season progress query and returns the resulting progress
+func (m UserSeasonModel) queryProgress(q string, a ...interface{}) (*UserSeasonProgress, error) {
+	var sp UserSeasonProgress
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, a...)
+	err := row.Scan(&sp.ID, &sp.UserID, &sp.Season, &sp.Tier, &sp.LevelProgress, &sp.ChallengesDone,
+		&sp.ChallengesTotal, &sp.CreateTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return &sp, ErrUserSeasonNotExistent
+		default:
+			return &sp, err
+		}
+	}
+	return &sp, nil
+}
+
+// GetAllByUserID retrieves the full season progress history and the archived seasons of the given User ID
+// from the database
+func (m UserSeasonModel) GetAllByUserID(i int64) ([]*UserSeasonProgress, error) {
+	q := `SELECT id, user_id, season, tier, level_progress, challenges_done, challenges_total, ctime, NULL
+            FROM user_season_progress s
+           WHERE s.user_id = $1
+           UNION ALL
+          SELECT id, user_id, season, tier, level_progress, challenges_done, challenges_total, ctime, atime
+            FROM user_season_archive a
+           WHERE a.user_id = $1
+           ORDER BY ctime`
+
+	var sl []*UserSeasonProgress
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var sp UserSeasonProgress
+		err := rows.Scan(&sp.ID, &sp.UserID, &sp.Season, &sp.Tier, &sp.LevelProgress, &sp.ChallengesDone,
+			&sp.ChallengesTotal, &sp.CreateTime, &sp.ArchiveTime)
+		if err != nil {
+			return nil, err
+		}
+		sl = append(sl, &sp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// Insert adds a new UserSeasonProgress into the database
+func (m UserSeasonModel) Insert(sp *UserSeasonProgress) error {
+	q := `INSERT INTO user_season_progress (user_id, season, tier, level_progress, challenges_done,
+                                  challenges_total)
+               VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id, ctime`
+	v := []interface{}{sp.UserID, sp.Season, sp.Tier, sp.LevelProgress, sp.ChallengesDone, sp.ChallengesTotal}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, q, v...).Scan(&sp.ID, &sp.CreateTime)
+}
+
+// Archive stores the given (final) season progress in the season archive and removes the progress
+// history of that season of the user
+func (m UserSeasonModel) Archive(sp *UserSeasonProgress) error {
+	aq := `INSERT INTO user_season_archive (user_id, season, tier, level_progress, challenges_done,
+                                 challenges_total, ctime)
+               VALUES ($1, $2, $3, $4, $5, $6, $7)
+          ON CONFLICT DO NOTHING`
+	dq := `DELETE FROM user_season_progress s WHERE s.user_id = $1 AND s.season = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, aq, sp.UserID, sp.Season, sp.Tier, sp.LevelProgress, sp.ChallengesDone,
+		sp.ChallengesTotal, sp.CreateTime); err != nil {
+		return fmt.Errorf("failed to archive season progress: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, dq, sp.UserID, sp.Season); err != nil {
+		return fmt.Errorf("failed to remove archived season progress: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Downsample rolls up the user season progress with a ctime between f and t into buckets of the given
+// Granularity and returns the number of removed rows
+func (m UserSeasonModel) Downsample(f, t time.Time, g Granularity) (int64, error) {
+	return downsample(m.DB, "user_season_progress", "r.user_id, r.season", f, t, g)
+}
@@ -14,24 +14,26 @@ type UserStatModel struct {
 
 // UserStat represents the user statistics in the database
 type UserStat struct {
-	ID                int64     `json:"id"`
-	UserID            int64     `json:"userId"`
-	Title             string    `json:"title"`
-	Gold              int64     `json:"gold"`
-	Doubloons         int64     `json:"doubloons"`
-	AncientCoins      int64     `json:"ancientCoins"`
-	KrakenDefeated    int64     `json:"krakenDefeated"`
-	MegalodonEnounter int64     `json:"megalodonEnounter"`
-	ChestsHandedIn    int64     `json:"chestsHandedIn"`
-	ShipsSunk         int64     `json:"shipsSunk"`
-	VomittedTimes     int64     `json:"vomittedTimes"`
-	DistanceSailed    int64     `json:"distanceSailed"`
-	CreateTime        time.Time `json:"createTime"`
+	ID                int64       `json:"id"`
+	UserID            int64       `json:"userId"`
+	Title             string      `json:"title"`
+	Gold              int64       `json:"gold"`
+	Doubloons         int64       `json:"doubloons"`
+	AncientCoins      int64       `json:"ancientCoins"`
+	KrakenDefeated    int64       `json:"krakenDefeated"`
+	MegalodonEnounter int64       `json:"megalodonEnounter"`
+	ChestsHandedIn    int64       `json:"chestsHandedIn"`
+	ShipsSunk         int64       `json:"shipsSunk"`
+	VomittedTimes     int64       `json:"vomittedTimes"`
+	DistanceSailed    int64       `json:"distanceSailed"`
+	Granularity       Granularity `json:"granularity"`
+	CreateTime        time.Time   `json:"createTime"`
 }
 
 // GetByUserID retrieves the User details from the database based on the given User ID
 func (m UserStatModel) GetByUserID(i int64) (*UserStat, error) {
-	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance, ctime
+	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance,
+                 granularity, ctime
             FROM user_stats s
            WHERE s.user_id = $1
            ORDER BY id DESC
@@ -44,7 +46,7 @@ func (m UserStatModel) GetByUserID(i int64) (*UserStat, error) {
 	row := m.DB.QueryRowContext(ctx, q, i)
 	err := row.Scan(&us.ID, &us.UserID, &us.Title, &us.Gold, &us.Doubloons, &us.AncientCoins, &us.KrakenDefeated,
 		&us.MegalodonEnounter, &us.ChestsHandedIn, &us.ShipsSunk, &us.VomittedTimes, &us.DistanceSailed,
-		&us.CreateTime)
+		&us.Granularity, &us.CreateTime)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -59,7 +61,8 @@ func (m UserStatModel) GetByUserID(i int64) (*UserStat, error) {
 // GetByUserIDAtTime retrieves the User details from the database based on the given User ID at a specific
 // point of time
 func (m UserStatModel) GetByUserIDAtTime(i int64, t time.Time) (*UserStat, error) {
-	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance, ctime
+	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance,
+                 granularity, ctime
             FROM user_stats s
            WHERE s.user_id = $1
              AND s.ctime >= $2
@@ -73,7 +76,7 @@ func (m UserStatModel) GetByUserIDAtTime(i int64, t time.Time) (*UserStat, error
 	row := m.DB.QueryRowContext(ctx, q, i, t)
 	err := row.Scan(&us.ID, &us.UserID, &us.Title, &us.Gold, &us.Doubloons, &us.AncientCoins, &us.KrakenDefeated,
 		&us.MegalodonEnounter, &us.ChestsHandedIn, &us.ShipsSunk, &us.VomittedTimes, &us.DistanceSailed,
-		&us.CreateTime)
+		&us.Granularity, &us.CreateTime)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -85,12 +88,111 @@ func (m UserStatModel) GetByUserIDAtTime(i int64, t time.Time) (*UserStat, error
 	return &us, nil
 }
 
+// GetByUserIDBeforeTime retrieves the latest user stats from the database based on the given User ID
+// that have been stored at or before a specific point of time
+func (m UserStatModel) GetByUserIDBeforeTime(i int64, t time.Time) (*UserStat, error) {
+	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance,
+                 granularity, ctime
+            FROM user_stats s
+           WHERE s.user_id = $1
+             AND s.ctime <= $2
+           ORDER BY ctime DESC
+           LIMIT 1`
+
+	var us UserStat
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, i, t)
+	err := row.Scan(&us.ID, &us.UserID, &us.Title, &us.Gold, &us.Doubloons, &us.AncientCoins, &us.KrakenDefeated,
+		&us.MegalodonEnounter, &us.ChestsHandedIn, &us.ShipsSunk, &us.VomittedTimes, &us.DistanceSailed,
+		&us.Granularity, &us.CreateTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return &us, ErrUserStatNotExistent
+		default:
+			return &us, err
+		}
+	}
+	return &us, nil
+}
+
+// GetAllByUserID retrieves the full user stats history from the database based on the given User ID
+func (m UserStatModel) GetAllByUserID(i int64) ([]*UserStat, error) {
+	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance,
+                 granularity, ctime
+            FROM user_stats s
+           WHERE s.user_id = $1
+           ORDER BY id`
+
+	var sl []*UserStat
+	ctx, cancel := context.WithTimeout(context.Background(), SQLExportTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var us UserStat
+		err := rows.Scan(&us.ID, &us.UserID, &us.Title, &us.Gold, &us.Doubloons, &us.AncientCoins,
+			&us.KrakenDefeated, &us.MegalodonEnounter, &us.ChestsHandedIn, &us.ShipsSunk, &us.VomittedTimes,
+			&us.DistanceSailed, &us.Granularity, &us.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		sl = append(sl, &us)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// GetRangeByUserID retrieves the user stats history between f and t from the database based on the
+// given User ID
+func (m UserStatModel) GetRangeByUserID(i int64, f, t time.Time) ([]*UserStat, error) {
+	q := `SELECT id, user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, chests, ships, vomit, distance,
+                 granularity, ctime
+            FROM user_stats s
+           WHERE s.user_id = $1
+             AND s.ctime >= $2
+             AND s.ctime <= $3
+           ORDER BY ctime`
+
+	var sl []*UserStat
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, i, f, t)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var us UserStat
+		err := rows.Scan(&us.ID, &us.UserID, &us.Title, &us.Gold, &us.Doubloons, &us.AncientCoins,
+			&us.KrakenDefeated, &us.MegalodonEnounter, &us.ChestsHandedIn, &us.ShipsSunk, &us.VomittedTimes,
+			&us.DistanceSailed, &us.Granularity, &us.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		sl = append(sl, &us)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
 // Insert adds a new User into the database
 func (m UserStatModel) Insert(us *UserStat) error {
 	q := `INSERT INTO user_stats (user_id, title, gold, doubloons, ancient_coins, kraken, megalodon, 
                         chests, ships, vomit, distance)
                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-            RETURNING id, ctime`
+            RETURNING id, granularity, ctime`
 	v := []interface{}{
 		us.UserID, us.Title, us.Gold, us.Doubloons, us.AncientCoins, us.KrakenDefeated,
 		us.MegalodonEnounter, us.ChestsHandedIn, us.ShipsSunk, us.VomittedTimes, us.DistanceSailed,
@@ -100,9 +202,15 @@ func (m UserStatModel) Insert(us *UserStat) error {
 	defer cancel()
 
 	row := m.DB.QueryRowContext(ctx, q, v...)
-	err := row.Scan(&us.ID, &us.CreateTime)
+	err := row.Scan(&us.ID, &us.Granularity, &us.CreateTime)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// Downsample rolls up the user stats with a ctime between f and t into buckets of the given Granularity
+// and returns the number of removed rows
+func (m UserStatModel) Downsample(f, t time.Time, g Granularity) (int64, error) {
+	return downsample(m.DB, "user_stats", "r.user_id", f, t, g)
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrUserSubscriptionNotExistent should be used in case a requested user subscription was not found in
+// the database
+var ErrUserSubscriptionNotExistent = errors.New("requested user subscription not existent in database")
+
+// UserSubscriptionModel wraps the connection pool.
+type UserSubscriptionModel struct {
+	DB *sql.DB
+}
+
+// UserSubscription represents a personal topic subscription of a user in the database
+type UserSubscription struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"userId"`
+	Topic      string    `json:"topic"`
+	Filter     string    `json:"filter"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+// GetByUserID retrieves all subscriptions of the given User ID from the database
+func (m UserSubscriptionModel) GetByUserID(i int64) ([]*UserSubscription, error) {
+	q := `SELECT id, user_id, topic, filter, ctime
+            FROM user_subscriptions s
+           WHERE s.user_id = $1
+           ORDER BY id`
+	return m.querySubscriptions(q, i)
+}
+
+// GetByUserIDAndTopic retrieves the subscriptions of the given User ID to the given topic from the database
+func (m UserSubscriptionModel) GetByUserIDAndTopic(i int64, t string) ([]*UserSubscription, error) {
+	q := `SELECT id, user_id, topic, filter, ctime
+            FROM user_subscriptions s
+           WHERE s.user_id = $1
+             AND s.topic = $2
+           ORDER BY id`
+	return m.querySubscriptions(q, i, t)
+}
+
+// GetByTopic retrieves the subscriptions of all users to the given topic from the database
+func (m UserSubscriptionModel) GetByTopic(t string) ([]*UserSubscription, error) {
+	q := `SELECT id, user_id, topic, filter, ctime
+            FROM user_subscriptions s
+           WHERE s.topic = $1
+           ORDER BY user_id, id`
+	return m.querySubscriptions(q, t)
+}
+
+// querySubscriptions runs the given subscription query and returns the resulting list of subscriptions
+func (m UserSubscriptionModel) querySubscriptions(q string, a ...interface{}) ([]*UserSubscription, error) {
+	var sl []*UserSubscription
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+	rows, err := m.DB.QueryContext(ctx, q, a...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var s UserSubscription
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Topic, &s.Filter, &s.CreateTime); err != nil {
+			return nil, err
+		}
+		sl = append(sl, &s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// Insert adds a new UserSubscription into the database
+func (m UserSubscriptionModel) Insert(s *UserSubscription) error {
+	q := `INSERT INTO user_subscriptions (user_id, topic, filter)
+               VALUES ($1, $2, $3)
+            RETURNING id, ctime`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	row := m.DB.QueryRowContext(ctx, q, s.UserID, s.Topic, s.Filter)
+	return row.Scan(&s.ID, &s.CreateTime)
+}
+
+// Delete removes the subscription with the given ID of the given User ID from the database
+func (m UserSubscriptionModel) Delete(i, si int64) error {
+	q := `DELETE FROM user_subscriptions s WHERE s.user_id = $1 AND s.id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), SQLTimeout)
+	defer cancel()
+
+	r, err := m.DB.ExecContext(ctx, q, i, si)
+	if err != nil {
+		return err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return ErrUserSubscriptionNotExistent
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+// Package notify provides the central notification subsystem of ArrGo. Notifications are typed and
+// routed according to the preferences of the user and the guild they relate to. Notifications can be
+// deduplicated and are held back during the quiet hours of the user
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// Type represents the type of a notification
+type Type string
+
+// List of notification types
+const (
+	TypeCookieExpiry      Type = "cookie_expiry"
+	TypeSessionSummary    Type = "session_summary"
+	TypeMilestone         Type = "milestone"
+	TypeSubscriptionMatch Type = "subscription_match"
+)
+
+// Types is the list of all notification types
+var Types = []Type{TypeCookieExpiry, TypeSessionSummary, TypeMilestone, TypeSubscriptionMatch}
+
+// Status represents the outcome of a Notify call
+type Status string
+
+// List of notification statuses
+const (
+	StatusSent      Status = "sent"
+	StatusQueued    Status = "queued"
+	StatusMuted     Status = "muted"
+	StatusDuplicate Status = "duplicate"
+)
+
+// FlushBatchSize is the maximum number of queued notifications processed per Flush call
+const FlushBatchSize = 100
+
+// FlushMaxAttempts is the number of failed delivery attempts after which a queued notification is dropped
+const FlushMaxAttempts = 5
+
+// ErrUnknownType is returned if a notification type is not known
+var ErrUnknownType = errors.New("unknown notification type")
+
+// Sender is the subset of the Discord API that the Notifier uses to deliver notifications
+type Sender interface {
+	UserChannelCreate(rid string, ol ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelMessageSendComplex(cid string, d *discordgo.MessageSend,
+		ol ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+// Link represents a link button that is attached to a notification
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Notification represents a single notification for a user
+type Notification struct {
+	Type  Type                    `json:"type"`
+	User  *model.User             `json:"-"`
+	Embed *discordgo.MessageEmbed `json:"embed"`
+	Links []Link                  `json:"links,omitempty"`
+
+	// GuildID is the guild the notification relates to. It is required for channel and role delivery
+	GuildID string `json:"guildId,omitempty"`
+
+	// DedupKey makes sure that a notification is delivered only once per delivery target. An empty
+	// key disables the deduplication
+	DedupKey string `json:"dedupKey,omitempty"`
+
+	// NoPing suppresses the user mention for channel deliveries
+	NoPing bool `json:"noPing,omitempty"`
+}
+
+// queuedNotification is the payload of a queued notification. It keeps the dedup record that has been
+// written for the notification, so that it can be removed if the notification is dropped
+type queuedNotification struct {
+	Notification
+	DedupRecord string `json:"dedupRecord,omitempty"`
+}
+
+// Notifier routes and delivers notifications
+type Notifier struct {
+	log    zerolog.Logger
+	model  model.Model
+	sender Sender
+	now    func() time.Time
+}
+
+// Option is a function to override the defaults of the Notifier in the New() method
+type Option func(n *Notifier)
+
+// WithLogger sets the logger of the Notifier
+func WithLogger(l zerolog.Logger) Option {
+	return func(n *Notifier) {
+		n.log = l
+	}
+}
+
+// WithClock overrides the time source of the Notifier
+func WithClock(f func() time.Time) Option {
+	return func(n *Notifier) {
+		if f != nil {
+			n.now = f
+		}
+	}
+}
+
+// New returns a new Notifier
+func New(m model.Model, s Sender, ol ...Option) *Notifier {
+	n := &Notifier{log: zerolog.Nop(), model: m, sender: s, now: time.Now}
+	for _, o := range ol {
+		if o == nil {
+			continue
+		}
+		o(n)
+	}
+	return n
+}
+
+// ParseType returns the Type for the given string
+func ParseType(v string) (Type, error) {
+	for _, t := range Types {
+		if string(t) == v {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownType, v)
+}
+
+// Notify routes the notification according to the user and guild preferences and delivers it. During
+// the quiet hours of the user, the notification is queued until the quiet hours end
+func (n *Notifier) Notify(no *Notification) (Status, error) {
+	if no.User == nil {
+		return "", model.ErrUserNil
+	}
+	rt, err := n.Route(no)
+	if err != nil {
+		return "", err
+	}
+	if rt.Delivery == DeliveryMuted {
+		return StatusMuted, nil
+	}
+
+	dk := ""
+	if no.DedupKey != "" {
+		dk = rt.target() + ":" + no.DedupKey
+		ok, err := n.model.Notification.Log(no.User, string(no.Type), dk)
+		if err != nil {
+			return "", fmt.Errorf("failed to record notification: %w", err)
+		}
+		if !ok {
+			return StatusDuplicate, nil
+		}
+	}
+
+	qh, err := n.QuietHours(no.User)
+	if err != nil {
+		n.log.Warn().Msgf("failed to read quiet hours of user %d: %s", no.User.ID, err)
+	}
+	now := n.now()
+	if qh != nil && qh.Contains(now) {
+		if err := n.enqueue(no, dk, qh.End(now)); err != nil {
+			n.unlog(no, dk)
+			return "", err
+		}
+		return StatusQueued, nil
+	}
+
+	if err := n.deliver(no, rt); err != nil {
+		n.unlog(no, dk)
+		return "", err
+	}
+	return StatusSent, nil
+}
+
+// Flush delivers all queued notifications that are due and returns the number of delivered notifications
+func (n *Notifier) Flush() (int, error) {
+	ql, err := n.model.Notification.GetDue(n.now(), FlushBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch queued notifications: %w", err)
+	}
+	c := 0
+	for _, qn := range ql {
+		var qp queuedNotification
+		if err := json.Unmarshal(qn.Payload, &qp); err != nil {
+			n.log.Error().Msgf("failed to decode queued notification %d: %s", qn.ID, err)
+			_ = n.model.Notification.Dequeue(qn)
+			continue
+		}
+		no := &qp.Notification
+		no.User, err = n.model.User.GetByID(qn.UserID)
+		if err != nil {
+			n.retry(qn, qp.DedupRecord, fmt.Errorf("failed to look up user: %w", err))
+			continue
+		}
+
+		// The preferences might have changed since the notification has been queued
+		rt, err := n.Route(no)
+		if err != nil {
+			n.retry(qn, qp.DedupRecord, fmt.Errorf("failed to route notification: %w", err))
+			continue
+		}
+		if rt.Delivery != DeliveryMuted {
+			if err := n.deliver(no, rt); err != nil {
+				n.retry(qn, qp.DedupRecord, fmt.Errorf("failed to deliver notification: %w", err))
+				continue
+			}
+			c++
+		}
+		if err := n.model.Notification.Dequeue(qn); err != nil {
+			return c, fmt.Errorf("failed to remove notification from queue: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// retry records a failed delivery attempt of a queued notification, so that it is tried again on the
+// next Flush. After FlushMaxAttempts failed attempts, the notification is removed from the queue together
+// with its dedup record dk, so that the notification is not suppressed when it is sent again
+func (n *Notifier) retry(qn *model.QueuedNotification, dk string, err error) {
+	if qn.Attempts+1 >= FlushMaxAttempts {
+		n.log.Error().Msgf("dropping queued notification %d after %d failed attempts: %s", qn.ID,
+			qn.Attempts+1, err)
+		if err := n.model.Notification.Dequeue(qn); err != nil {
+			n.log.Error().Msgf("failed to remove notification from queue: %s", err)
+		}
+		n.unlog(&Notification{Type: Type(qn.Type), User: &model.User{ID: qn.UserID}}, dk)
+		return
+	}
+	n.log.Error().Msgf("queued notification %d failed (attempt %d of %d): %s", qn.ID, qn.Attempts+1,
+		FlushMaxAttempts, err)
+	if err := n.model.Notification.Retry(qn); err != nil {
+		n.log.Error().Msgf("failed to update queued notification: %s", err)
+	}
+}
+
+// enqueue stores the notification and its dedup record dk in the queue until the given time
+func (n *Notifier) enqueue(no *Notification, dk string, t time.Time) error {
+	pl, err := json.Marshal(queuedNotification{Notification: *no, DedupRecord: dk})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	qn := &model.QueuedNotification{UserID: no.User.ID, Type: string(no.Type), Payload: pl, NotBefore: t}
+	if err := n.model.Notification.Enqueue(qn); err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return nil
+}
+
+// unlog removes the dedup record of a notification that could not be delivered
+func (n *Notifier) unlog(no *Notification, dk string) {
+	if dk == "" {
+		return
+	}
+	if err := n.model.Notification.Unlog(no.User, string(no.Type), dk); err != nil {
+		n.log.Warn().Msgf("failed to remove notification record: %s", err)
+	}
+}
+
+// deliver sends the notification to the given Route
+func (n *Notifier) deliver(no *Notification, rt Route) error {
+	ms := &discordgo.MessageSend{AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	if no.Embed != nil {
+		ms.Embeds = []*discordgo.MessageEmbed{no.Embed}
+	}
+	if len(no.Links) > 0 {
+		var bl []discordgo.MessageComponent
+		for _, l := range no.Links {
+			bl = append(bl, discordgo.Button{Label: l.Label, Style: discordgo.LinkButton, URL: l.URL})
+		}
+		ms.Components = []discordgo.MessageComponent{discordgo.ActionsRow{Components: bl}}
+	}
+
+	cid := rt.ChannelID
+	switch rt.Delivery {
+	case DeliveryDM:
+		ch, err := n.sender.UserChannelCreate(no.User.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to create DM channel with user: %w", err)
+		}
+		cid = ch.ID
+	case DeliveryChannel:
+		if !no.NoPing {
+			ms.Content = fmt.Sprintf("<@%s>", no.User.UserID)
+			ms.AllowedMentions.Users = []string{no.User.UserID}
+		}
+	case DeliveryRole:
+		ms.Content = fmt.Sprintf("<@&%s>", rt.RoleID)
+		ms.AllowedMentions.Roles = []string{rt.RoleID}
+	}
+	if cid == "" {
+		return fmt.Errorf("no channel to deliver %s notification to", no.Type)
+	}
+	if _, err := n.sender.ChannelMessageSendComplex(cid, ms); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", no.Type, err)
+	}
+	return nil
+}
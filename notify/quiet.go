@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// ErrQuietHoursFormat is returned if quiet hours could not be parsed
+var ErrQuietHoursFormat = errors.New("quiet hours need to be given as HH:MM-HH:MM, e.g. 22:00-07:00")
+
+// QuietHours represents the daily period in which a user does not want to receive notifications
+type QuietHours struct {
+	Start    time.Duration
+	Stop     time.Duration
+	Location *time.Location
+}
+
+// ParseQuietHours parses quiet hours in the HH:MM-HH:MM format in the given IANA time zone. An empty
+// time zone is interpreted as UTC
+func ParseQuietHours(v, tz string) (*QuietHours, error) {
+	var sh, sm, eh, em int
+	if _, err := fmt.Sscanf(v, "%d:%d-%d:%d", &sh, &sm, &eh, &em); err != nil {
+		return nil, ErrQuietHoursFormat
+	}
+	if sh < 0 || sh > 23 || eh < 0 || eh > 23 || sm < 0 || sm > 59 || em < 0 || em > 59 {
+		return nil, ErrQuietHoursFormat
+	}
+	lo, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", tz)
+	}
+	qh := &QuietHours{
+		Start:    time.Duration(sh)*time.Hour + time.Duration(sm)*time.Minute,
+		Stop:     time.Duration(eh)*time.Hour + time.Duration(em)*time.Minute,
+		Location: lo,
+	}
+	if qh.Start == qh.Stop {
+		return nil, ErrQuietHoursFormat
+	}
+	return qh, nil
+}
+
+// String satisfies the fmt.Stringer interface for the QuietHours type
+func (q *QuietHours) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d (%s)", int(q.Start.Hours()), int(q.Start.Minutes())%60,
+		int(q.Stop.Hours()), int(q.Stop.Minutes())%60, q.Location)
+}
+
+// Contains returns true if the given time is within the QuietHours
+func (q *QuietHours) Contains(t time.Time) bool {
+	d := q.sinceMidnight(t)
+	if q.Start < q.Stop {
+		return d >= q.Start && d < q.Stop
+	}
+	return d >= q.Start || d < q.Stop
+}
+
+// End returns the next end of the QuietHours after the given time
+func (q *QuietHours) End(t time.Time) time.Time {
+	lt := t.In(q.Location)
+	e := time.Date(lt.Year(), lt.Month(), lt.Day(), 0, 0, 0, 0, q.Location).Add(q.Stop)
+	if !e.After(lt) {
+		e = time.Date(lt.Year(), lt.Month(), lt.Day()+1, 0, 0, 0, 0, q.Location).Add(q.Stop)
+	}
+	return e
+}
+
+// sinceMidnight returns the duration since local midnight of the given time
+func (q *QuietHours) sinceMidnight(t time.Time) time.Duration {
+	lt := t.In(q.Location)
+	return time.Duration(lt.Hour())*time.Hour + time.Duration(lt.Minute())*time.Minute +
+		time.Duration(lt.Second())*time.Second
+}
+
+// QuietHours returns the quiet hours of the given user or nil if the user has not configured any
+func (n *Notifier) QuietHours(u *model.User) (*QuietHours, error) {
+	v, err := n.userPref(u, model.UserPrefNotifyQuietHours)
+	if err != nil || v == "" {
+		return nil, err
+	}
+	tz, err := n.userPref(u, model.UserPrefNotifyQuietTZ)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuietHours(v, tz)
+}
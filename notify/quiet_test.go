@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // the tests must not depend on the zoneinfo database of the system
+)
+
+func TestParseQuietHours(t *testing.T) {
+	tt := []struct {
+		name  string
+		v     string
+		tz    string
+		start time.Duration
+		stop  time.Duration
+		str   string
+		err   error
+	}{
+		{"overnight", "22:00-07:00", "", time.Hour * 22, time.Hour * 7, "22:00-07:00 (UTC)", nil},
+		{"same day", "12:30-14:15", "UTC", time.Hour*12 + time.Minute*30, time.Hour*14 + time.Minute*15,
+			"12:30-14:15 (UTC)", nil},
+		{"time zone", "23:00-06:00", "Europe/Berlin", time.Hour * 23, time.Hour * 6,
+			"23:00-06:00 (Europe/Berlin)", nil},
+		{"single digits", "1:05-2:00", "", time.Hour + time.Minute*5, time.Hour * 2, "01:05-02:00 (UTC)", nil},
+		{"invalid format", "22-07", "", 0, 0, "", ErrQuietHoursFormat},
+		{"invalid hour", "24:00-07:00", "", 0, 0, "", ErrQuietHoursFormat},
+		{"invalid minute", "22:60-07:00", "", 0, 0, "", ErrQuietHoursFormat},
+		{"negative", "-1:00-07:00", "", 0, 0, "", ErrQuietHoursFormat},
+		{"empty period", "07:00-07:00", "", 0, 0, "", ErrQuietHoursFormat},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			qh, err := ParseQuietHours(tc.v, tc.tz)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("ParseQuietHours(%q) failed, expected error: %s, got: %v", tc.v, tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuietHours(%q) failed: %s", tc.v, err)
+			}
+			if qh.Start != tc.start || qh.Stop != tc.stop {
+				t.Errorf("ParseQuietHours(%q) failed, expected: %s-%s, got: %s-%s", tc.v, tc.start, tc.stop,
+					qh.Start, qh.Stop)
+			}
+			if qh.String() != tc.str {
+				t.Errorf("ParseQuietHours(%q) failed, expected string: %q, got: %q", tc.v, tc.str, qh.String())
+			}
+		})
+	}
+}
+
+func TestParseQuietHours_unknownTimeZone(t *testing.T) {
+	if _, err := ParseQuietHours("22:00-07:00", "Atlantis/Sunken_City"); err == nil {
+		t.Error("ParseQuietHours was supposed to fail with an unknown time zone")
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	be, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %s", err)
+	}
+	tt := []struct {
+		name string
+		v    string
+		tz   string
+		t    time.Time
+		in   bool
+		end  time.Time
+	}{
+		{"overnight, before midnight", "22:00-07:00", "", time.Date(2023, 5, 10, 23, 0, 0, 0, time.UTC), true,
+			time.Date(2023, 5, 11, 7, 0, 0, 0, time.UTC)},
+		{"overnight, after midnight", "22:00-07:00", "", time.Date(2023, 5, 11, 3, 0, 0, 0, time.UTC), true,
+			time.Date(2023, 5, 11, 7, 0, 0, 0, time.UTC)},
+		{"overnight, at the end", "22:00-07:00", "", time.Date(2023, 5, 11, 7, 0, 0, 0, time.UTC), false,
+			time.Date(2023, 5, 12, 7, 0, 0, 0, time.UTC)},
+		{"overnight, during the day", "22:00-07:00", "", time.Date(2023, 5, 11, 12, 0, 0, 0, time.UTC), false,
+			time.Date(2023, 5, 12, 7, 0, 0, 0, time.UTC)},
+		{"same day, within", "12:00-14:00", "", time.Date(2023, 5, 11, 13, 0, 0, 0, time.UTC), true,
+			time.Date(2023, 5, 11, 14, 0, 0, 0, time.UTC)},
+		{"same day, before", "12:00-14:00", "", time.Date(2023, 5, 11, 11, 59, 0, 0, time.UTC), false,
+			time.Date(2023, 5, 11, 14, 0, 0, 0, time.UTC)},
+		{"time zone", "22:00-07:00", "Europe/Berlin", time.Date(2023, 5, 10, 21, 0, 0, 0, time.UTC), true,
+			time.Date(2023, 5, 11, 7, 0, 0, 0, be)},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			qh, err := ParseQuietHours(tc.v, tc.tz)
+			if err != nil {
+				t.Fatalf("ParseQuietHours(%q) failed: %s", tc.v, err)
+			}
+			if in := qh.Contains(tc.t); in != tc.in {
+				t.Errorf("Contains(%s) failed, expected: %t, got: %t", tc.t, tc.in, in)
+			}
+			if e := qh.End(tc.t); !e.Equal(tc.end) {
+				t.Errorf("End(%s) failed, expected: %s, got: %s", tc.t, tc.end, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wneessen/arrgo/model"
+)
+
+// Delivery represents the delivery method of a notification
+type Delivery string
+
+// List of delivery methods
+const (
+	DeliveryDefault Delivery = "default"
+	DeliveryDM      Delivery = "dm"
+	DeliveryChannel Delivery = "channel"
+	DeliveryRole    Delivery = "role"
+	DeliveryMuted   Delivery = "muted"
+)
+
+// Route represents where a notification is delivered to
+type Route struct {
+	Delivery  Delivery
+	ChannelID string
+	RoleID    string
+}
+
+// String satisfies the fmt.Stringer interface for the Route type
+func (r Route) String() string {
+	switch r.Delivery {
+	case DeliveryChannel:
+		return fmt.Sprintf("ping in <#%s>", r.ChannelID)
+	case DeliveryRole:
+		return fmt.Sprintf("<@&%s> mention in <#%s>", r.RoleID, r.ChannelID)
+	case DeliveryMuted:
+		return "muted"
+	default:
+		return "direct message"
+	}
+}
+
+// target returns the delivery target of the Route, which is part of the dedup key
+func (r Route) target() string {
+	switch r.Delivery {
+	case DeliveryChannel, DeliveryRole:
+		return "channel-" + r.ChannelID
+	default:
+		return string(r.Delivery)
+	}
+}
+
+// DefaultDelivery returns the Delivery of a notification Type if neither the user nor the guild
+// configured one
+func DefaultDelivery(t Type) Delivery {
+	if t == TypeSessionSummary {
+		return DeliveryChannel
+	}
+	return DeliveryDM
+}
+
+// Route resolves where the notification is delivered to. The preferences of the user take precedence
+// over the guild preferences, but a guild can mute channel and role deliveries of a notification type
+// on the guild
+func (n *Notifier) Route(no *Notification) (Route, error) {
+	u := no.User
+	t := string(no.Type)
+	d, err := n.userPref(u, model.UserPrefNotifyDelivery(t))
+	if err != nil {
+		return Route{}, err
+	}
+	rt := Route{Delivery: Delivery(d)}
+	rt.ChannelID, err = n.userPref(u, model.UserPrefNotifyChannel(t))
+	if err != nil {
+		return rt, err
+	}
+	rt.RoleID, err = n.userPref(u, model.UserPrefNotifyRole(t))
+	if err != nil {
+		return rt, err
+	}
+
+	var g *model.Guild
+	if no.GuildID != "" {
+		g, err = n.model.Guild.GetByGuildID(no.GuildID)
+		if err != nil && !errors.Is(err, model.ErrGuildNotExistent) {
+			return rt, fmt.Errorf("failed to look up guild: %w", err)
+		}
+		if err != nil {
+			g = nil
+		}
+	}
+	gd := ""
+	if g != nil {
+		gd, err = n.guildPref(g, model.GuildPrefNotifyDelivery(t))
+		if err != nil {
+			return rt, err
+		}
+	}
+	if rt.Delivery == "" || rt.Delivery == DeliveryDefault {
+		rt.Delivery = Delivery(gd)
+	}
+	if rt.Delivery == "" || rt.Delivery == DeliveryDefault {
+		rt.Delivery = DefaultDelivery(no.Type)
+	}
+	if rt.Delivery == DeliveryDM || rt.Delivery == DeliveryMuted {
+		return Route{Delivery: rt.Delivery}, nil
+	}
+
+	// Channel and role deliveries
+	if g != nil {
+		if Delivery(gd) == DeliveryMuted {
+			return Route{Delivery: DeliveryMuted}, nil
+		}
+		if no.Type == TypeSessionSummary {
+			ok, err := n.model.Guild.GetPrefBool(g, model.GuildPrefAnnounceSoTSummary)
+			if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+				return rt, fmt.Errorf("failed to read guild preference: %w", err)
+			}
+			if !ok {
+				return Route{Delivery: DeliveryMuted}, nil
+			}
+		}
+		if rt.ChannelID == "" {
+			rt.ChannelID, err = n.guildPref(g, model.GuildPrefNotifyChannel(t))
+			if err != nil {
+				return rt, err
+			}
+		}
+		if rt.ChannelID == "" {
+			rt.ChannelID = n.model.Guild.AnnouceChannel(g)
+		}
+		if rt.Delivery == DeliveryRole && rt.RoleID == "" {
+			rt.RoleID, err = n.guildPref(g, model.GuildPrefNotifyRole(t))
+			if err != nil {
+				return rt, err
+			}
+		}
+	}
+	if no.Type == TypeSessionSummary && g == nil {
+		return Route{Delivery: DeliveryMuted}, nil
+	}
+	if rt.Delivery == DeliveryRole && rt.RoleID == "" {
+		rt.Delivery = DeliveryChannel
+	}
+	if rt.ChannelID == "" {
+		return Route{Delivery: DeliveryDM}, nil
+	}
+	return rt, nil
+}
+
+// userPref returns the string user preference or an empty string if it is not set
+func (n *Notifier) userPref(u *model.User, k model.UserPrefKey) (string, error) {
+	v, err := n.model.User.GetPrefString(u, k)
+	if err != nil && !errors.Is(err, model.ErrUserPrefNotExistent) {
+		return "", fmt.Errorf("failed to read user preference %s: %w", k, err)
+	}
+	return v, nil
+}
+
+// guildPref returns the string guild preference or an empty string if it is not set
+func (n *Notifier) guildPref(g *model.Guild, k model.GuildPrefKey) (string, error) {
+	v, err := n.model.Guild.GetPrefString(g, k)
+	if err != nil && !errors.Is(err, model.ErrGuildPrefNotExistent) {
+		return "", fmt.Errorf("failed to read guild preference %s: %w", k, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,76 @@
+package replay
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a controllable clock that satisfies the bot.Clock interface. Time only moves forward
+// when Advance or AdvanceTo is called. Goroutines calling Sleep are blocked until the clock has
+// been advanced past their wake-up time
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+	sl  []sleeper
+}
+
+// sleeper represents a goroutine that is waiting for the clock to reach a certain time
+type sleeper struct {
+	until time.Time
+	ch    chan struct{}
+}
+
+// NewClock returns a new Clock that starts at the given time
+func NewClock(t time.Time) *Clock {
+	return &Clock{now: t}
+}
+
+// Now returns the current time of the Clock
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep blocks until the Clock has been advanced by at least the given duration
+func (c *Clock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mu.Lock()
+	s := sleeper{until: c.now.Add(d), ch: make(chan struct{})}
+	c.sl = append(c.sl, s)
+	c.mu.Unlock()
+	<-s.ch
+}
+
+// Advance moves the Clock forward by the given duration
+func (c *Clock) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo moves the Clock forward to the given time and wakes up all sleepers that are due.
+// The Clock never moves backwards
+func (c *Clock) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t
+	}
+	var sl []sleeper
+	for _, s := range c.sl {
+		if !s.until.After(c.now) {
+			close(s.ch)
+			continue
+		}
+		sl = append(sl, s)
+	}
+	c.sl = sl
+}
+
+// Sleepers returns the number of goroutines currently blocked in Sleep
+func (c *Clock) Sleepers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sl)
+}
@@ -0,0 +1,58 @@
+package replay
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock_SleepAdvanceTo(t *testing.T) {
+	st := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	c := NewClock(st)
+	c.Sleep(0)
+
+	wc := make(chan time.Duration, 2)
+	for _, d := range []time.Duration{time.Minute * 2, time.Minute} {
+		d := d
+		go func() {
+			c.Sleep(d)
+			wc <- d
+		}()
+	}
+	waitSleepers(t, c, 2)
+
+	c.AdvanceTo(st.Add(time.Second * 59))
+	if n := c.Sleepers(); n != 2 {
+		t.Errorf("AdvanceTo failed, expected 2 sleepers before the first wake-up time, got: %d", n)
+	}
+	c.AdvanceTo(st.Add(time.Minute))
+	if d := <-wc; d != time.Minute {
+		t.Errorf("AdvanceTo failed, expected the 1m sleeper to wake up first, got: %s", d)
+	}
+	if n := c.Sleepers(); n != 1 {
+		t.Errorf("AdvanceTo failed, expected 1 remaining sleeper, got: %d", n)
+	}
+
+	c.AdvanceTo(st)
+	if !c.Now().Equal(st.Add(time.Minute)) {
+		t.Errorf("AdvanceTo failed, the clock moved backwards to: %s", c.Now())
+	}
+	c.Advance(time.Minute)
+	if d := <-wc; d != time.Minute*2 {
+		t.Errorf("Advance failed, expected the 2m sleeper to wake up, got: %s", d)
+	}
+	if n := c.Sleepers(); n != 0 {
+		t.Errorf("Advance failed, expected no remaining sleeper, got: %d", n)
+	}
+}
+
+// waitSleepers waits until n goroutines are blocked in Sleep of the given Clock
+func waitSleepers(t *testing.T, c *Clock, n int) {
+	t.Helper()
+	dl := time.Now().Add(time.Second * 5)
+	for c.Sleepers() != n {
+		if time.Now().After(dl) {
+			t.Fatalf("expected %d sleepers, got: %d", n, c.Sleepers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,100 @@
+// Package replay feeds recorded gateway events into the handlers of a Bot, that runs against a
+// fake Discord session, a fake Sea of Thieves API and a controllable clock
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wneessen/arrgo/bot"
+	"github.com/wneessen/arrgo/config"
+	"github.com/wneessen/arrgo/discordfake"
+	"github.com/wneessen/arrgo/model"
+	"github.com/wneessen/arrgo/sotfake"
+)
+
+// DefaultSettle is the default amount of real time that the handlers are granted to process
+// an event, before the next event is dispatched
+const DefaultSettle = time.Millisecond * 100
+
+// maxLineSize is the maximum size of a single line in a recording
+const maxLineSize = 1024 * 1024 * 4
+
+// ErrNoModel is returned if a Replayer is initialized without the model stores of a dedicated database
+var ErrNoModel = errors.New("replay requires the model stores of a dedicated database")
+
+// Replayer replays recorded gateway events
+type Replayer struct {
+	Bot     *bot.Bot
+	Clock   *Clock
+	Session *discordfake.Session
+	SoT     *sotfake.Client
+	Settle  time.Duration
+}
+
+// New returns a new Replayer. Replaying a recording changes the data of the users in the database,
+// therefore the model stores of a dedicated database have to be provided. The Bot never opens the
+// database of the configuration on its own. The given options are passed to bot.New after the fake
+// session, SoT API client and clock, so they can be overridden
+func New(c *config.Config, m model.Model, ol ...bot.Option) (*Replayer, error) {
+	if m.User == nil {
+		return nil, ErrNoModel
+	}
+	r := &Replayer{
+		Clock:   NewClock(time.Time{}),
+		Session: discordfake.New(),
+		SoT:     sotfake.New(),
+		Settle:  DefaultSettle,
+	}
+	bo := []bot.Option{
+		bot.WithSession(r.Session), bot.WithSoTClient(r.SoT), bot.WithClock(r.Clock),
+		bot.WithModel(m),
+	}
+	b, err := bot.New(c, append(bo, ol...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize bot for replay: %w", err)
+	}
+	r.Bot = b
+	return r, nil
+}
+
+// Replay reads the recorded events from the given io.Reader and dispatches them one by one.
+// Before each event is dispatched, the Clock is advanced to the time of the recorded event. It
+// returns the number of dispatched events
+func (r *Replayer) Replay(rd io.Reader) (int, error) {
+	sc := bufio.NewScanner(rd)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	n := 0
+	l := 0
+	for sc.Scan() {
+		l++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var ev bot.RecordedEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			return n, fmt.Errorf("failed to parse event in line %d: %w", l, err)
+		}
+		r.Clock.AdvanceTo(ev.Time)
+		if err := r.Bot.DispatchEvent(ev); err != nil {
+			return n, fmt.Errorf("failed to dispatch event in line %d: %w", l, err)
+		}
+		n++
+		time.Sleep(r.Settle)
+	}
+	if err := sc.Err(); err != nil {
+		return n, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return n, nil
+}
+
+// Drain advances the Clock by the given duration, so that all handlers that are still waiting
+// for the clock (i. e. the delayed voyage summary) are able to finish
+func (r *Replayer) Drain(d time.Duration) {
+	r.Clock.Advance(d)
+	time.Sleep(r.Settle)
+}
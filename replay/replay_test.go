@@ -0,0 +1,149 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wneessen/arrgo/config"
+	"github.com/wneessen/arrgo/model"
+)
+
+// recording is a two-line JSONL recording of /time interactions of an unregistered user
+const recording = `{"time":"2023-05-10T12:00:00Z","type":"INTERACTION_CREATE","data":{"id":"1","type":2,` +
+	`"guild_id":"300","channel_id":"400","token":"t1","member":{"user":{"id":"200","username":"bob"}},` +
+	`"data":{"id":"900","name":"time","type":1}}}
+{"time":"2023-05-10T12:30:00Z","type":"INTERACTION_CREATE","data":{"id":"2","type":2,` +
+	`"guild_id":"300","channel_id":"400","token":"t2","member":{"user":{"id":"200","username":"bob"}},` +
+	`"data":{"id":"900","name":"time","type":1}}}
+`
+
+func TestReplayer_Replay(t *testing.T) {
+	c := &config.Config{}
+	c.Data.EncryptionKey = "0123456789abcdef0123456789abcdef"
+	c.Reminder.Stages = "24h,6h,1h"
+	db := sql.OpenDB(emptyDB{})
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	r, err := New(c, model.New(db, c))
+	if err != nil {
+		t.Fatalf("failed to create Replayer: %s", err)
+	}
+	r.Settle = time.Millisecond * 10
+
+	n, err := r.Replay(strings.NewReader(recording))
+	if err != nil {
+		t.Fatalf("Replay failed: %s", err)
+	}
+	if n != 2 {
+		t.Errorf("Replay failed, expected 2 dispatched events, got: %d", n)
+	}
+	if rl := r.Session.InteractionResponses(); len(rl) != 2 {
+		t.Errorf("Replay failed, expected 2 deferred interaction responses, got: %d", len(rl))
+	}
+	el := r.Session.InteractionEdits()
+	if len(el) != 2 {
+		t.Fatalf("Replay failed, expected 2 interaction edits, got: %d", len(el))
+	}
+	for k, ts := range []string{"2023-05-10T12:00:00Z", "2023-05-10T12:30:00Z"} {
+		et, _ := time.Parse(time.RFC3339, ts)
+		if len(el[k].Embeds) != 1 {
+			t.Fatalf("Replay failed, expected 1 embed in edit %d, got: %d", k, len(el[k].Embeds))
+		}
+		want := fmt.Sprintf("<t:%d:f>", et.Unix())
+		if !strings.Contains(el[k].Embeds[0].Description, want) {
+			t.Errorf("Replay failed, expected edit %d to show the recorded time %s, got: %s", k, want,
+				el[k].Embeds[0].Description)
+		}
+	}
+	if !r.Clock.Now().Equal(time.Date(2023, 5, 10, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("Replay failed, expected the clock at the time of the last event, got: %s", r.Clock.Now())
+	}
+}
+
+func TestReplayer_Replay_invalidLine(t *testing.T) {
+	c := &config.Config{}
+	c.Data.EncryptionKey = "0123456789abcdef0123456789abcdef"
+	db := sql.OpenDB(emptyDB{})
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	r, err := New(c, model.New(db, c))
+	if err != nil {
+		t.Fatalf("failed to create Replayer: %s", err)
+	}
+	n, err := r.Replay(strings.NewReader("\n{invalid"))
+	if err == nil || n != 0 {
+		t.Errorf("Replay failed, expected an error for the invalid line, got: %d events, %v", n, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Replay failed, expected the line number in the error, got: %s", err)
+	}
+}
+
+// emptyDB is a database/sql connector without data. Queries return no rows and all other statements
+// are reported to have affected a single row
+type emptyDB struct{}
+
+// Connect implements the driver.Connector interface
+func (emptyDB) Connect(context.Context) (driver.Conn, error) {
+	return emptyConn{}, nil
+}
+
+// Driver implements the driver.Connector interface
+func (emptyDB) Driver() driver.Driver {
+	return emptyDriver{}
+}
+
+// emptyDriver is the driver.Driver of the emptyDB. It can only be used via the emptyDB connector
+type emptyDriver struct{}
+
+// Open implements the driver.Driver interface
+func (emptyDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("the empty driver can only be used via its connector")
+}
+
+// emptyConn is a connection to the emptyDB
+type emptyConn struct{}
+
+// Prepare implements the driver.Conn interface. Prepared statements are not supported
+func (emptyConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported by the empty driver")
+}
+
+// Close implements the driver.Conn interface
+func (emptyConn) Close() error { return nil }
+
+// Begin implements the driver.Conn interface. Transactions are not supported
+func (emptyConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported by the empty driver")
+}
+
+// QueryContext implements the driver.QueryerContext interface
+func (emptyConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+// ExecContext implements the driver.ExecerContext interface
+func (emptyConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+// emptyRows is a result without rows
+type emptyRows struct{}
+
+// Columns implements the driver.Rows interface
+func (emptyRows) Columns() []string { return nil }
+
+// Close implements the driver.Rows interface
+func (emptyRows) Close() error { return nil }
+
+// Next implements the driver.Rows interface
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
@@ -0,0 +1,101 @@
+// Package sotfake provides a fake Sea of Thieves API client that answers requests with canned
+// responses instead of querying the real API
+package sotfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/wneessen/arrgo/bot"
+)
+
+// Response represents a canned API response
+type Response struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Client is a fake SoTClient
+type Client struct {
+	mu        sync.Mutex
+	requests  []string
+	responses map[string]Response
+}
+
+// New returns a new fake Client without any canned responses
+func New() *Client {
+	return &Client{responses: make(map[string]Response)}
+}
+
+// Set registers a canned response for the given URL. Query parameters of the requests are
+// ignored when the response is looked up
+func (c *Client) Set(u string, st int, b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[u] = Response{Status: st, Body: b}
+}
+
+// LoadFile reads canned responses from a JSON file. The file holds an object with the URLs as
+// keys and a Response as value. If the body of a Response is a JSON string, the unquoted string
+// is returned as body (i. e. for the HTML of the event-hub)
+func (c *Client) LoadFile(p string) error {
+	fb, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("failed to read fake SoT API responses: %w", err)
+	}
+	rl := make(map[string]Response)
+	if err := json.Unmarshal(fb, &rl); err != nil {
+		return fmt.Errorf("failed to parse fake SoT API responses: %w", err)
+	}
+	for u, r := range rl {
+		var s string
+		if err := json.Unmarshal(r.Body, &s); err == nil {
+			r.Body = []byte(s)
+		}
+		if r.Status == 0 {
+			r.Status = http.StatusOK
+		}
+		c.Set(u, r.Status, r.Body)
+	}
+	return nil
+}
+
+// HTTPReq generates a HTTPRequest the same way the real HTTPClient does
+func (c *Client) HTTPReq(p string, m bot.HTTPReqMethod, q map[string]string) (*bot.HTTPRequest, error) {
+	return (&bot.HTTPClient{}).HTTPReq(p, m, q)
+}
+
+// Fetch answers the request with the canned response for the URL. If no response is registered
+// a 404 is returned
+func (c *Client) Fetch(r *bot.HTTPRequest) ([]byte, *http.Response, error) {
+	u := *r.URL
+	u.RawQuery = ""
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, u.String())
+	cr, ok := c.responses[u.String()]
+	if !ok {
+		cr = Response{Status: http.StatusNotFound, Body: []byte(`{}`)}
+	}
+	res := &http.Response{
+		Status:     fmt.Sprintf("%d %s", cr.Status, http.StatusText(cr.Status)),
+		StatusCode: cr.Status,
+		Body:       io.NopCloser(bytes.NewReader(cr.Body)),
+		Request:    r.Request,
+	}
+	return cr.Body, res, nil
+}
+
+// Requests returns the list of URLs that have been requested from the fake Client
+func (c *Client) Requests() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rl := make([]string, len(c.requests))
+	copy(rl, c.requests)
+	return rl
+}
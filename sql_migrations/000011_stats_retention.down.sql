@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS user_reputation_user_id_emissary_ctime_idx;
+DROP INDEX IF EXISTS user_stats_user_id_ctime_idx;
+ALTER TABLE user_reputation DROP COLUMN granularity;
+ALTER TABLE user_stats DROP COLUMN granularity;
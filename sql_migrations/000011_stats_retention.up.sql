@@ -0,0 +1,4 @@
+ALTER TABLE user_stats ADD COLUMN granularity varchar(8) NOT NULL DEFAULT 'raw';
+ALTER TABLE user_reputation ADD COLUMN granularity varchar(8) NOT NULL DEFAULT 'raw';
+CREATE INDEX IF NOT EXISTS user_stats_user_id_ctime_idx ON user_stats (user_id, ctime);
+CREATE INDEX IF NOT EXISTS user_reputation_user_id_emissary_ctime_idx ON user_reputation (user_id, emissary, ctime);
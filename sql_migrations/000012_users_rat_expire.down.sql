@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS users_rat_expire_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS rat_expire;
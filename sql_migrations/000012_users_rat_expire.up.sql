@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN rat_expire timestamp(0) with time zone NULL;
+CREATE INDEX IF NOT EXISTS users_rat_expire_idx ON users (rat_expire);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_queue;
+DROP TABLE IF EXISTS notification_log;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS notification_log
+(
+    id        bigserial PRIMARY KEY,
+    user_id   bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    ntype     varchar(32)                 NOT NULL,
+    dedup_key varchar(255)                NOT NULL,
+    ctime     timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, ntype, dedup_key)
+);
+CREATE TABLE IF NOT EXISTS notification_queue
+(
+    id         bigserial PRIMARY KEY,
+    user_id    bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    ntype      varchar(32)                 NOT NULL,
+    payload    bytea                       NOT NULL,
+    not_before timestamp(0) with time zone NOT NULL,
+    ctime      timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS notification_queue_not_before_idx ON notification_queue (not_before);
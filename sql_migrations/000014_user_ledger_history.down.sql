@@ -0,0 +1 @@
+DROP INDEX IF EXISTS user_ledger_user_id_emissary_ctime_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS user_ledger_user_id_emissary_ctime_idx ON user_ledger (user_id, emissary, ctime);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_goals
+(
+    id          bigserial PRIMARY KEY,
+    user_id     bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    metric      varchar(32)                 NOT NULL,
+    emissary    varchar(32)                 NOT NULL DEFAULT '',
+    target      bigint                      NOT NULL,
+    start_val   bigint                      NOT NULL,
+    cur_val     bigint                      NOT NULL,
+    completed   timestamp(0) with time zone,
+    ctime       timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    mtime       timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS user_goals_user_id_idx ON user_goals (user_id);
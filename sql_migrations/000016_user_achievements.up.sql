@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_achievements
+(
+    id          bigserial PRIMARY KEY,
+    user_id     bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    name        varchar(255)                NOT NULL,
+    description text                        NOT NULL DEFAULT '',
+    media_url   text                        NOT NULL DEFAULT '',
+    sort        int                         NOT NULL DEFAULT 0,
+    ctime       timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_season_archive;
+DROP TABLE IF EXISTS user_season_progress;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS user_season_progress
+(
+    id               bigserial PRIMARY KEY,
+    user_id          bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    season           varchar(255)                NOT NULL,
+    tier             int                         NOT NULL,
+    level_progress   double precision            NOT NULL,
+    challenges_done  int                         NOT NULL,
+    challenges_total int                         NOT NULL,
+    ctime            timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS user_season_progress_user_id_season_ctime_idx
+    ON user_season_progress (user_id, season, ctime);
+CREATE TABLE IF NOT EXISTS user_season_archive
+(
+    id               bigserial PRIMARY KEY,
+    user_id          bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    season           varchar(255)                NOT NULL,
+    tier             int                         NOT NULL,
+    level_progress   double precision            NOT NULL,
+    challenges_done  int                         NOT NULL,
+    challenges_total int                         NOT NULL,
+    ctime            timestamp(0) with time zone NOT NULL,
+    atime            timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, season)
+);
@@ -0,0 +1 @@
+ALTER TABLE trade_routes DROP COLUMN validfrom;
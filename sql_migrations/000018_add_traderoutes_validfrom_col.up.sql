@@ -0,0 +1,3 @@
+ALTER TABLE trade_routes ADD COLUMN validfrom timestamp(0) with time zone NOT NULL DEFAULT NOW();
+UPDATE trade_routes
+   SET validfrom = (date_trunc('day', validthru AT TIME ZONE 'UTC') - INTERVAL '6 days') AT TIME ZONE 'UTC';
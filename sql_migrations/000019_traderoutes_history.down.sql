@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS trade_routes_validfrom_idx;
+ALTER TABLE trade_routes DROP CONSTRAINT IF EXISTS trade_routes_outpost_validfrom_key;
+DELETE FROM trade_routes t USING trade_routes n WHERE t.outpost = n.outpost AND t.validfrom < n.validfrom;
+ALTER TABLE trade_routes ADD CONSTRAINT trade_routes_outpost_key UNIQUE (outpost);
@@ -0,0 +1,3 @@
+ALTER TABLE trade_routes DROP CONSTRAINT IF EXISTS trade_routes_outpost_key;
+ALTER TABLE trade_routes ADD CONSTRAINT trade_routes_outpost_validfrom_key UNIQUE (outpost, validfrom);
+CREATE INDEX IF NOT EXISTS trade_routes_validfrom_idx ON trade_routes (validfrom);
@@ -0,0 +1 @@
+ALTER TABLE deeds DROP COLUMN reminded;
@@ -0,0 +1 @@
+ALTER TABLE deeds ADD COLUMN reminded timestamp(0) with time zone;
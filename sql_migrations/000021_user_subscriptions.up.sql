@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_subscriptions
+(
+    id          bigserial PRIMARY KEY,
+    user_id     bigint                      NOT NULL REFERENCES users ON DELETE CASCADE,
+    topic       varchar(32)                 NOT NULL,
+    filter      varchar(255)                NOT NULL DEFAULT '',
+    ctime       timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS user_subscriptions_user_id_idx ON user_subscriptions (user_id);
+CREATE INDEX IF NOT EXISTS user_subscriptions_topic_idx ON user_subscriptions (topic);
@@ -0,0 +1 @@
+ALTER TABLE notification_queue ADD COLUMN attempts integer NOT NULL DEFAULT 0;
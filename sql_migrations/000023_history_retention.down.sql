@@ -0,0 +1,2 @@
+ALTER TABLE user_season_progress DROP COLUMN granularity;
+ALTER TABLE user_ledger DROP COLUMN granularity;
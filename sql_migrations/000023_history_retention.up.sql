@@ -0,0 +1,2 @@
+ALTER TABLE user_ledger ADD COLUMN granularity varchar(8) NOT NULL DEFAULT 'raw';
+ALTER TABLE user_season_progress ADD COLUMN granularity varchar(8) NOT NULL DEFAULT 'raw';